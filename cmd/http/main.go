@@ -2,43 +2,201 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/kalogs-c/nerd-backlog/config"
 	"github.com/kalogs-c/nerd-backlog/internal/httpserver"
+	"github.com/kalogs-c/nerd-backlog/internal/jobs"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/memory"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/migrate"
 	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/sqlite"
+	sqlitemigrations "github.com/kalogs-c/nerd-backlog/internal/storage/sqlite/migrations"
+	"github.com/kalogs-c/nerd-backlog/internal/telemetry"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
 	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
 )
 
+const usage = `usage: nerd-backlog <command> [flags]
+
+commands:
+  serve                          run the HTTP server (default)
+  migrate up|down|status|redo    manage the database schema
+  version                        print the binary and schema versions
+`
+
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := slog.New(telemetry.NewLogHandler(slog.NewJSONHandler(os.Stdout, nil)))
+
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		serve(logger, args)
+	case "migrate":
+		runMigrate(logger, args)
+	case "version":
+		printVersion(os.Stdout)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func serve(logger *slog.Logger, args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	demo := flags.Bool("demo", false, "run in memory with seeded demo data")
+	autoMigrate := flags.Bool("auto-migrate", false, "apply pending Postgres migrations before serving")
+	_ = flags.Parse(args)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	config := config.NewHTTPConfig(config.Development)
+	cfg := config.NewHTTPConfig(config.Development)
+
+	shutdownTracing, err := telemetry.Setup(ctx, telemetry.Config{
+		Exporter:       cfg.TracingExporter,
+		File:           cfg.TracingFile,
+		ServiceVersion: binaryVersion(),
+	})
+	if err != nil {
+		logger.Error("failed to set up tracing", "err", err)
+		os.Exit(1)
+	}
 
-	db := postgres.MustConnect(ctx, config.DSN, logger)
-	queries := sqlc.New(db)
+	var (
+		repos   httpserver.Repositories
+		runner  *jobs.Runner
+		closeDB = func() {}
+	)
+	if *demo {
+		repos = httpserver.MemoryRepositories()
+		if err := memory.Seed(ctx, repos.Games, repos.Accounts); err != nil {
+			logger.Error("failed to seed demo data", "err", err)
+			os.Exit(1)
+		}
+		logger.Info("Running in demo mode, nothing is persisted", "email", memory.DemoEmail, "password", memory.DemoPassword)
+	} else {
+		repos, runner, closeDB = mustOpenStorage(ctx, cfg, logger, *autoMigrate)
+	}
 
 	server := httpserver.NewHTTPServer(
 		logger,
-		queries,
-		config,
+		repos,
+		cfg,
+		runner,
 		middleware.RequestID,
+		httpserver.WithTracing(),
 		middleware.Recoverer,
 		middleware.StripSlashes,
 		httpserver.WithLogging(logger),
 	)
 
-	go server.MustServe()
+	if err := server.Start(); err != nil {
+		logger.Error("failed to start server", "err", err)
+		closeDB()
+		os.Exit(1)
+	}
+	if runner != nil {
+		runner.Start(context.Background())
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	<-sigChan
+	exitCode := 0
+	select {
+	case sig := <-sigChan:
+		logger.Info("Shutting down", "signal", sig.String(), "timeout", cfg.ShutdownTimeout.String())
+	case err := <-server.Done():
+		logger.Error("error listening and serving", "err", err)
+		exitCode = 1
+	}
+
+	// Requests drain first, since they may enqueue jobs, along with the
+	// metadata refreshes they started, then the workers finish theirs, and
+	// only then does the database go away. All of it shares one deadline, so
+	// a long import can't hold the process past the container's grace period.
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("failed to drain requests", "err", err)
+		exitCode = 1
+	}
+	if runner != nil {
+		if err := runner.Stop(shutdownCtx); err != nil {
+			logger.Error("failed to stop job runner", "err", err)
+			exitCode = 1
+		}
+	}
+	cancelShutdown()
+	closeDB()
+
+	// Spans are batched, so flush the ones the shutdown itself produced.
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		logger.Error("failed to flush traces", "err", err)
+	}
+	cancelTracing()
+
 	logger.Info("Server gracefully stopped")
+	os.Exit(exitCode)
+}
+
+func mustOpenStorage(
+	ctx context.Context,
+	cfg *config.HTTPConfig,
+	logger *slog.Logger,
+	autoMigrate bool,
+) (httpserver.Repositories, *jobs.Runner, func()) {
+	driver, dsn, err := cfg.Storage()
+	if err != nil {
+		logger.Error("invalid database configuration", "err", err)
+		os.Exit(1)
+	}
+
+	switch driver {
+	case config.StorageSQLite:
+		db := sqlite.MustConnect(ctx, dsn)
+
+		// A SQLite deployment is a single binary, so it migrates itself.
+		if _, err := sqlitemigrations.MustProvide(db).Up(ctx); err != nil {
+			logger.Error("failed to migrate database", "err", err)
+			os.Exit(1)
+		}
+
+		return httpserver.SQLiteRepositories(db), nil, func() { _ = db.Close() }
+	default:
+		db := postgres.MustConnect(ctx, dsn, logger)
+
+		provider, err := migrations.Provide(db)
+		if err != nil {
+			logger.Error("failed to load migrations", "err", err)
+			os.Exit(1)
+		}
+		if autoMigrate {
+			// Replicas booting together queue on the provider's advisory
+			// lock, and the later ones find nothing left to apply.
+			if _, err := provider.Up(context.Background()); err != nil {
+				logger.Error("failed to migrate database", "err", err)
+				os.Exit(1)
+			}
+		}
+		if err := migrate.EnsureCurrent(ctx, provider); err != nil {
+			logger.Error("refusing to start, run `nerd-backlog migrate up` or pass -auto-migrate", "err", err)
+			os.Exit(1)
+		}
+
+		runner := jobs.NewRunner(jobs.NewRepository(sqlc.New(db)), logger, jobs.DefaultOptions())
+		return httpserver.PostgresRepositories(db), runner, db.Close
+	}
 }
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/kalogs-c/nerd-backlog/config"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/migrate"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/sqlite"
+	sqlitemigrations "github.com/kalogs-c/nerd-backlog/internal/storage/sqlite/migrations"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+)
+
+// runMigrate applies "migrate <command>" to the configured database.
+// Migrations may take a while, so it only stops on a signal.
+func runMigrate(logger *slog.Logger, args []string) {
+	if len(args) != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	provider, closeDB, err := openMigrations(ctx, config.NewHTTPConfig(config.Development), logger)
+	if err != nil {
+		logger.Error("failed to open database", "err", err)
+		os.Exit(1)
+	}
+	defer closeDB()
+
+	if err := migrate.Run(ctx, provider, args[0], os.Stdout); err != nil {
+		logger.Error("migrate "+args[0]+" failed", "err", err)
+		closeDB()
+		os.Exit(1)
+	}
+}
+
+func openMigrations(ctx context.Context, cfg *config.HTTPConfig, logger *slog.Logger) (*goose.Provider, func(), error) {
+	driver, dsn, err := cfg.Storage()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch driver {
+	case config.StorageSQLite:
+		db, err := sqlite.Connect(ctx, dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		provider, err := sqlitemigrations.Provide(db)
+		if err != nil {
+			_ = db.Close()
+			return nil, nil, err
+		}
+		return provider, func() { _ = db.Close() }, nil
+	default:
+		db, err := postgres.Connect(ctx, dsn, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		provider, err := migrations.Provide(db)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		return provider, db.Close, nil
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"runtime/debug"
+
+	"github.com/kalogs-c/nerd-backlog/internal/storage/migrate"
+	sqlitemigrations "github.com/kalogs-c/nerd-backlog/internal/storage/sqlite/migrations"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+)
+
+// version is set at build time with -ldflags "-X main.version=...".
+var version = ""
+
+func printVersion(out io.Writer) {
+	_, _ = fmt.Fprintf(out, "nerd-backlog %s\n", binaryVersion())
+	printSchemaVersion(out, "postgres", migrations.Embed)
+	printSchemaVersion(out, "sqlite", sqlitemigrations.Embed)
+}
+
+func printSchemaVersion(out io.Writer, driver string, fsys fs.FS) {
+	latest, err := migrate.Latest(fsys)
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "%s schema unknown: %v\n", driver, err)
+		return
+	}
+	_, _ = fmt.Fprintf(out, "%s schema %d\n", driver, latest)
+}
+
+// binaryVersion falls back to what the Go toolchain recorded when no version
+// was stamped in.
+func binaryVersion() string {
+	if version != "" {
+		return version
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+
+	return "dev"
+}
@@ -1,15 +1,46 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type Environment int
 
 const (
 	Development Environment = iota
 )
 
+type StorageDriver string
+
+const (
+	StoragePostgres StorageDriver = "postgres"
+	StorageSQLite   StorageDriver = "sqlite"
+)
+
 type HTTPConfig struct {
-	Host string
-	Port string
-	DSN  string
+	Host             string
+	Port             string
+	DSN              string
+	SteamAPIKey      string
+	SteamAPIBaseURL  string
+	IGDBClientID     string
+	IGDBClientSecret string
+	IGDBBaseURL      string
+	TwitchTokenURL   string
+	MetadataCacheTTL time.Duration
+	// ReadTimeout, WriteTimeout and IdleTimeout are passed to http.Server.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownTimeout bounds the whole shutdown after a signal: draining
+	// requests and their metadata refreshes, then in-flight jobs.
+	ShutdownTimeout time.Duration
+	// TracingExporter is "otlp", "stdout" or "file", and empty turns tracing
+	// off. TracingFile is where the file exporter writes.
+	TracingExporter string
+	TracingFile     string
 }
 
 func NewHTTPConfig(environment Environment) *HTTPConfig {
@@ -20,3 +51,20 @@ func NewHTTPConfig(environment Environment) *HTTPConfig {
 		return newDevHTTPConfig()
 	}
 }
+
+// Storage picks the backend from the DSN scheme. "sqlite:" DSNs have the
+// scheme stripped, and "file:" URIs are passed to SQLite unchanged.
+func (c *HTTPConfig) Storage() (StorageDriver, string, error) {
+	switch {
+	case strings.HasPrefix(c.DSN, "postgres://"), strings.HasPrefix(c.DSN, "postgresql://"):
+		return StoragePostgres, c.DSN, nil
+	case strings.HasPrefix(c.DSN, "sqlite://"):
+		return StorageSQLite, strings.TrimPrefix(c.DSN, "sqlite://"), nil
+	case strings.HasPrefix(c.DSN, "sqlite:"):
+		return StorageSQLite, strings.TrimPrefix(c.DSN, "sqlite:"), nil
+	case strings.HasPrefix(c.DSN, "file:"):
+		return StorageSQLite, c.DSN, nil
+	default:
+		return "", "", fmt.Errorf("unsupported database dsn scheme: %q", c.DSN)
+	}
+}
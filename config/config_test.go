@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPConfig_Storage(t *testing.T) {
+	tests := []struct {
+		dsn    string
+		driver StorageDriver
+		want   string
+	}{
+		{"postgres://u:p@localhost:5432/db", StoragePostgres, "postgres://u:p@localhost:5432/db"},
+		{"postgresql://localhost/db", StoragePostgres, "postgresql://localhost/db"},
+		{"sqlite:backlog.db", StorageSQLite, "backlog.db"},
+		{"sqlite:///var/lib/backlog.db", StorageSQLite, "/var/lib/backlog.db"},
+		{"file:backlog.db?cache=shared", StorageSQLite, "file:backlog.db?cache=shared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			driver, dsn, err := (&HTTPConfig{DSN: tt.dsn}).Storage()
+			require.NoError(t, err)
+			require.Equal(t, tt.driver, driver)
+			require.Equal(t, tt.want, dsn)
+		})
+	}
+
+	_, _, err := (&HTTPConfig{DSN: "mysql://localhost/db"}).Storage()
+	require.Error(t, err)
+}
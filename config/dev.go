@@ -1,6 +1,9 @@
 package config
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 func newDevHTTPConfig() *HTTPConfig {
 	host := envOrDefault("HTTP_HOST", "localhost")
@@ -12,9 +15,22 @@ func newDevHTTPConfig() *HTTPConfig {
 	}
 
 	return &HTTPConfig{
-		Host: host,
-		Port: port,
-		DSN:  dsn,
+		Host:             host,
+		Port:             port,
+		DSN:              dsn,
+		SteamAPIKey:      envOrDefault("STEAM_API_KEY", ""),
+		SteamAPIBaseURL:  envOrDefault("STEAM_API_BASE_URL", "https://api.steampowered.com"),
+		IGDBClientID:     envOrDefault("IGDB_CLIENT_ID", ""),
+		IGDBClientSecret: envOrDefault("IGDB_CLIENT_SECRET", ""),
+		IGDBBaseURL:      envOrDefault("IGDB_BASE_URL", "https://api.igdb.com/v4"),
+		TwitchTokenURL:   envOrDefault("TWITCH_TOKEN_URL", "https://id.twitch.tv/oauth2/token"),
+		MetadataCacheTTL: durationOrDefault("METADATA_CACHE_TTL", 7*24*time.Hour),
+		ReadTimeout:      durationOrDefault("HTTP_READ_TIMEOUT", 15*time.Second),
+		WriteTimeout:     durationOrDefault("HTTP_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:      durationOrDefault("HTTP_IDLE_TIMEOUT", 2*time.Minute),
+		ShutdownTimeout:  durationOrDefault("HTTP_SHUTDOWN_TIMEOUT", 20*time.Second),
+		TracingExporter:  envOrDefault("TRACING_EXPORTER", ""),
+		TracingFile:      envOrDefault("TRACING_FILE", "traces.jsonl"),
 	}
 }
 
@@ -26,3 +42,12 @@ func envOrDefault(key, fallback string) string {
 
 	return fallback
 }
+
+func durationOrDefault(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(envOrDefault(key, ""))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
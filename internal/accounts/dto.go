@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
@@ -9,17 +10,34 @@ import (
 )
 
 type AccountResponse struct {
-	ID       uuid.UUID `json:"id"`
-	Nickname string    `json:"nickname"`
-	Email    string    `json:"email"`
+	ID         uuid.UUID  `json:"id"`
+	Nickname   string     `json:"nickname"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	DisabledAt *time.Time `json:"disabled_at"`
 }
 
 func MountAccountResponse(account domain.Account) AccountResponse {
+	var disabledAt *time.Time
+	if !account.DisabledAt.IsZero() {
+		disabledAt = &account.DisabledAt
+	}
+
 	return AccountResponse{
-		ID:       account.ID,
-		Nickname: account.Nickname,
-		Email:    account.Email,
+		ID:         account.ID,
+		Nickname:   account.Nickname,
+		Email:      account.Email,
+		Role:       string(account.Role.OrMember()),
+		DisabledAt: disabledAt,
+	}
+}
+
+func MountAccountsResponse(accounts []domain.Account) []AccountResponse {
+	response := make([]AccountResponse, len(accounts))
+	for i, account := range accounts {
+		response[i] = MountAccountResponse(account)
 	}
+	return response
 }
 
 type LoginPayload struct {
@@ -1,11 +1,14 @@
 package accounts
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
 	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
@@ -25,17 +28,17 @@ func (h *HTTPAdapter) Login(w http.ResponseWriter, r *http.Request) {
 
 	payload, err := httpjson.DecodeValid[*LoginPayload](r)
 	if err != nil {
-		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusBadRequest, "invalid payload", err)
+		httpjson.NotifyProblem(w, r, h.logger, "invalid payload", err)
 		return
 	}
 
 	account, session, err := h.service.Login(ctx, payload.Email, payload.Password)
 	if err != nil {
-		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusInternalServerError, "failed to login", err)
+		httpjson.NotifyProblem(w, r, h.logger, "failed to login", err)
 		return
 	}
 
-	setSessionCookie(w, r, session)
+	SetSessionCookie(w, r, session)
 
 	response := MountAccountResponse(account)
 	if err := httpjson.Encode(w, r, http.StatusCreated, response); err != nil {
@@ -48,17 +51,17 @@ func (h *HTTPAdapter) Register(w http.ResponseWriter, r *http.Request) {
 
 	payload, err := httpjson.DecodeValid[*RegisterPayload](r)
 	if err != nil {
-		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusBadRequest, "invalid payload", err)
+		httpjson.NotifyProblem(w, r, h.logger, "invalid payload", err)
 		return
 	}
 
 	account, session, err := h.service.Register(ctx, payload.Nickname, payload.Email, payload.Password)
 	if err != nil {
-		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusInternalServerError, "failed to register", err)
+		httpjson.NotifyProblem(w, r, h.logger, "failed to register", err)
 		return
 	}
 
-	setSessionCookie(w, r, session)
+	SetSessionCookie(w, r, session)
 
 	response := MountAccountResponse(account)
 	if err := httpjson.Encode(w, r, http.StatusCreated, response); err != nil {
@@ -69,20 +72,65 @@ func (h *HTTPAdapter) Register(w http.ResponseWriter, r *http.Request) {
 func (h *HTTPAdapter) Logout(w http.ResponseWriter, r *http.Request) {
 	sessionToken, ok := auth.SessionTokenFromContext(r.Context())
 	if !ok {
-		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusUnauthorized, "missing session", auth.ErrInvalidToken)
+		httpjson.NotifyProblem(w, r, h.logger, "missing session", auth.ErrInvalidToken)
 		return
 	}
 
 	if err := h.service.LogoutSession(r.Context(), sessionToken); err != nil {
-		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusInternalServerError, "failed to logout", err)
+		httpjson.NotifyProblem(w, r, h.logger, "failed to logout", err)
 		return
 	}
 
-	clearSessionCookie(w, r)
+	ClearSessionCookie(w, r)
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func setSessionCookie(w http.ResponseWriter, r *http.Request, session domain.Session) {
+func (h *HTTPAdapter) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.service.ListAccounts(r.Context())
+	if err != nil {
+		httpjson.NotifyProblem(w, r, h.logger, "failed to list accounts", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountAccountsResponse(accounts)); err != nil {
+		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusInternalServerError, "failed to encode accounts", err)
+	}
+}
+
+func (h *HTTPAdapter) DisableAccount(w http.ResponseWriter, r *http.Request) {
+	h.updateAccount(w, r, "failed to disable account", h.service.DisableAccount)
+}
+
+func (h *HTTPAdapter) PromoteAccount(w http.ResponseWriter, r *http.Request) {
+	h.updateAccount(w, r, "failed to promote account", h.service.PromoteAccount)
+}
+
+func (h *HTTPAdapter) updateAccount(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	update func(ctx context.Context, id uuid.UUID) (domain.Account, error),
+) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	account, err := update(r.Context(), id)
+	if err != nil {
+		httpjson.NotifyProblem(w, r, h.logger, action, err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountAccountResponse(account)); err != nil {
+		httpjson.NotifyHTTPError(w, r, h.logger, http.StatusInternalServerError, "failed to encode account", err)
+	}
+}
+
+// SetSessionCookie stores the session token in an HTTP-only cookie, shared by
+// the JSON API and the web UI.
+func SetSessionCookie(w http.ResponseWriter, r *http.Request, session domain.Session) {
 	age := int(time.Until(session.ExpiresAt).Seconds())
 	maxAge := max(age, 0)
 
@@ -100,7 +148,7 @@ func setSessionCookie(w http.ResponseWriter, r *http.Request, session domain.Ses
 	http.SetCookie(w, cookie)
 }
 
-func clearSessionCookie(w http.ResponseWriter, r *http.Request) {
+func ClearSessionCookie(w http.ResponseWriter, r *http.Request) {
 	cookie := &http.Cookie{
 		Name:     auth.SessionCookieName,
 		Value:    "",
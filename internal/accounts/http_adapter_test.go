@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -11,14 +12,21 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/httpserver/problems"
 	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 )
 
+// Adapters answer with the problems the router registers.
+func init() {
+	problems.Register()
+}
+
 func TestHTTPAdapter_Login(t *testing.T) {
 	mockSvc := new(MockAccountService)
 	logger := slog.Default()
@@ -181,6 +189,25 @@ func TestHTTPAdapter_Register_ServiceError(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestHTTPAdapter_Register_EmailTaken(t *testing.T) {
+	mockSvc := new(MockAccountService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	mockSvc.On("Register", mock.Anything, "nerd", "nerd@example.com", "password").Return(domain.Account{}, domain.Session{}, domain.ErrEmailTaken)
+
+	body := bytes.NewBufferString(`{"nickname":"nerd","email":"nerd@example.com","password":"password"}`)
+	req := httptest.NewRequest(http.MethodPost, "/register", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.Register(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	mockSvc.AssertExpectations(t)
+}
+
 func TestHTTPAdapter_Logout(t *testing.T) {
 	mockSvc := new(MockAccountService)
 	logger := slog.Default()
@@ -240,6 +267,83 @@ func TestHTTPAdapter_Logout_ServiceError(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func withRouteParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHTTPAdapter_ListAccounts(t *testing.T) {
+	mockSvc := new(MockAccountService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	disabledAt := time.Now().UTC()
+	mockSvc.On("ListAccounts", mock.Anything).Return([]domain.Account{
+		{ID: uuid.New(), Nickname: "admin", Role: auth.RoleAdmin},
+		{ID: uuid.New(), Nickname: "gone", DisabledAt: disabledAt},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/accounts", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListAccounts(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response []AccountResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Len(t, response, 2)
+	require.Equal(t, "admin", response[0].Role)
+	require.Nil(t, response[0].DisabledAt)
+	require.Equal(t, "member", response[1].Role)
+	require.NotNil(t, response[1].DisabledAt)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_DisableAccount(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"disabled", nil, http.StatusOK},
+		{"self", domain.ErrDisableSelf, http.StatusUnprocessableEntity},
+		{"unknown", domain.ErrAccountNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockAccountService)
+			handler := NewHTTPAdapter(mockSvc, slog.Default())
+			id := uuid.New()
+
+			mockSvc.On("DisableAccount", mock.Anything, id).Return(domain.Account{ID: id, DisabledAt: time.Now()}, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/accounts/"+id.String()+"/disable", nil)
+			req = withRouteParam(req, "id", id.String())
+			w := httptest.NewRecorder()
+
+			handler.DisableAccount(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHTTPAdapter_PromoteAccount_InvalidID(t *testing.T) {
+	mockSvc := new(MockAccountService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/accounts/nope/promote", nil)
+	req = withRouteParam(req, "id", "nope")
+	w := httptest.NewRecorder()
+
+	handler.PromoteAccount(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	mockSvc.AssertNotCalled(t, "PromoteAccount", mock.Anything, mock.Anything)
+}
+
 func TestIsSecureRequest(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -2,21 +2,23 @@ package accounts
 
 import (
 	"context"
-	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
 )
 
 type repository struct {
-	db *sqlc.Queries
+	db   *sqlc.Queries
+	pool postgres.TxBeginner
 }
 
-func NewRepository(q *sqlc.Queries) domain.AccountRepository {
-	return &repository{q}
+func NewRepository(q *sqlc.Queries, pool postgres.TxBeginner) domain.AccountRepository {
+	return &repository{q, pool}
 }
 
 func (r *repository) CreateAccount(ctx context.Context, account domain.Account) (domain.Account, error) {
@@ -24,64 +26,138 @@ func (r *repository) CreateAccount(ctx context.Context, account domain.Account)
 		Nickname:       account.Nickname,
 		Email:          account.Email,
 		HashedPassword: account.HashedPassword,
+		Role:           string(account.Role.OrMember()),
 	})
 	if err != nil {
-		return domain.Account{}, err
+		return domain.Account{}, postgres.TranslateError(err, nil)
 	}
 
-	return domain.Account{
-		ID:             insertedAccount.ID,
-		Nickname:       insertedAccount.Nickname,
-		Email:          insertedAccount.Email,
-		HashedPassword: insertedAccount.HashedPassword,
-		TimeStamps: domain.TimeStamps{
-			InsertedAt: insertedAccount.InsertedAt.Time,
-			UpdatedAt:  insertedAccount.UpdatedAt.Time,
-			DeletedAt:  insertedAccount.DeletedAt.Time,
-		},
-	}, nil
+	return toDomainAccount(insertedAccount), nil
 }
 
 func (r *repository) GetAccountByEmail(ctx context.Context, email string) (domain.Account, error) {
 	account, err := r.db.GetAccountByEmail(ctx, email)
-	if err == sql.ErrNoRows {
-		return domain.Account{}, domain.ErrAccountNotFound
-	} else if err != nil {
+	if err != nil {
+		return domain.Account{}, postgres.TranslateError(err, domain.ErrAccountNotFound)
+	}
+
+	return toDomainAccount(account), nil
+}
+
+func (r *repository) GetAccountByID(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	account, err := r.db.GetAccountByID(ctx, id)
+	if err != nil {
+		return domain.Account{}, postgres.TranslateError(err, domain.ErrAccountNotFound)
+	}
+
+	return toDomainAccount(account), nil
+}
+
+func (r *repository) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	rows, err := r.db.ListAccounts(ctx)
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	accounts := make([]domain.Account, len(rows))
+	for i, row := range rows {
+		accounts[i] = toDomainAccount(row)
+	}
+
+	return accounts, nil
+}
+
+func (r *repository) CountAccounts(ctx context.Context) (int, error) {
+	count, err := r.db.CountAccounts(ctx)
+	if err != nil {
+		return 0, postgres.TranslateError(err, nil)
+	}
+
+	return int(count), nil
+}
+
+// WithAccountsLock serializes callers on a transaction-scoped advisory lock,
+// so two registrations can't both count zero accounts.
+func (r *repository) WithAccountsLock(ctx context.Context, fn func(repo domain.AccountRepository) error) error {
+	return postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		if err := q.LockAccountRegistration(ctx); err != nil {
+			return err
+		}
+
+		return fn(&repository{q, r.pool})
+	})
+}
+
+func (r *repository) UpdateAccountRole(ctx context.Context, id uuid.UUID, role auth.Role) (domain.Account, error) {
+	account, err := r.db.UpdateAccountRole(ctx, sqlc.UpdateAccountRoleParams{ID: id, Role: string(role)})
+	if err != nil {
+		return domain.Account{}, postgres.TranslateError(err, domain.ErrAccountNotFound)
+	}
+
+	return toDomainAccount(account), nil
+}
+
+func (r *repository) DisableAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	var disabled sqlc.Account
+	err := postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		var err error
+		disabled, err = q.DisableAccount(ctx, id)
+		if err != nil {
+			return postgres.TranslateError(err, domain.ErrAccountNotFound)
+		}
+
+		return q.DeleteAccountSessions(ctx, id)
+	})
+	if err != nil {
 		return domain.Account{}, err
 	}
 
-	return domain.Account{
-		ID:             account.ID,
-		Nickname:       account.Nickname,
-		Email:          account.Email,
-		HashedPassword: account.HashedPassword,
-		TimeStamps: domain.TimeStamps{
-			InsertedAt: account.InsertedAt.Time,
-			UpdatedAt:  account.UpdatedAt.Time,
-			DeletedAt:  account.DeletedAt.Time,
-		},
-	}, nil
+	return toDomainAccount(disabled), nil
 }
 
 func (r *repository) CreateSession(ctx context.Context, accountID uuid.UUID, token string, expiresAt time.Time) error {
-	return r.db.CreateSession(ctx, sqlc.CreateSessionParams{
+	err := r.db.CreateSession(ctx, sqlc.CreateSessionParams{
 		Token:     token,
 		AccountID: accountID,
 		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
 	})
+	return postgres.TranslateError(err, nil)
 }
 
-func (r *repository) GetSessionAccountID(ctx context.Context, token string) (uuid.UUID, error) {
-	accountID, err := r.db.GetSessionAccountID(ctx, token)
-	if err == sql.ErrNoRows {
-		return uuid.Nil, domain.ErrSessionNotFound
-	} else if err != nil {
-		return uuid.Nil, err
+func (r *repository) GetSessionAccount(ctx context.Context, token string) (domain.Account, error) {
+	account, err := r.db.GetSessionAccount(ctx, token)
+	if err != nil {
+		return domain.Account{}, postgres.TranslateError(err, domain.ErrSessionNotFound)
 	}
 
-	return accountID, nil
+	return toDomainAccount(account), nil
 }
 
 func (r *repository) DeleteSession(ctx context.Context, token string) error {
-	return r.db.DeleteSession(ctx, token)
+	return postgres.TranslateError(r.db.DeleteSession(ctx, token), nil)
+}
+
+func (r *repository) CountActiveSessions(ctx context.Context) (int, error) {
+	count, err := r.db.CountActiveSessions(ctx)
+	if err != nil {
+		return 0, postgres.TranslateError(err, nil)
+	}
+
+	return int(count), nil
+}
+
+func toDomainAccount(account sqlc.Account) domain.Account {
+	return domain.Account{
+		ID:             account.ID,
+		Nickname:       account.Nickname,
+		Email:          account.Email,
+		HashedPassword: account.HashedPassword,
+		Role:           auth.Role(account.Role),
+		DisabledAt:     account.DisabledAt.Time,
+		TimeStamps: domain.TimeStamps{
+			InsertedAt: account.InsertedAt.Time,
+			UpdatedAt:  account.UpdatedAt.Time,
+			DeletedAt:  account.DeletedAt.Time,
+		},
+	}
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -27,17 +28,57 @@ func (m *MockAccountRepository) GetAccountByEmail(ctx context.Context, email str
 	return args.Get(0).(domain.Account), args.Error(1)
 }
 
+func (m *MockAccountRepository) GetAccountByID(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) CountAccounts(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+// WithAccountsLock records the call and runs fn against the mock itself
+// unless an error was set up.
+func (m *MockAccountRepository) WithAccountsLock(ctx context.Context, fn func(repo domain.AccountRepository) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(m)
+}
+
+func (m *MockAccountRepository) UpdateAccountRole(ctx context.Context, id uuid.UUID, role auth.Role) (domain.Account, error) {
+	args := m.Called(ctx, id, role)
+	return args.Get(0).(domain.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) DisableAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Account), args.Error(1)
+}
+
 func (m *MockAccountRepository) CreateSession(ctx context.Context, accountID uuid.UUID, token string, expiresAt time.Time) error {
 	args := m.Called(ctx, accountID, token, expiresAt)
 	return args.Error(0)
 }
 
-func (m *MockAccountRepository) GetSessionAccountID(ctx context.Context, token string) (uuid.UUID, error) {
+func (m *MockAccountRepository) GetSessionAccount(ctx context.Context, token string) (domain.Account, error) {
 	args := m.Called(ctx, token)
-	return args.Get(0).(uuid.UUID), args.Error(1)
+	return args.Get(0).(domain.Account), args.Error(1)
 }
 
 func (m *MockAccountRepository) DeleteSession(ctx context.Context, token string) error {
 	args := m.Called(ctx, token)
 	return args.Error(0)
 }
+
+func (m *MockAccountRepository) CountActiveSessions(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
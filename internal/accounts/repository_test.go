@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
 	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
 	"github.com/kalogs-c/nerd-backlog/internal/testutils"
@@ -19,6 +20,7 @@ import (
 )
 
 var testQueries *sqlc.Queries
+var testDB *pgxpool.Pool
 
 func TestMain(m *testing.M) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -29,9 +31,9 @@ func TestMain(m *testing.M) {
 		log.Fatalln(err)
 	}
 
-	db := postgres.MustConnect(ctx, dsn, nil)
-	gooseProvider := migrations.MustProvide(db)
-	testQueries = sqlc.New(db)
+	testDB = postgres.MustConnect(ctx, dsn, nil)
+	gooseProvider := migrations.MustProvide(testDB)
+	testQueries = sqlc.New(testDB)
 
 	_, err = gooseProvider.Up(context.Background())
 	if err != nil {
@@ -48,7 +50,7 @@ func TestMain(m *testing.M) {
 }
 
 func TestRepository_CreateAndGetAccount(t *testing.T) {
-	repo := NewRepository(testQueries)
+	repo := NewRepository(testQueries, testDB)
 	ctx := context.Background()
 
 	nickname := "testuser"
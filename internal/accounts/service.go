@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
@@ -37,10 +38,26 @@ func (s *service) Register(ctx context.Context, nickname string, email string, p
 		return domain.Account{}, domain.Session{}, err
 	}
 
-	account, err := s.repository.CreateAccount(ctx, domain.Account{
-		Nickname:       nickname,
-		Email:          email,
-		HashedPassword: hashedPassword,
+	var account domain.Account
+	err = s.repository.WithAccountsLock(ctx, func(repo domain.AccountRepository) error {
+		count, err := repo.CountAccounts(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The first account ever registered administers the instance.
+		role := auth.RoleMember
+		if count == 0 {
+			role = auth.RoleAdmin
+		}
+
+		account, err = repo.CreateAccount(ctx, domain.Account{
+			Nickname:       nickname,
+			Email:          email,
+			HashedPassword: hashedPassword,
+			Role:           role,
+		})
+		return err
 	})
 	if err != nil {
 		return domain.Account{}, domain.Session{}, err
@@ -58,8 +75,27 @@ func (s *service) LogoutSession(ctx context.Context, token string) error {
 	return s.repository.DeleteSession(ctx, token)
 }
 
+func (s *service) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	return s.repository.ListAccounts(ctx)
+}
+
+func (s *service) DisableAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	if accountID, ok := auth.AccountIDFromContext(ctx); ok && accountID == id {
+		return domain.Account{}, domain.ErrDisableSelf
+	}
+
+	return s.repository.DisableAccount(ctx, id)
+}
+
+func (s *service) PromoteAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	return s.repository.UpdateAccountRole(ctx, id, auth.RoleAdmin)
+}
+
 func (s *service) authenticate(ctx context.Context, email string, password string) (domain.Account, error) {
 	user, err := s.repository.GetAccountByEmail(ctx, email)
+	if errors.Is(err, domain.ErrAccountNotFound) {
+		return domain.Account{}, domain.ErrInvalidCredentials
+	}
 	if err != nil {
 		return domain.Account{}, err
 	}
@@ -69,7 +105,10 @@ func (s *service) authenticate(ctx context.Context, email string, password strin
 		return domain.Account{}, err
 	}
 	if !ok {
-		return domain.Account{}, domain.ErrAccountNotFound
+		return domain.Account{}, domain.ErrInvalidCredentials
+	}
+	if !user.DisabledAt.IsZero() {
+		return domain.Account{}, domain.ErrAccountDisabled
 	}
 
 	return user, nil
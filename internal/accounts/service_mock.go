@@ -3,6 +3,7 @@ package accounts
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
 	"github.com/stretchr/testify/mock"
 )
@@ -29,3 +30,18 @@ func (m *MockAccountService) LogoutSession(ctx context.Context, token string) er
 	args := m.Called(ctx, token)
 	return args.Error(0)
 }
+
+func (m *MockAccountService) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Account), args.Error(1)
+}
+
+func (m *MockAccountService) DisableAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Account), args.Error(1)
+}
+
+func (m *MockAccountService) PromoteAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Account), args.Error(1)
+}
@@ -56,7 +56,7 @@ func TestService_Login_EmailNotFound(t *testing.T) {
 
 	_, _, err := svc.Login(ctx, email, password)
 	require.Error(t, err)
-	require.EqualError(t, err, domain.ErrAccountNotFound.Error())
+	require.ErrorIs(t, err, domain.ErrInvalidCredentials)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -83,6 +83,102 @@ func TestService_Login_PasswordIncorrect(t *testing.T) {
 
 	_, _, err := svc.Login(ctx, email, password)
 	require.Error(t, err)
-	require.EqualError(t, err, domain.ErrAccountNotFound.Error())
+	require.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_Login_Disabled(t *testing.T) {
+	mockRepo := new(MockAccountRepository)
+	svc := NewService(mockRepo, auth.NewSessionManager(time.Hour))
+
+	ctx := context.Background()
+	email := "disabled@example.com"
+	password := "password$123"
+	hashedPassword, _ := auth.HashPassword(password)
+
+	user := domain.Account{
+		ID:             uuid.New(),
+		Email:          email,
+		HashedPassword: hashedPassword,
+		DisabledAt:     time.Now(),
+	}
+
+	mockRepo.On("GetAccountByEmail", ctx, email).Return(user, nil)
+
+	_, _, err := svc.Login(ctx, email, password)
+	require.ErrorIs(t, err, domain.ErrAccountDisabled)
+	mockRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_Register_FirstAccountIsAdmin(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing int
+		role     auth.Role
+	}{
+		{"first account", 0, auth.RoleAdmin},
+		{"later account", 3, auth.RoleMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockAccountRepository)
+			svc := NewService(mockRepo, auth.NewSessionManager(time.Hour))
+			ctx := context.Background()
+			created := domain.Account{ID: uuid.New(), Role: tt.role}
+
+			mockRepo.On("WithAccountsLock", ctx).Return(nil)
+			mockRepo.On("CountAccounts", ctx).Return(tt.existing, nil)
+			mockRepo.On("CreateAccount", ctx, mock.MatchedBy(func(account domain.Account) bool {
+				return account.Role == tt.role && account.Email == "new@example.com"
+			})).Return(created, nil)
+			mockRepo.On("CreateSession", ctx, created.ID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+
+			account, _, err := svc.Register(ctx, "newbie", "new@example.com", "password$123")
+			require.NoError(t, err)
+			require.Equal(t, tt.role, account.Role)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_DisableAccount(t *testing.T) {
+	adminID := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), adminID)
+
+	t.Run("other account", func(t *testing.T) {
+		mockRepo := new(MockAccountRepository)
+		svc := NewService(mockRepo, auth.NewSessionManager(time.Hour))
+		id := uuid.New()
+
+		mockRepo.On("DisableAccount", ctx, id).Return(domain.Account{ID: id, DisabledAt: time.Now()}, nil)
+
+		account, err := svc.DisableAccount(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, id, account.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("own account", func(t *testing.T) {
+		mockRepo := new(MockAccountRepository)
+		svc := NewService(mockRepo, auth.NewSessionManager(time.Hour))
+
+		_, err := svc.DisableAccount(ctx, adminID)
+		require.ErrorIs(t, err, domain.ErrDisableSelf)
+		mockRepo.AssertNotCalled(t, "DisableAccount", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_PromoteAccount(t *testing.T) {
+	mockRepo := new(MockAccountRepository)
+	svc := NewService(mockRepo, auth.NewSessionManager(time.Hour))
+	ctx := context.Background()
+	id := uuid.New()
+
+	mockRepo.On("UpdateAccountRole", ctx, id, auth.RoleAdmin).Return(domain.Account{ID: id, Role: auth.RoleAdmin}, nil)
+
+	account, err := svc.PromoteAccount(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, auth.RoleAdmin, account.Role)
 	mockRepo.AssertExpectations(t)
 }
@@ -6,31 +6,59 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 )
 
 var ErrAccountNotFound = errors.New("account not found")
 var ErrSessionNotFound = errors.New("session not found")
+var ErrEmailTaken = errors.New("email already registered")
+var ErrInvalidCredentials = errors.New("invalid email or password")
+var ErrAccountDisabled = errors.New("account disabled")
+var ErrDisableSelf = errors.New("cannot disable your own account")
 
 type Account struct {
 	ID             uuid.UUID
 	Nickname       string
 	Email          string
 	HashedPassword string
+	Role           auth.Role
+	// DisabledAt is zero for active accounts. Disabled accounts can neither
+	// log in nor use the sessions they had.
+	DisabledAt time.Time
 	TimeStamps
 }
 
 type AccountRepository interface {
 	CreateAccount(ctx context.Context, user Account) (Account, error)
 	GetAccountByEmail(ctx context.Context, email string) (Account, error)
+	GetAccountByID(ctx context.Context, id uuid.UUID) (Account, error)
+	// ListAccounts returns every account, oldest first.
+	ListAccounts(ctx context.Context) ([]Account, error)
+	CountAccounts(ctx context.Context) (int, error)
+	// WithAccountsLock runs fn against a repository bound to one transaction.
+	// Calls never overlap, so fn may decide on CountAccounts before writing.
+	WithAccountsLock(ctx context.Context, fn func(repo AccountRepository) error) error
+	UpdateAccountRole(ctx context.Context, id uuid.UUID, role auth.Role) (Account, error)
+	// DisableAccount marks the account disabled and ends its sessions. Disabling
+	// it again keeps the original DisabledAt.
+	DisableAccount(ctx context.Context, id uuid.UUID) (Account, error)
 	CreateSession(ctx context.Context, accountID uuid.UUID, token string, expiresAt time.Time) error
-	GetSessionAccountID(ctx context.Context, token string) (uuid.UUID, error)
+	// GetSessionAccount returns the account behind a live session, reporting
+	// ErrSessionNotFound for expired sessions and disabled accounts.
+	GetSessionAccount(ctx context.Context, token string) (Account, error)
 	DeleteSession(ctx context.Context, token string) error
+	// CountActiveSessions counts sessions that have not expired.
+	CountActiveSessions(ctx context.Context) (int, error)
 }
 
 type AccountService interface {
 	Login(ctx context.Context, email string, password string) (Account, Session, error)
 	Register(ctx context.Context, nickname string, email string, password string) (Account, Session, error)
 	LogoutSession(ctx context.Context, token string) error
+	ListAccounts(ctx context.Context) ([]Account, error)
+	DisableAccount(ctx context.Context, id uuid.UUID) (Account, error)
+	PromoteAccount(ctx context.Context, id uuid.UUID) (Account, error)
 }
 
 type Session struct {
@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+// Storage failures that aren't tied to one kind of record. Repositories wrap
+// the driver error with them, so callers can tell a conflict from an outage.
+var (
+	ErrAlreadyExists     = errors.New("record already exists")
+	ErrReferenceNotFound = errors.New("referenced record not found")
+	ErrConcurrentUpdate  = errors.New("concurrent update conflict")
+)
+
+// ErrForbidden means the caller is known but may not touch the record.
+var ErrForbidden = errors.New("forbidden")
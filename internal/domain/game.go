@@ -3,27 +3,182 @@ package domain
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
 type Game struct {
-	ID    uuid.UUID
-	Title string
+	ID          uuid.UUID
+	Title       string
+	ReleaseDate time.Time
+	Summary     string
+	Developer   string
+	Publisher   string
+	CoverURL    string
+	Platforms   []string
+	Genres      []string
+	// CreatedBy is the account that added the game to the catalog, or
+	// uuid.Nil when nobody owns it. Only the creator and admins may change it.
+	CreatedBy uuid.UUID
+	TimeStamps
+}
+
+// GamePatch holds the fields to change on a game. Nil fields are left as they
+// are; a non-nil pointer to a zero value clears the field.
+type GamePatch struct {
+	Title       *string
+	ReleaseDate *time.Time
+	Summary     *string
+	Developer   *string
+	Publisher   *string
+	CoverURL    *string
+	Platforms   *[]string
+	Genres      *[]string
+}
+
+type GameSort string
+
+const (
+	GameSortTitle       GameSort = "title"
+	GameSortInsertedAt  GameSort = "inserted_at"
+	GameSortReleaseDate GameSort = "release_date"
+)
+
+var GameSorts = []GameSort{
+	GameSortTitle,
+	GameSortInsertedAt,
+	GameSortReleaseDate,
+}
+
+// GameCursor points at the last game of a page. SortKey is the value the
+// repository sorted by, as written by GameSortKey, so the next page can resume
+// right after it.
+type GameCursor struct {
+	SortKey string
+	ID      uuid.UUID
+}
+
+// GameSortKeyTimeLayout is how insertion times are written in sort keys.
+const GameSortKeyTimeLayout = "2006-01-02T15:04:05.000000"
+
+// GameSortKey returns the value game is ordered by under sort. A missing
+// release date has an empty key; those games sort last in either direction.
+func GameSortKey(game Game, sort GameSort) string {
+	switch sort {
+	case GameSortInsertedAt:
+		return game.InsertedAt.UTC().Format(GameSortKeyTimeLayout)
+	case GameSortReleaseDate:
+		if game.ReleaseDate.IsZero() {
+			return ""
+		}
+		return game.ReleaseDate.Format(time.DateOnly)
+	default:
+		return strings.ToLower(game.Title)
+	}
+}
+
+// ValidGameSortKey reports whether key could have come from GameSortKey.
+func ValidGameSortKey(sort GameSort, key string) bool {
+	var err error
+	switch sort {
+	case GameSortInsertedAt:
+		_, err = time.Parse(GameSortKeyTimeLayout, key)
+	case GameSortReleaseDate:
+		if key != "" {
+			_, err = time.Parse(time.DateOnly, key)
+		}
+	}
+	return err == nil
+}
+
+type ListGamesParams struct {
+	Sort        GameSort
+	Descending  bool
+	Platform    string
+	Genre       string
+	TitlePrefix string
+	After       *GameCursor
+	Limit       int
+}
+
+type GamePage struct {
+	Games []Game
+	Next  *GameCursor
+}
+
+// GameSearchResult is a game matched by a search. Snippets are HTML-escaped
+// with matched terms wrapped in <mark>.
+type GameSearchResult struct {
+	Game           Game
+	Score          float64
+	TitleSnippet   string
+	SummarySnippet string
 }
 
 type GameService interface {
-	CreateGame(ctx context.Context, title string) (Game, error)
+	CreateGame(ctx context.Context, game Game) (Game, error)
 	GetGameByID(ctx context.Context, id uuid.UUID) (Game, error)
-	ListGames(ctx context.Context) ([]Game, error)
+	ListGames(ctx context.Context, params ListGamesParams) (GamePage, error)
+	SearchGames(ctx context.Context, query string, limit int) ([]GameSearchResult, error)
+	UpdateGame(ctx context.Context, id uuid.UUID, patch GamePatch) (Game, error)
+	EnrichGame(ctx context.Context, id uuid.UUID) (Game, error)
+	// ResolveOrCreateGame returns the game already known by one of
+	// externalIDs or by its normalized title, creating it otherwise. Every
+	// external ID ends up mapped to the returned game.
+	ResolveOrCreateGame(ctx context.Context, game Game, externalIDs []ExternalID) (Game, error)
+	// MergeGames folds the source game into the target and deletes it. Only
+	// admins may merge.
+	MergeGames(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) (Game, error)
 	DeleteGameByID(ctx context.Context, id uuid.UUID) error
 }
 
 type GameRepository interface {
 	CreateGame(ctx context.Context, game Game) (Game, error)
 	GetGameByID(ctx context.Context, id uuid.UUID) (Game, error)
-	ListGames(ctx context.Context) ([]Game, error)
+	GetGameByNormalizedTitle(ctx context.Context, normalizedTitle string) (Game, error)
+	GetGameByExternalID(ctx context.Context, externalID ExternalID) (Game, error)
+	ListGames(ctx context.Context, params ListGamesParams) (GamePage, error)
+	SearchGames(ctx context.Context, query string, limit int) ([]GameSearchResult, error)
+	UpdateGame(ctx context.Context, game Game) (Game, error)
+	ListExternalIDs(ctx context.Context, gameID uuid.UUID) ([]ExternalID, error)
+	AddExternalIDs(ctx context.Context, gameID uuid.UUID, externalIDs []ExternalID) error
+	// CreateGameWithExternalIDs creates game and maps externalIDs to it in one
+	// transaction. When another game already holds one of the IDs, nothing is
+	// created and that game is returned instead, so concurrent imports of the
+	// same game agree on one row.
+	CreateGameWithExternalIDs(ctx context.Context, game Game, externalIDs []ExternalID) (Game, error)
+	// MergeGames saves target and moves everything that points at sourceID
+	// onto it before deleting the source, all in one transaction.
+	MergeGames(ctx context.Context, sourceID uuid.UUID, target Game) (Game, error)
 	DeleteGameByID(ctx context.Context, id uuid.UUID) error
 }
 
-var ErrGameNotFound = errors.New("game not found")
+var (
+	ErrGameNotFound  = errors.New("game not found")
+	ErrMergeSameGame = errors.New("cannot merge a game into itself")
+)
+
+// NormalizeTitle reduces a title to lowercase letters and digits separated by
+// single spaces, so "The Witcher® 3: Wild Hunt" and "the witcher 3 wild hunt"
+// compare equal. The external_ids migration backfills with the same rule.
+func NormalizeTitle(title string) string {
+	var b strings.Builder
+	pendingSpace := false
+	for _, r := range title {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			pendingSpace = b.Len() > 0
+			continue
+		}
+
+		if pendingSpace {
+			b.WriteByte(' ')
+			pendingSpace = false
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrImportJobNotFound = errors.New("import job not found")
+
+type ImportSource string
+
+const (
+	ImportSourceSteam ImportSource = "steam"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobPending   ImportJobStatus = "pending"
+	ImportJobRunning   ImportJobStatus = "running"
+	ImportJobCompleted ImportJobStatus = "completed"
+	ImportJobFailed    ImportJobStatus = "failed"
+)
+
+type ImportJob struct {
+	ID                uuid.UUID
+	AccountID         uuid.UUID
+	Source            ImportSource
+	ExternalAccountID string
+	Status            ImportJobStatus
+	TotalItems        int
+	ProcessedItems    int
+	FailedItems       int
+	Error             string
+	FinishedAt        time.Time
+	TimeStamps
+}
+
+type ImportJobItemStatus string
+
+const (
+	ImportJobItemPending  ImportJobItemStatus = "pending"
+	ImportJobItemImported ImportJobItemStatus = "imported"
+	ImportJobItemFailed   ImportJobItemStatus = "failed"
+)
+
+type ImportJobItem struct {
+	ID         int64
+	JobID      uuid.UUID
+	ExternalID string
+	Title      string
+	Status     ImportJobItemStatus
+	GameID     uuid.UUID
+	Error      string
+	TimeStamps
+}
+
+// Importer pulls a library from an external source into the job's account,
+// recording progress on the job as it goes.
+type Importer interface {
+	Import(ctx context.Context, job ImportJob) (ImportJob, error)
+}
+
+type ImportService interface {
+	ImportSteamLibrary(ctx context.Context, steamID string) (ImportJob, error)
+	GetJob(ctx context.Context, id uuid.UUID) (ImportJob, error)
+	ListJobs(ctx context.Context) ([]ImportJob, error)
+	ListJobItems(ctx context.Context, id uuid.UUID) ([]ImportJobItem, error)
+}
+
+type ImportRepository interface {
+	CreateImportJob(ctx context.Context, job ImportJob) (ImportJob, error)
+	GetImportJob(ctx context.Context, accountID uuid.UUID, id uuid.UUID) (ImportJob, error)
+	ListImportJobs(ctx context.Context, accountID uuid.UUID) ([]ImportJob, error)
+	UpdateImportJob(ctx context.Context, job ImportJob) (ImportJob, error)
+	CreateImportJobItem(ctx context.Context, item ImportJobItem) (ImportJobItem, error)
+	UpdateImportJobItem(ctx context.Context, item ImportJobItem) error
+	ListImportJobItems(ctx context.Context, accountID uuid.UUID, jobID uuid.UUID) ([]ImportJobItem, error)
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrJobNotFound    = errors.New("job not found")
+	ErrNoJobAvailable = errors.New("no job available")
+	// ErrJobLost is returned when a job's lock went stale and another worker
+	// claimed it, so the outcome belongs to that worker now.
+	ErrJobLost = errors.New("job claimed by another worker")
+)
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobDead      JobStatus = "dead"
+)
+
+type Job struct {
+	ID          int64
+	Kind        string
+	Payload     []byte
+	Status      JobStatus
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	LockedAt    time.Time
+	LastError   string
+	TimeStamps
+}
+
+// JobQueue hands work to the background runner. The payload is stored as JSON
+// and decoded into the type the handler for kind was registered with.
+type JobQueue interface {
+	Enqueue(ctx context.Context, kind string, payload any) (Job, error)
+}
+
+type JobRepository interface {
+	EnqueueJob(ctx context.Context, job Job) (Job, error)
+	GetJob(ctx context.Context, id int64) (Job, error)
+	// ClaimJob locks the next runnable job, including running jobs whose lock
+	// is older than staleBefore, and returns ErrNoJobAvailable when idle.
+	ClaimJob(ctx context.Context, staleBefore time.Time) (Job, error)
+	// ExtendJobLock, CompleteJob, RetryJob and KillJob act on the claim that
+	// made attempt, and return ErrJobLost once the job was claimed again.
+	ExtendJobLock(ctx context.Context, id int64, attempt int) error
+	CompleteJob(ctx context.Context, id int64, attempt int) error
+	RetryJob(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error
+	KillJob(ctx context.Context, id int64, attempt int, lastError string) error
+	// CountJobs returns how many jobs are in each status. Statuses without
+	// jobs may be missing.
+	CountJobs(ctx context.Context) (map[JobStatus]int, error)
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrLibraryEntryNotFound = errors.New("library entry not found")
+var ErrInvalidLibraryStatus = errors.New("invalid library status")
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+type LibraryStatus string
+
+const (
+	LibraryStatusBacklog   LibraryStatus = "backlog"
+	LibraryStatusPlaying   LibraryStatus = "playing"
+	LibraryStatusCompleted LibraryStatus = "completed"
+	LibraryStatusDropped   LibraryStatus = "dropped"
+	LibraryStatusWishlist  LibraryStatus = "wishlist"
+	LibraryStatusOnHold    LibraryStatus = "on_hold"
+)
+
+var LibraryStatuses = []LibraryStatus{
+	LibraryStatusBacklog,
+	LibraryStatusPlaying,
+	LibraryStatusCompleted,
+	LibraryStatusDropped,
+	LibraryStatusWishlist,
+	LibraryStatusOnHold,
+}
+
+func ParseLibraryStatus(value string) (LibraryStatus, error) {
+	for _, status := range LibraryStatuses {
+		if string(status) == value {
+			return status, nil
+		}
+	}
+
+	return "", ErrInvalidLibraryStatus
+}
+
+type LibraryEntry struct {
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	GameID          uuid.UUID
+	GameTitle       string
+	Status          LibraryStatus
+	StartedAt       time.Time
+	FinishedAt      time.Time
+	Rating          int
+	Notes           string
+	PlaytimeMinutes int
+	LastPlayedAt    time.Time
+	TimeStamps
+}
+
+type LibraryEntryEventKind string
+
+const (
+	LibraryEntryEventStatusChanged LibraryEntryEventKind = "status_changed"
+	LibraryEntryEventRatingChanged LibraryEntryEventKind = "rating_changed"
+	LibraryEntryEventNotesEdited   LibraryEntryEventKind = "notes_edited"
+)
+
+type LibraryEntryEvent struct {
+	ID         int64
+	EntryID    uuid.UUID
+	AccountID  uuid.UUID
+	Kind       LibraryEntryEventKind
+	From       string
+	To         string
+	InsertedAt time.Time
+}
+
+// StatusTransitionError is returned when a library entry cannot move from its
+// current status to the requested one. Allowed lists the statuses it may move to.
+type StatusTransitionError struct {
+	From    LibraryStatus
+	To      LibraryStatus
+	Allowed []LibraryStatus
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot move library entry from %s to %s", e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
+func (e *StatusTransitionError) AllowedStates() []string {
+	states := make([]string, len(e.Allowed))
+	for i, status := range e.Allowed {
+		states[i] = string(status)
+	}
+	return states
+}
+
+type LibraryEntryUpdate struct {
+	Status *LibraryStatus
+	Rating *int
+	Notes  *string
+}
+
+type LibraryService interface {
+	AddEntry(ctx context.Context, gameID uuid.UUID, status LibraryStatus) (LibraryEntry, error)
+	GetEntry(ctx context.Context, id uuid.UUID) (LibraryEntry, error)
+	ListEntries(ctx context.Context) ([]LibraryEntry, error)
+	UpdateEntry(ctx context.Context, id uuid.UUID, update LibraryEntryUpdate) (LibraryEntry, error)
+	DeleteEntry(ctx context.Context, id uuid.UUID) error
+	GetEntryHistory(ctx context.Context, id uuid.UUID) ([]LibraryEntryEvent, error)
+}
+
+type LibraryRepository interface {
+	CreateLibraryEntry(ctx context.Context, entry LibraryEntry, events []LibraryEntryEvent) (LibraryEntry, error)
+	GetLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) (LibraryEntry, error)
+	ListLibraryEntries(ctx context.Context, accountID uuid.UUID) ([]LibraryEntry, error)
+	UpdateLibraryEntry(ctx context.Context, entry LibraryEntry, events []LibraryEntryEvent) (LibraryEntry, error)
+	DeleteLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) error
+	// ImportLibraryEntry creates the entry or, if the account already has the
+	// game, refreshes its playtime. Events are only recorded for new entries.
+	ImportLibraryEntry(ctx context.Context, entry LibraryEntry, events []LibraryEntryEvent) (LibraryEntry, bool, error)
+	ListLibraryEntryEvents(ctx context.Context, accountID uuid.UUID, entryID uuid.UUID) ([]LibraryEntryEvent, error)
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrMetadataNotFound    = errors.New("metadata not found")
+	ErrMetadataUnavailable = errors.New("metadata provider unavailable")
+	ErrMetadataFetch       = errors.New("metadata provider request failed")
+)
+
+type ExternalSource string
+
+const (
+	ExternalSourceIGDB  ExternalSource = "igdb"
+	ExternalSourceSteam ExternalSource = "steam"
+	ExternalSourceGOG   ExternalSource = "gog"
+)
+
+type ExternalID struct {
+	Source ExternalSource
+	ID     string
+}
+
+// GameMetadata is what a third-party catalog knows about a game, shaped like
+// the metadata fields of Game so it can be merged into one.
+type GameMetadata struct {
+	Source      ExternalSource
+	SourceID    string
+	Title       string
+	ReleaseDate time.Time
+	Summary     string
+	Developer   string
+	Publisher   string
+	CoverURL    string
+	Platforms   []string
+	Genres      []string
+	ExternalIDs []ExternalID
+}
+
+type MetadataProvider interface {
+	// Source names the catalog the provider's IDs belong to.
+	Source() ExternalSource
+	SearchGames(ctx context.Context, query string, limit int) ([]GameMetadata, error)
+	GetGame(ctx context.Context, id string) (GameMetadata, error)
+}
+
+// MetadataCacheEntry is a provider payload as it was last fetched.
+type MetadataCacheEntry struct {
+	Provider   ExternalSource
+	ExternalID string
+	Payload    []byte
+	ETag       string
+	FetchedAt  time.Time
+}
+
+type MetadataCacheRepository interface {
+	GetMetadataCacheEntry(ctx context.Context, provider ExternalSource, externalID string) (MetadataCacheEntry, error)
+	UpsertMetadataCacheEntry(ctx context.Context, entry MetadataCacheEntry) error
+}
@@ -2,21 +2,498 @@ package games
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
 	"github.com/kalogs-c/nerd-backlog/pkg/validator"
 )
 
+const dateLayout = "2006-01-02"
+
+const (
+	maxTitleLength    = 255
+	maxSummaryLength  = 5000
+	maxCompanyLength  = 255
+	maxCategoryLength = 64
+	maxCategories     = 32
+	defaultPageLimit  = 50
+	maxPageLimit      = 100
+	defaultSearchSize = 10
+	maxSearchSize     = 50
+	maxSearchLength   = 200
+)
+
 type GameResponse struct {
-	ID    uuid.UUID `json:"id"`
-	Title string    `json:"title"`
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	ReleaseDate *string    `json:"release_date"`
+	Summary     string     `json:"summary"`
+	Developer   string     `json:"developer"`
+	Publisher   string     `json:"publisher"`
+	CoverURL    string     `json:"cover_url"`
+	Platforms   []string   `json:"platforms"`
+	Genres      []string   `json:"genres"`
+	CreatedBy   *uuid.UUID `json:"created_by"`
+	InsertedAt  time.Time  `json:"inserted_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func MountGameResponse(game domain.Game) GameResponse {
+	var releaseDate *string
+	if !game.ReleaseDate.IsZero() {
+		date := game.ReleaseDate.Format(dateLayout)
+		releaseDate = &date
+	}
+
+	var createdBy *uuid.UUID
+	if game.CreatedBy != uuid.Nil {
+		createdBy = &game.CreatedBy
+	}
+
+	return GameResponse{
+		ID:          game.ID,
+		Title:       game.Title,
+		ReleaseDate: releaseDate,
+		Summary:     game.Summary,
+		Developer:   game.Developer,
+		Publisher:   game.Publisher,
+		CoverURL:    game.CoverURL,
+		Platforms:   nonNil(game.Platforms),
+		Genres:      nonNil(game.Genres),
+		CreatedBy:   createdBy,
+		InsertedAt:  game.InsertedAt,
+		UpdatedAt:   game.UpdatedAt,
+	}
+}
+
+func MountGamesResponse(games []domain.Game) []GameResponse {
+	response := make([]GameResponse, len(games))
+	for i, game := range games {
+		response[i] = MountGameResponse(game)
+	}
+	return response
+}
+
+type GamesPageResponse struct {
+	Items      []GameResponse `json:"items"`
+	NextCursor *string        `json:"next_cursor"`
+}
+
+func MountGamesPageResponse(page domain.GamePage, params domain.ListGamesParams) GamesPageResponse {
+	var nextCursor *string
+	if page.Next != nil {
+		cursor := encodeCursor(params, *page.Next)
+		nextCursor = &cursor
+	}
+
+	return GamesPageResponse{
+		Items:      MountGamesResponse(page.Games),
+		NextCursor: nextCursor,
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
 }
 
 type CreateGamePayload struct {
-	Title string `json:"title"`
+	Title       string   `json:"title"`
+	ReleaseDate string   `json:"release_date"`
+	Summary     string   `json:"summary"`
+	Developer   string   `json:"developer"`
+	Publisher   string   `json:"publisher"`
+	CoverURL    string   `json:"cover_url"`
+	Platforms   []string `json:"platforms"`
+	Genres      []string `json:"genres"`
 }
 
 func (crp *CreateGamePayload) Valid(ctx context.Context) validator.Problems {
 	problems := make(validator.Problems)
+
+	crp.Title = validateTitle(problems, crp.Title)
+	validateReleaseDate(problems, crp.ReleaseDate)
+	validateGameText(problems, crp.Summary, crp.Developer, crp.Publisher)
+	validateCoverURL(problems, crp.CoverURL)
+	crp.Platforms = validateCategories(problems, "platforms", crp.Platforms)
+	crp.Genres = validateCategories(problems, "genres", crp.Genres)
+
+	return problems
+}
+
+func validateTitle(problems validator.Problems, title string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		problems.Add("title", "title is required")
+	} else if len(title) > maxTitleLength {
+		problems.Add("title", fmt.Sprintf("title must be at most %d characters long", maxTitleLength))
+	}
+
+	return title
+}
+
+func validateReleaseDate(problems validator.Problems, releaseDate string) {
+	if releaseDate == "" {
+		return
+	}
+
+	if _, err := time.Parse(dateLayout, releaseDate); err != nil {
+		problems.Add("release_date", "release_date must be a date in YYYY-MM-DD format")
+	}
+}
+
+func validateGameText(problems validator.Problems, summary, developer, publisher string) {
+	if len(summary) > maxSummaryLength {
+		problems.Add("summary", fmt.Sprintf("summary must be at most %d characters long", maxSummaryLength))
+	}
+
+	if len(developer) > maxCompanyLength {
+		problems.Add("developer", fmt.Sprintf("developer must be at most %d characters long", maxCompanyLength))
+	}
+
+	if len(publisher) > maxCompanyLength {
+		problems.Add("publisher", fmt.Sprintf("publisher must be at most %d characters long", maxCompanyLength))
+	}
+}
+
+func validateCoverURL(problems validator.Problems, coverURL string) {
+	if coverURL == "" {
+		return
+	}
+
+	if err := validator.ValidateURL(coverURL); err != nil {
+		problems.Add("cover_url", "cover_url must be an absolute http or https url")
+	}
+}
+
+// validateCategories trims and deduplicates platform or genre names, adding a
+// problem for every blank or oversized value.
+func validateCategories(problems validator.Problems, field string, values []string) []string {
+	if len(values) > maxCategories {
+		problems.Add(field, fmt.Sprintf("%s must have at most %d items", field, maxCategories))
+	}
+
+	seen := make(map[string]bool, len(values))
+	var categories []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		switch {
+		case value == "":
+			problems.Add(field, fmt.Sprintf("%s must not contain empty values", field))
+		case len(value) > maxCategoryLength:
+			problems.Add(field, fmt.Sprintf("%s values must be at most %d characters long", field, maxCategoryLength))
+		case !seen[value]:
+			seen[value] = true
+			categories = append(categories, value)
+		}
+	}
+
+	return categories
+}
+
+func (crp *CreateGamePayload) Game() domain.Game {
+	releaseDate, _ := time.Parse(dateLayout, crp.ReleaseDate)
+
+	return domain.Game{
+		Title:       crp.Title,
+		ReleaseDate: releaseDate,
+		Summary:     crp.Summary,
+		Developer:   crp.Developer,
+		Publisher:   crp.Publisher,
+		CoverURL:    crp.CoverURL,
+		Platforms:   crp.Platforms,
+		Genres:      crp.Genres,
+	}
+}
+
+// Patch turns a full replacement payload into a patch that overwrites every
+// field, which is what PUT needs.
+func (crp *CreateGamePayload) Patch() domain.GamePatch {
+	game := crp.Game()
+
+	return domain.GamePatch{
+		Title:       &game.Title,
+		ReleaseDate: &game.ReleaseDate,
+		Summary:     &game.Summary,
+		Developer:   &game.Developer,
+		Publisher:   &game.Publisher,
+		CoverURL:    &game.CoverURL,
+		Platforms:   &game.Platforms,
+		Genres:      &game.Genres,
+	}
+}
+
+// UpdateGamePayload follows JSON merge patch semantics: absent fields are
+// left untouched and null clears a field.
+type UpdateGamePayload struct {
+	Title       httpjson.Optional[string]   `json:"title"`
+	ReleaseDate httpjson.Optional[string]   `json:"release_date"`
+	Summary     httpjson.Optional[string]   `json:"summary"`
+	Developer   httpjson.Optional[string]   `json:"developer"`
+	Publisher   httpjson.Optional[string]   `json:"publisher"`
+	CoverURL    httpjson.Optional[string]   `json:"cover_url"`
+	Platforms   httpjson.Optional[[]string] `json:"platforms"`
+	Genres      httpjson.Optional[[]string] `json:"genres"`
+}
+
+func (p *UpdateGamePayload) Valid(ctx context.Context) validator.Problems {
+	problems := make(validator.Problems)
+
+	if p.Title.Null {
+		problems.Add("title", "title cannot be removed")
+	} else if p.Title.Set {
+		p.Title.Value = validateTitle(problems, p.Title.Value)
+	}
+
+	validateReleaseDate(problems, p.ReleaseDate.Value)
+	validateGameText(problems, p.Summary.Value, p.Developer.Value, p.Publisher.Value)
+	validateCoverURL(problems, p.CoverURL.Value)
+	p.Platforms.Value = validateCategories(problems, "platforms", p.Platforms.Value)
+	p.Genres.Value = validateCategories(problems, "genres", p.Genres.Value)
+
+	return problems
+}
+
+func (p *UpdateGamePayload) Patch() domain.GamePatch {
+	var patch domain.GamePatch
+
+	if p.Title.Set {
+		patch.Title = &p.Title.Value
+	}
+
+	if p.ReleaseDate.Set {
+		releaseDate, _ := time.Parse(dateLayout, p.ReleaseDate.Value)
+		patch.ReleaseDate = &releaseDate
+	}
+
+	patch.Summary = optionalString(p.Summary)
+	patch.Developer = optionalString(p.Developer)
+	patch.Publisher = optionalString(p.Publisher)
+	patch.CoverURL = optionalString(p.CoverURL)
+	patch.Platforms = optionalList(p.Platforms)
+	patch.Genres = optionalList(p.Genres)
+
+	return patch
+}
+
+func optionalString(field httpjson.Optional[string]) *string {
+	if !field.Set {
+		return nil
+	}
+	return &field.Value
+}
+
+func optionalList(field httpjson.Optional[[]string]) *[]string {
+	if !field.Set {
+		return nil
+	}
+	return &field.Value
+}
+
+// ListGamesQuery holds the query string of GET /games. Sort is a sort key
+// optionally prefixed with "-" for descending order.
+type ListGamesQuery struct {
+	Sort        string
+	Cursor      string
+	Limit       string
+	Platform    string
+	Genre       string
+	TitlePrefix string
+
+	params domain.ListGamesParams
+}
+
+func NewListGamesQuery(values url.Values) *ListGamesQuery {
+	return &ListGamesQuery{
+		Sort:        values.Get("sort"),
+		Cursor:      values.Get("cursor"),
+		Limit:       values.Get("limit"),
+		Platform:    strings.TrimSpace(values.Get("platform")),
+		Genre:       strings.TrimSpace(values.Get("genre")),
+		TitlePrefix: strings.TrimSpace(values.Get("title_prefix")),
+	}
+}
+
+func (q *ListGamesQuery) Valid(ctx context.Context) validator.Problems {
+	problems := make(validator.Problems)
+
+	q.params = domain.ListGamesParams{
+		Sort:        domain.GameSortTitle,
+		Platform:    q.Platform,
+		Genre:       q.Genre,
+		TitlePrefix: q.TitlePrefix,
+		Limit:       defaultPageLimit,
+	}
+
+	if q.Sort != "" {
+		sort, descending := strings.CutPrefix(q.Sort, "-")
+		if !validSort(sort) {
+			problems.Add("sort", "sort must be one of title, inserted_at or release_date, optionally prefixed with -")
+		}
+		q.params.Sort = domain.GameSort(sort)
+		q.params.Descending = descending
+	}
+
+	if q.Limit != "" {
+		limit, err := strconv.Atoi(q.Limit)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			problems.Add("limit", fmt.Sprintf("limit must be a number between 1 and %d", maxPageLimit))
+		}
+		q.params.Limit = limit
+	}
+
+	if q.Cursor != "" {
+		after, err := decodeCursor(q.Cursor, q.params)
+		if err != nil {
+			problems.Add("cursor", err.Error())
+		}
+		q.params.After = after
+	}
+
+	return problems
+}
+
+func (q *ListGamesQuery) Params() domain.ListGamesParams {
+	return q.params
+}
+
+func validSort(sort string) bool {
+	for _, s := range domain.GameSorts {
+		if string(s) == sort {
+			return true
+		}
+	}
+	return false
+}
+
+// gameCursor is the JSON behind the opaque cursor. It remembers the order it
+// was issued for so it cannot be replayed against a different sort.
+type gameCursor struct {
+	Sort       domain.GameSort `json:"s"`
+	Descending bool            `json:"d"`
+	SortKey    string          `json:"k"`
+	ID         uuid.UUID       `json:"id"`
+}
+
+func encodeCursor(params domain.ListGamesParams, cursor domain.GameCursor) string {
+	data, _ := json.Marshal(gameCursor{
+		Sort:       params.Sort,
+		Descending: params.Descending,
+		SortKey:    cursor.SortKey,
+		ID:         cursor.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string, params domain.ListGamesParams) (*domain.GameCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("cursor is malformed")
+	}
+
+	var cursor gameCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errors.New("cursor is malformed")
+	}
+
+	if cursor.Sort != params.Sort || cursor.Descending != params.Descending {
+		return nil, errors.New("cursor was issued for a different sort")
+	}
+
+	if !domain.ValidGameSortKey(cursor.Sort, cursor.SortKey) {
+		return nil, errors.New("cursor is malformed")
+	}
+
+	return &domain.GameCursor{SortKey: cursor.SortKey, ID: cursor.ID}, nil
+}
+
+type GameSearchResultResponse struct {
+	GameResponse
+	Score          float64 `json:"score"`
+	TitleSnippet   string  `json:"title_snippet"`
+	SummarySnippet string  `json:"summary_snippet"`
+}
+
+func MountGameSearchResultsResponse(results []domain.GameSearchResult) []GameSearchResultResponse {
+	response := make([]GameSearchResultResponse, len(results))
+	for i, result := range results {
+		response[i] = GameSearchResultResponse{
+			GameResponse:   MountGameResponse(result.Game),
+			Score:          result.Score,
+			TitleSnippet:   result.TitleSnippet,
+			SummarySnippet: result.SummarySnippet,
+		}
+	}
+	return response
+}
+
+type SearchGamesQuery struct {
+	Query string
+	Limit string
+
+	limit int
+}
+
+func NewSearchGamesQuery(values url.Values) *SearchGamesQuery {
+	return &SearchGamesQuery{
+		Query: strings.TrimSpace(values.Get("q")),
+		Limit: values.Get("limit"),
+	}
+}
+
+func (q *SearchGamesQuery) Valid(ctx context.Context) validator.Problems {
+	problems := make(validator.Problems)
+
+	if q.Query == "" {
+		problems.Add("q", "q is required")
+	} else if len(q.Query) > maxSearchLength {
+		problems.Add("q", fmt.Sprintf("q must be at most %d characters long", maxSearchLength))
+	}
+
+	q.limit = defaultSearchSize
+	if q.Limit != "" {
+		limit, err := strconv.Atoi(q.Limit)
+		if err != nil || limit < 1 || limit > maxSearchSize {
+			problems.Add("limit", fmt.Sprintf("limit must be a number between 1 and %d", maxSearchSize))
+		}
+		q.limit = limit
+	}
+
+	return problems
+}
+
+func (q *SearchGamesQuery) Size() int {
+	return q.limit
+}
+
+type MergeGamesPayload struct {
+	SourceID uuid.UUID `json:"source_id"`
+	TargetID uuid.UUID `json:"target_id"`
+}
+
+func (p *MergeGamesPayload) Valid(ctx context.Context) validator.Problems {
+	problems := make(validator.Problems)
+
+	if p.SourceID == uuid.Nil {
+		problems.Add("source_id", "source_id is required")
+	}
+	if p.TargetID == uuid.Nil {
+		problems.Add("target_id", "target_id is required")
+	}
+	if p.SourceID != uuid.Nil && p.SourceID == p.TargetID {
+		problems.Add("target_id", "target_id must differ from source_id")
+	}
+
 	return problems
 }
@@ -1,7 +1,6 @@
 package games
 
 import (
-	"errors"
 	"log/slog"
 	"net/http"
 
@@ -10,7 +9,6 @@ import (
 
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
 	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
-	"github.com/kalogs-c/nerd-backlog/pkg/validator"
 )
 
 type HTTPAdapter struct {
@@ -35,27 +33,31 @@ func (h *HTTPAdapter) error(w http.ResponseWriter, r *http.Request, code int, ti
 	)
 }
 
+// serviceError answers with the problem registered for err.
+func (h *HTTPAdapter) serviceError(w http.ResponseWriter, r *http.Request, action string, err error) {
+	httpjson.NotifyProblem(w, r, h.logger, action, err)
+}
+
+func (h *HTTPAdapter) payloadError(w http.ResponseWriter, r *http.Request, err error) {
+	h.serviceError(w, r, "invalid payload", err)
+}
+
 func (h *HTTPAdapter) CreateGame(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	payload, err := httpjson.DecodeValid[*CreateGamePayload](r)
 	if err != nil {
-		switch e := err.(type) {
-		case validator.ValidationError:
-			httpjson.EncodeValidationErrors(w, r, e.Problems)
-		default:
-			h.error(w, r, http.StatusBadRequest, "invalid payload", err)
-		}
+		h.payloadError(w, r, err)
 		return
 	}
 
-	game, err := h.service.CreateGame(ctx, payload.Title)
+	game, err := h.service.CreateGame(ctx, payload.Game())
 	if err != nil {
-		h.error(w, r, http.StatusInternalServerError, "failed to create game", err)
+		h.serviceError(w, r, "failed to create game", err)
 		return
 	}
 
-	if err := httpjson.Encode(w, r, http.StatusCreated, game); err != nil {
+	if err := httpjson.Encode(w, r, http.StatusCreated, MountGameResponse(game)); err != nil {
 		h.error(w, r, http.StatusInternalServerError, "failed to encode game", err)
 	}
 }
@@ -72,27 +74,135 @@ func (h *HTTPAdapter) GetGameByID(w http.ResponseWriter, r *http.Request) {
 
 	game, err := h.service.GetGameByID(ctx, id)
 	if err != nil {
-		h.error(w, r, http.StatusNotFound, "failed to retrieve game", err)
+		h.serviceError(w, r, "failed to retrieve game", err)
 		return
 	}
 
-	if err := httpjson.Encode(w, r, http.StatusOK, game); err != nil {
+	if err := httpjson.Encode(w, r, http.StatusOK, MountGameResponse(game)); err != nil {
 		h.error(w, r, http.StatusInternalServerError, "failed to encode game", err)
 	}
 }
 
 func (h *HTTPAdapter) ListGames(w http.ResponseWriter, r *http.Request) {
-	games, err := h.service.ListGames(r.Context())
+	query := NewListGamesQuery(r.URL.Query())
+	if problems := query.Valid(r.Context()); len(problems) > 0 {
+		httpjson.EncodeValidationErrors(w, r, problems)
+		return
+	}
+
+	params := query.Params()
+	page, err := h.service.ListGames(r.Context(), params)
+	if err != nil {
+		h.serviceError(w, r, "failed to list games", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountGamesPageResponse(page, params)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode games", err)
+	}
+}
+
+func (h *HTTPAdapter) SearchGames(w http.ResponseWriter, r *http.Request) {
+	query := NewSearchGamesQuery(r.URL.Query())
+	if problems := query.Valid(r.Context()); len(problems) > 0 {
+		httpjson.EncodeValidationErrors(w, r, problems)
+		return
+	}
+
+	results, err := h.service.SearchGames(r.Context(), query.Query, query.Size())
 	if err != nil {
-		h.error(w, r, http.StatusInternalServerError, "failed to list games", err)
+		h.serviceError(w, r, "failed to search games", err)
 		return
 	}
 
-	if err := httpjson.Encode(w, r, http.StatusOK, games); err != nil {
+	if err := httpjson.Encode(w, r, http.StatusOK, MountGameSearchResultsResponse(results)); err != nil {
 		h.error(w, r, http.StatusInternalServerError, "failed to encode games", err)
 	}
 }
 
+func (h *HTTPAdapter) UpdateGame(w http.ResponseWriter, r *http.Request) {
+	payload, err := httpjson.DecodeValid[*UpdateGamePayload](r)
+	if err != nil {
+		h.payloadError(w, r, err)
+		return
+	}
+
+	h.updateGame(w, r, payload.Patch())
+}
+
+func (h *HTTPAdapter) ReplaceGame(w http.ResponseWriter, r *http.Request) {
+	payload, err := httpjson.DecodeValid[*CreateGamePayload](r)
+	if err != nil {
+		h.payloadError(w, r, err)
+		return
+	}
+
+	h.updateGame(w, r, payload.Patch())
+}
+
+func (h *HTTPAdapter) updateGame(w http.ResponseWriter, r *http.Request, patch domain.GamePatch) {
+	ctx := r.Context()
+	idString := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	game, err := h.service.UpdateGame(ctx, id, patch)
+	if err != nil {
+		h.serviceError(w, r, "failed to update game", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountGameResponse(game)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode game", err)
+	}
+}
+
+func (h *HTTPAdapter) EnrichGame(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idString := chi.URLParam(r, "id")
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	game, err := h.service.EnrichGame(ctx, id)
+	if err != nil {
+		h.serviceError(w, r, "failed to enrich game", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountGameResponse(game)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode game", err)
+	}
+}
+
+// MergeGames folds a duplicate game into the one that survives.
+func (h *HTTPAdapter) MergeGames(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	payload, err := httpjson.DecodeValid[*MergeGamesPayload](r)
+	if err != nil {
+		h.payloadError(w, r, err)
+		return
+	}
+
+	game, err := h.service.MergeGames(ctx, payload.SourceID, payload.TargetID)
+	if err != nil {
+		h.serviceError(w, r, "failed to merge games", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountGameResponse(game)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode game", err)
+	}
+}
+
 func (h *HTTPAdapter) DeleteGameByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	idString := chi.URLParam(r, "id")
@@ -105,12 +215,7 @@ func (h *HTTPAdapter) DeleteGameByID(w http.ResponseWriter, r *http.Request) {
 
 	err = h.service.DeleteGameByID(ctx, id)
 	if err != nil {
-		switch {
-		case errors.Is(err, domain.ErrGameNotFound):
-			h.error(w, r, http.StatusNotFound, "game not found", err)
-		default:
-			h.error(w, r, http.StatusBadRequest, "failed to delete game", err)
-		}
+		h.serviceError(w, r, "failed to delete game", err)
 		return
 	}
 
@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -16,8 +18,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/httpserver/problems"
 )
 
+// Adapters answer with the problems the router registers.
+func init() {
+	problems.Register()
+}
+
 func withRouteParam(req *http.Request, key, value string) *http.Request {
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add(key, value)
@@ -30,7 +38,7 @@ func TestHTTPAdapter_CreateGame(t *testing.T) {
 	handler := NewHTTPAdapter(mockSvc, logger)
 
 	want := domain.Game{ID: uuid.New(), Title: "Zelda"}
-	mockSvc.On("CreateGame", mock.Anything, "Zelda").Return(want, nil)
+	mockSvc.On("CreateGame", mock.Anything, domain.Game{Title: "Zelda"}).Return(want, nil)
 
 	body := bytes.NewBufferString(`{"title":"Zelda"}`)
 	req := httptest.NewRequest(http.MethodPost, "/games", body)
@@ -66,6 +74,76 @@ func TestHTTPAdapter_CreateGame_BadPayload(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestHTTPAdapter_CreateGame_WithMetadata(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	gameIn := domain.Game{
+		Title:       "Celeste",
+		ReleaseDate: time.Date(2018, time.January, 25, 0, 0, 0, 0, time.UTC),
+		Developer:   "Maddy Makes Games",
+		CoverURL:    "https://example.com/celeste.png",
+		Platforms:   []string{"pc", "switch"},
+		Genres:      []string{"platformer"},
+	}
+	want := gameIn
+	want.ID = uuid.New()
+	mockSvc.On("CreateGame", mock.Anything, gameIn).Return(want, nil)
+
+	body := bytes.NewBufferString(`{
+		"title": " Celeste ",
+		"release_date": "2018-01-25",
+		"developer": "Maddy Makes Games",
+		"cover_url": "https://example.com/celeste.png",
+		"platforms": ["pc", "switch", "pc"],
+		"genres": ["platformer"]
+	}`)
+	req := httptest.NewRequest(http.MethodPost, "/games", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateGame(w, req)
+	res := w.Result()
+
+	require.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var got GameResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	require.Equal(t, want.ID, got.ID)
+	require.NotNil(t, got.ReleaseDate)
+	require.Equal(t, "2018-01-25", *got.ReleaseDate)
+	require.Equal(t, []string{"pc", "switch"}, got.Platforms)
+	require.Equal(t, []string{"platformer"}, got.Genres)
+	require.NoError(t, res.Body.Close())
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_CreateGame_InvalidMetadata(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	body := bytes.NewBufferString(`{
+		"title": "  ",
+		"release_date": "25/01/2018",
+		"cover_url": "ftp://example.com/cover.png",
+		"platforms": [""]
+	}`)
+	req := httptest.NewRequest(http.MethodPost, "/games", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	handler.CreateGame(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	for _, field := range []string{"title", "release_date", "cover_url", "platforms"} {
+		require.Contains(t, w.Body.String(), field)
+	}
+	mockSvc.AssertNotCalled(t, "CreateGame", mock.Anything, mock.Anything)
+}
+
 func TestHTTPAdapter_GetGameByID(t *testing.T) {
 	mockSvc := new(MockGameService)
 	logger := slog.Default()
@@ -107,11 +185,12 @@ func TestHTTPAdapter_ListGames(t *testing.T) {
 	logger := slog.Default()
 	handler := NewHTTPAdapter(mockSvc, logger)
 
-	want := []domain.Game{
+	params := domain.ListGamesParams{Sort: domain.GameSortTitle, Limit: defaultPageLimit}
+	want := domain.GamePage{Games: []domain.Game{
 		{ID: uuid.New(), Title: "Hades"},
 		{ID: uuid.New(), Title: "Celeste"},
-	}
-	mockSvc.On("ListGames", mock.Anything).Return(want, nil)
+	}}
+	mockSvc.On("ListGames", mock.Anything, params).Return(want, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/games", nil)
 	w := httptest.NewRecorder()
@@ -120,13 +199,81 @@ func TestHTTPAdapter_ListGames(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, w.Code)
 
-	var got []domain.Game
+	var got GamesPageResponse
 	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
-	require.Len(t, got, 2)
-	require.Equal(t, "Hades", got[0].Title)
+	require.Len(t, got.Items, 2)
+	require.Equal(t, "Hades", got.Items[0].Title)
+	require.Nil(t, got.NextCursor)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_ListGames_Cursor(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	first := domain.ListGamesParams{
+		Sort:       domain.GameSortReleaseDate,
+		Descending: true,
+		Platform:   "switch",
+		Genre:      "platformer",
+		Limit:      1,
+	}
+	next := &domain.GameCursor{SortKey: "2018-01-25", ID: uuid.New()}
+	mockSvc.On("ListGames", mock.Anything, first).Return(domain.GamePage{
+		Games: []domain.Game{{ID: next.ID, Title: "Celeste"}},
+		Next:  next,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/games?sort=-release_date&limit=1&platform=switch&genre=platformer", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListGames(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page GamesPageResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.NotNil(t, page.NextCursor)
+
+	second := first
+	second.After = next
+	mockSvc.On("ListGames", mock.Anything, second).Return(domain.GamePage{}, nil)
+
+	req = httptest.NewRequest(http.MethodGet, "/games?sort=-release_date&limit=1&platform=switch&genre=platformer&cursor="+*page.NextCursor, nil)
+	w = httptest.NewRecorder()
+
+	handler.ListGames(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"items":[],"next_cursor":null}`, w.Body.String())
 	mockSvc.AssertExpectations(t)
 }
 
+func TestHTTPAdapter_ListGames_InvalidQuery(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	cursor := encodeCursor(domain.ListGamesParams{Sort: domain.GameSortTitle}, domain.GameCursor{SortKey: "hades", ID: uuid.New()})
+	badKey := encodeCursor(domain.ListGamesParams{Sort: domain.GameSortReleaseDate}, domain.GameCursor{SortKey: "hades", ID: uuid.New()})
+
+	for _, query := range []string{
+		"sort=rating",
+		"limit=0",
+		"limit=1000",
+		"cursor=not-a-cursor",
+		"sort=inserted_at&cursor=" + cursor,
+		"sort=release_date&cursor=" + badKey,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/games?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.ListGames(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, query)
+	}
+
+	mockSvc.AssertNotCalled(t, "ListGames", mock.Anything, mock.Anything)
+}
+
 func TestHTTPAdapter_DeleteGameByID(t *testing.T) {
 	mockSvc := new(MockGameService)
 	logger := slog.Default()
@@ -170,6 +317,239 @@ func TestHTTPAdapter_DeleteGameByID_ServiceError(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	handler.DeleteGameByID(w, req)
-	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	require.NotContains(t, w.Body.String(), "delete failed")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_UpdateGame_MergePatch(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	id := uuid.New()
+	title := "Hades II"
+	releaseDate := time.Time{}
+	genres := []string(nil)
+	patch := domain.GamePatch{
+		Title:       &title,
+		ReleaseDate: &releaseDate,
+		Genres:      &genres,
+	}
+	want := domain.Game{ID: id, Title: title, Developer: "Supergiant Games"}
+	mockSvc.On("UpdateGame", mock.Anything, id, patch).Return(want, nil)
+
+	body := bytes.NewBufferString(`{"title":"Hades II","release_date":null,"genres":null}`)
+	req := httptest.NewRequest(http.MethodPatch, "/games/"+id.String(), body)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.UpdateGame(w, req)
+	res := w.Result()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var got GameResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	require.Equal(t, "Hades II", got.Title)
+	require.Equal(t, "Supergiant Games", got.Developer)
+	require.Nil(t, got.ReleaseDate)
+	require.NoError(t, res.Body.Close())
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_UpdateGame_NullTitle(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	id := uuid.New()
+	body := bytes.NewBufferString(`{"title":null}`)
+	req := httptest.NewRequest(http.MethodPatch, "/games/"+id.String(), body)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.UpdateGame(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	mockSvc.AssertNotCalled(t, "UpdateGame", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHTTPAdapter_UpdateGame_NotFound(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	id := uuid.New()
+	mockSvc.On("UpdateGame", mock.Anything, id, mock.Anything).Return(domain.Game{}, domain.ErrGameNotFound)
+
+	body := bytes.NewBufferString(`{"summary":"A roguelike."}`)
+	req := httptest.NewRequest(http.MethodPatch, "/games/"+id.String(), body)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.UpdateGame(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_ReplaceGame(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	id := uuid.New()
+	mockSvc.On("UpdateGame", mock.Anything, id, mock.MatchedBy(func(patch domain.GamePatch) bool {
+		return *patch.Title == "Celeste" && patch.Summary != nil && *patch.Summary == "" && patch.Platforms != nil
+	})).Return(domain.Game{ID: id, Title: "Celeste"}, nil)
+
+	body := bytes.NewBufferString(`{"title":"Celeste"}`)
+	req := httptest.NewRequest(http.MethodPut, "/games/"+id.String(), body)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.ReplaceGame(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_SearchGames(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	id := uuid.New()
+	mockSvc.On("SearchGames", mock.Anything, "witcher 3", defaultSearchSize).Return([]domain.GameSearchResult{{
+		Game:         domain.Game{ID: id, Title: "The Witcher 3: Wild Hunt"},
+		Score:        0.75,
+		TitleSnippet: "The <mark>Witcher</mark> <mark>3</mark>: Wild Hunt",
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/games/search?q=+witcher+3+", nil)
+	w := httptest.NewRecorder()
+
+	handler.SearchGames(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got []GameSearchResultResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Len(t, got, 1)
+	require.Equal(t, id, got[0].ID)
+	require.Equal(t, 0.75, got[0].Score)
+	require.Equal(t, "The <mark>Witcher</mark> <mark>3</mark>: Wild Hunt", got[0].TitleSnippet)
 	mockSvc.AssertExpectations(t)
 }
+
+func TestHTTPAdapter_SearchGames_InvalidQuery(t *testing.T) {
+	mockSvc := new(MockGameService)
+	logger := slog.Default()
+	handler := NewHTTPAdapter(mockSvc, logger)
+
+	for _, query := range []string{"", "q=+", "q=zelda&limit=0", "q=zelda&limit=51"} {
+		req := httptest.NewRequest(http.MethodGet, "/games/search?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchGames(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, query)
+	}
+
+	mockSvc.AssertNotCalled(t, "SearchGames", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHTTPAdapter_EnrichGame(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"enriched", nil, http.StatusOK},
+		{"unknown game", domain.ErrGameNotFound, http.StatusNotFound},
+		{"no metadata", domain.ErrMetadataNotFound, http.StatusNotFound},
+		{"not configured", domain.ErrMetadataUnavailable, http.StatusServiceUnavailable},
+		{"provider down", fmt.Errorf("%w: %w", domain.ErrMetadataFetch, errors.New("call igdb /games: unexpected status 500")), http.StatusBadGateway},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockSvc := new(MockGameService)
+			handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+			id := uuid.New()
+			mockSvc.On("EnrichGame", mock.Anything, id).Return(domain.Game{ID: id, Title: "Hades"}, tc.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/games/"+id.String()+"/enrich", nil)
+			req = withRouteParam(req, "id", id.String())
+			w := httptest.NewRecorder()
+
+			handler.EnrichGame(w, req)
+
+			require.Equal(t, tc.code, w.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHTTPAdapter_MergeGames(t *testing.T) {
+	mockSvc := new(MockGameService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	sourceID, targetID := uuid.New(), uuid.New()
+	mockSvc.On("MergeGames", mock.Anything, sourceID, targetID).Return(domain.Game{ID: targetID, Title: "Hades"}, nil)
+
+	body := bytes.NewBufferString(`{"source_id":"` + sourceID.String() + `","target_id":"` + targetID.String() + `"}`)
+	req := httptest.NewRequest(http.MethodPost, "/admin/games/merge", body)
+	w := httptest.NewRecorder()
+
+	handler.MergeGames(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got GameResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Equal(t, targetID, got.ID)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_MergeGames_InvalidPayload(t *testing.T) {
+	id := uuid.New().String()
+	cases := map[string]string{
+		"missing ids": `{}`,
+		"same game":   `{"source_id":"` + id + `","target_id":"` + id + `"}`,
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			mockSvc := new(MockGameService)
+			handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/games/merge", bytes.NewBufferString(body))
+			w := httptest.NewRecorder()
+
+			handler.MergeGames(w, req)
+
+			require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			mockSvc.AssertNotCalled(t, "MergeGames", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestHTTPAdapter_MergeGames_NotFound(t *testing.T) {
+	mockSvc := new(MockGameService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	sourceID, targetID := uuid.New(), uuid.New()
+	mockSvc.On("MergeGames", mock.Anything, sourceID, targetID).Return(domain.Game{}, domain.ErrGameNotFound)
+
+	body := bytes.NewBufferString(`{"source_id":"` + sourceID.String() + `","target_id":"` + targetID.String() + `"}`)
+	req := httptest.NewRequest(http.MethodPost, "/admin/games/merge", body)
+	w := httptest.NewRecorder()
+
+	handler.MergeGames(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -2,63 +2,574 @@ package games
 
 import (
 	"context"
-	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
 	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
 )
 
 type repository struct {
-	db *sqlc.Queries
+	db   *sqlc.Queries
+	pool postgres.TxBeginner
 }
 
-func NewRepository(q *sqlc.Queries) domain.GameRepository {
-	return &repository{q}
+func NewRepository(q *sqlc.Queries, pool postgres.TxBeginner) domain.GameRepository {
+	return &repository{q, pool}
 }
 
 func (r *repository) CreateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
-	insertedGame, err := r.db.CreateGame(ctx, game.Title)
+	return r.createGame(ctx, game, nil)
+}
+
+func (r *repository) CreateGameWithExternalIDs(
+	ctx context.Context,
+	game domain.Game,
+	externalIDs []domain.ExternalID,
+) (domain.Game, error) {
+	created, err := r.createGame(ctx, game, externalIDs)
+
+	var taken externalIDTakenError
+	if errors.As(err, &taken) {
+		return r.GetGameByExternalID(ctx, taken.externalID)
+	}
+
+	return created, err
+}
+
+// externalIDTakenError rolls back a game whose external ID turned out to be
+// mapped to another game already.
+type externalIDTakenError struct {
+	externalID domain.ExternalID
+}
+
+func (e externalIDTakenError) Error() string {
+	return fmt.Sprintf("external id %s:%s already mapped", e.externalID.Source, e.externalID.ID)
+}
+
+func (r *repository) createGame(ctx context.Context, game domain.Game, externalIDs []domain.ExternalID) (domain.Game, error) {
+	var insertedGame sqlc.Game
+	err := postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		var err error
+		insertedGame, err = q.CreateGame(ctx, sqlc.CreateGameParams{
+			Title:           game.Title,
+			NormalizedTitle: domain.NormalizeTitle(game.Title),
+			ReleaseDate:     date(game.ReleaseDate),
+			Summary:         game.Summary,
+			Developer:       game.Developer,
+			Publisher:       game.Publisher,
+			CoverUrl:        game.CoverURL,
+			CreatedBy:       pgtype.UUID{Bytes: game.CreatedBy, Valid: game.CreatedBy != uuid.Nil},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Claiming the IDs waits on any transaction mapping them concurrently,
+		// so only one of two racing imports creates the game.
+		for _, externalID := range externalIDs {
+			_, err := q.ClaimExternalID(ctx, sqlc.ClaimExternalIDParams{
+				Provider:   string(externalID.Source),
+				ExternalID: externalID.ID,
+				GameID:     insertedGame.ID,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				return externalIDTakenError{externalID}
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return addCategories(ctx, q, insertedGame.ID, game.Platforms, game.Genres)
+	})
 	if err != nil {
 		return domain.Game{}, err
 	}
 
-	return domain.Game{
-		ID:    insertedGame.ID,
-		Title: insertedGame.Title,
-	}, nil
+	created := toDomainGame(insertedGame)
+	created.Platforms = game.Platforms
+	created.Genres = game.Genres
+
+	return created, nil
 }
 
 func (r *repository) GetGameByID(ctx context.Context, id uuid.UUID) (domain.Game, error) {
 	game, err := r.db.GetGame(ctx, id)
-	if err == sql.ErrNoRows {
-		return domain.Game{}, domain.ErrGameNotFound
+	if err != nil {
+		return domain.Game{}, postgres.TranslateError(err, domain.ErrGameNotFound)
+	}
+
+	games, err := r.withCategories(ctx, []sqlc.Game{game})
+	if err != nil {
+		return domain.Game{}, postgres.TranslateError(err, nil)
+	}
+
+	return games[0], nil
+}
+
+func (r *repository) GetGameByNormalizedTitle(ctx context.Context, normalizedTitle string) (domain.Game, error) {
+	game, err := r.db.GetGameByNormalizedTitle(ctx, normalizedTitle)
+	return r.oneGame(ctx, game, err)
+}
+
+func (r *repository) GetGameByExternalID(ctx context.Context, externalID domain.ExternalID) (domain.Game, error) {
+	game, err := r.db.GetGameByExternalID(ctx, sqlc.GetGameByExternalIDParams{
+		Provider:   string(externalID.Source),
+		ExternalID: externalID.ID,
+	})
+	return r.oneGame(ctx, game, err)
+}
+
+func (r *repository) oneGame(ctx context.Context, game sqlc.Game, err error) (domain.Game, error) {
+	if err != nil {
+		return domain.Game{}, postgres.TranslateError(err, domain.ErrGameNotFound)
+	}
+
+	games, err := r.withCategories(ctx, []sqlc.Game{game})
+	if err != nil {
+		return domain.Game{}, postgres.TranslateError(err, nil)
+	}
+
+	return games[0], nil
+}
+
+func (r *repository) ListGames(ctx context.Context, params domain.ListGamesParams) (domain.GamePage, error) {
+	rows, sortKeys, err := r.listGames(ctx, params)
+	if err != nil {
+		return domain.GamePage{}, postgres.TranslateError(err, nil)
+	}
+
+	var next *domain.GameCursor
+	if len(rows) > params.Limit {
+		rows = rows[:params.Limit]
+		next = &domain.GameCursor{SortKey: sortKeys[len(rows)-1], ID: rows[len(rows)-1].ID}
+	}
+
+	gamesList, err := r.withCategories(ctx, rows)
+	if err != nil {
+		return domain.GamePage{}, postgres.TranslateError(err, nil)
+	}
+
+	return domain.GamePage{Games: gamesList, Next: next}, nil
+}
+
+// listGames runs the query for the requested order, each of which resumes
+// after the cursor on an indexed column, and returns the sort key of every
+// game. Title keys come from Postgres, whose lowercasing is what the query
+// compares; dates and times format the same in Go.
+func (r *repository) listGames(ctx context.Context, params domain.ListGamesParams) ([]sqlc.Game, []string, error) {
+	var afterID pgtype.UUID
+	var afterKey string
+	if params.After != nil {
+		afterID = pgtype.UUID{Bytes: params.After.ID, Valid: true}
+		afterKey = params.After.SortKey
+	}
+	platform, genre, titlePrefix := text(params.Platform), text(params.Genre), text(params.TitlePrefix)
+	limit := int32(params.Limit + 1)
+
+	var games []sqlc.Game
+	var err error
+	switch params.Sort {
+	case domain.GameSortInsertedAt:
+		var after pgtype.Timestamptz
+		if params.After != nil {
+			insertedAt, err := time.Parse(domain.GameSortKeyTimeLayout, afterKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			after = pgtype.Timestamptz{Time: insertedAt, Valid: true}
+		}
+
+		arg := sqlc.ListGamesByInsertedAtParams{
+			Platform:        platform,
+			Genre:           genre,
+			TitlePrefix:     titlePrefix,
+			AfterID:         afterID,
+			AfterInsertedAt: after,
+			PageLimit:       limit,
+		}
+		if params.Descending {
+			games, err = r.db.ListGamesByInsertedAtDesc(ctx, sqlc.ListGamesByInsertedAtDescParams(arg))
+		} else {
+			games, err = r.db.ListGamesByInsertedAt(ctx, arg)
+		}
+
+	case domain.GameSortReleaseDate:
+		var after pgtype.Date
+		if afterKey != "" {
+			releaseDate, err := time.Parse(time.DateOnly, afterKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			after = date(releaseDate)
+		}
+
+		arg := sqlc.ListGamesByReleaseDateParams{
+			Platform:         platform,
+			Genre:            genre,
+			TitlePrefix:      titlePrefix,
+			AfterID:          afterID,
+			AfterReleaseDate: after,
+			PageLimit:        limit,
+		}
+		if params.Descending {
+			games, err = r.db.ListGamesByReleaseDateDesc(ctx, sqlc.ListGamesByReleaseDateDescParams(arg))
+		} else {
+			games, err = r.db.ListGamesByReleaseDate(ctx, arg)
+		}
+
+	default:
+		arg := sqlc.ListGamesByTitleParams{
+			Platform:    platform,
+			Genre:       genre,
+			TitlePrefix: titlePrefix,
+			AfterID:     afterID,
+			AfterTitle:  afterKey,
+			PageLimit:   limit,
+		}
+
+		var rows []sqlc.ListGamesByTitleRow
+		if params.Descending {
+			var descending []sqlc.ListGamesByTitleDescRow
+			descending, err = r.db.ListGamesByTitleDesc(ctx, sqlc.ListGamesByTitleDescParams(arg))
+			for _, row := range descending {
+				rows = append(rows, sqlc.ListGamesByTitleRow(row))
+			}
+		} else {
+			rows, err = r.db.ListGamesByTitle(ctx, arg)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		games = make([]sqlc.Game, len(rows))
+		sortKeys := make([]string, len(rows))
+		for i, row := range rows {
+			games[i] = sqlc.Game{
+				ID:              row.ID,
+				Title:           row.Title,
+				ReleaseDate:     row.ReleaseDate,
+				Summary:         row.Summary,
+				Developer:       row.Developer,
+				Publisher:       row.Publisher,
+				CoverUrl:        row.CoverUrl,
+				InsertedAt:      row.InsertedAt,
+				UpdatedAt:       row.UpdatedAt,
+				NormalizedTitle: row.NormalizedTitle,
+				CreatedBy:       row.CreatedBy,
+			}
+			sortKeys[i] = row.SortKey
+		}
+		return games, sortKeys, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sortKeys := make([]string, len(games))
+	for i, game := range games {
+		sortKeys[i] = domain.GameSortKey(toDomainGame(game), params.Sort)
+	}
+	return games, sortKeys, nil
+}
+
+func (r *repository) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameSearchResult, error) {
+	rows, err := r.db.SearchGames(ctx, sqlc.SearchGamesParams{
+		Query:       query,
+		ResultLimit: int32(limit),
+	})
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	games := make([]sqlc.Game, len(rows))
+	for i, row := range rows {
+		games[i] = sqlc.Game{
+			ID:          row.ID,
+			Title:       row.Title,
+			ReleaseDate: row.ReleaseDate,
+			Summary:     row.Summary,
+			Developer:   row.Developer,
+			Publisher:   row.Publisher,
+			CoverUrl:    row.CoverUrl,
+			InsertedAt:  row.InsertedAt,
+			UpdatedAt:   row.UpdatedAt,
+			CreatedBy:   row.CreatedBy,
+		}
+	}
+
+	gamesList, err := r.withCategories(ctx, games)
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	results := make([]domain.GameSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = domain.GameSearchResult{
+			Game:           gamesList[i],
+			Score:          float64(row.Score),
+			TitleSnippet:   highlight(row.TitleSnippet),
+			SummarySnippet: highlight(row.SummarySnippet),
+		}
 	}
 
+	return results, nil
+}
+
+func (r *repository) UpdateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	var updatedGame sqlc.Game
+	err := postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		var err error
+		updatedGame, err = updateGame(ctx, q, game)
+		return postgres.TranslateError(err, nil)
+	})
 	if err != nil {
 		return domain.Game{}, err
 	}
 
-	return domain.Game{
-		ID:    game.ID,
-		Title: game.Title,
-	}, nil
+	updated := toDomainGame(updatedGame)
+	updated.Platforms = game.Platforms
+	updated.Genres = game.Genres
+
+	return updated, nil
 }
 
-func (r *repository) ListGames(ctx context.Context) ([]domain.Game, error) {
-	games, err := r.db.ListGames(ctx)
+func (r *repository) ListExternalIDs(ctx context.Context, gameID uuid.UUID) ([]domain.ExternalID, error) {
+	rows, err := r.db.ListExternalIDs(ctx, gameID)
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	externalIDs := make([]domain.ExternalID, len(rows))
+	for i, row := range rows {
+		externalIDs[i] = domain.ExternalID{Source: domain.ExternalSource(row.Provider), ID: row.ExternalID}
+	}
+
+	return externalIDs, nil
+}
+
+// AddExternalIDs maps externalIDs to gameID. IDs already mapped, to this game
+// or another one, are left alone.
+func (r *repository) AddExternalIDs(ctx context.Context, gameID uuid.UUID, externalIDs []domain.ExternalID) error {
+	for _, externalID := range externalIDs {
+		err := r.db.AddExternalID(ctx, sqlc.AddExternalIDParams{
+			Provider:   string(externalID.Source),
+			ExternalID: externalID.ID,
+			GameID:     gameID,
+		})
+		if err != nil {
+			return postgres.TranslateError(err, nil)
+		}
+	}
+
+	return nil
+}
+
+func (r *repository) MergeGames(ctx context.Context, sourceID uuid.UUID, target domain.Game) (domain.Game, error) {
+	var mergedGame sqlc.Game
+	err := postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		var err error
+		mergedGame, err = updateGame(ctx, q, target)
+		if err != nil {
+			return err
+		}
+
+		// Accounts tracking both games keep one entry: the source's entry is
+		// folded into the target's and hands over its history before it goes.
+		err = q.MergeCollidingLibraryEntries(ctx, sqlc.MergeCollidingLibraryEntriesParams{
+			SourceID: sourceID,
+			TargetID: target.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.RepointCollidingLibraryEntryEvents(ctx, sqlc.RepointCollidingLibraryEntryEventsParams{
+			TargetID: target.ID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteCollidingLibraryEntries(ctx, sqlc.DeleteCollidingLibraryEntriesParams{
+			SourceID: sourceID,
+			TargetID: target.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.RepointLibraryEntries(ctx, sqlc.RepointLibraryEntriesParams{
+			TargetID: target.ID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.RepointLibraryEntryEvents(ctx, sqlc.RepointLibraryEntryEventsParams{
+			TargetID: target.ID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.RepointExternalIDs(ctx, sqlc.RepointExternalIDsParams{
+			TargetID: target.ID,
+			SourceID: sourceID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.RepointImportJobItems(ctx, sqlc.RepointImportJobItemsParams{
+			TargetID: pgtype.UUID{Bytes: target.ID, Valid: true},
+			SourceID: pgtype.UUID{Bytes: sourceID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteGameByID(ctx, sourceID)
+	})
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	merged := toDomainGame(mergedGame)
+	merged.Platforms = target.Platforms
+	merged.Genres = target.Genres
+
+	return merged, nil
+}
+
+func (r *repository) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
+	return postgres.TranslateError(r.db.DeleteGameByID(ctx, id), nil)
+}
+
+// withCategories converts games to their domain form, loading platforms and
+// genres for all of them in one query each.
+func (r *repository) withCategories(ctx context.Context, games []sqlc.Game) ([]domain.Game, error) {
+	ids := make([]uuid.UUID, len(games))
+	for i, game := range games {
+		ids[i] = game.ID
+	}
+
+	platforms, err := r.db.ListGamePlatforms(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	genres, err := r.db.ListGameGenres(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
 
+	platformsByGame := make(map[uuid.UUID][]string, len(games))
+	for _, platform := range platforms {
+		platformsByGame[platform.GameID] = append(platformsByGame[platform.GameID], platform.Platform)
+	}
+
+	genresByGame := make(map[uuid.UUID][]string, len(games))
+	for _, genre := range genres {
+		genresByGame[genre.GameID] = append(genresByGame[genre.GameID], genre.Genre)
+	}
+
 	gamesList := make([]domain.Game, len(games))
 	for i, game := range games {
-		gamesList[i] = domain.Game{ID: game.ID, Title: game.Title}
+		gamesList[i] = toDomainGame(game)
+		gamesList[i].Platforms = platformsByGame[game.ID]
+		gamesList[i].Genres = genresByGame[game.ID]
 	}
 
 	return gamesList, nil
 }
 
-func (r *repository) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
-	return r.db.DeleteGameByID(ctx, id)
+// updateGame overwrites a game's fields and categories using q, which is
+// expected to be bound to a transaction.
+func updateGame(ctx context.Context, q *sqlc.Queries, game domain.Game) (sqlc.Game, error) {
+	updatedGame, err := q.UpdateGame(ctx, sqlc.UpdateGameParams{
+		ID:              game.ID,
+		Title:           game.Title,
+		NormalizedTitle: domain.NormalizeTitle(game.Title),
+		ReleaseDate:     date(game.ReleaseDate),
+		Summary:         game.Summary,
+		Developer:       game.Developer,
+		Publisher:       game.Publisher,
+		CoverUrl:        game.CoverURL,
+	})
+	if err != nil {
+		return sqlc.Game{}, postgres.TranslateError(err, domain.ErrGameNotFound)
+	}
+
+	if err := q.DeleteGamePlatforms(ctx, game.ID); err != nil {
+		return sqlc.Game{}, err
+	}
+
+	if err := q.DeleteGameGenres(ctx, game.ID); err != nil {
+		return sqlc.Game{}, err
+	}
+
+	return updatedGame, addCategories(ctx, q, game.ID, game.Platforms, game.Genres)
+}
+
+func addCategories(ctx context.Context, q *sqlc.Queries, gameID uuid.UUID, platforms []string, genres []string) error {
+	for _, platform := range platforms {
+		err := q.AddGamePlatform(ctx, sqlc.AddGamePlatformParams{GameID: gameID, Platform: platform})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, genre := range genres {
+		err := q.AddGameGenre(ctx, sqlc.AddGameGenreParams{GameID: gameID, Genre: genre})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toDomainGame(game sqlc.Game) domain.Game {
+	return domain.Game{
+		ID:          game.ID,
+		Title:       game.Title,
+		ReleaseDate: game.ReleaseDate.Time,
+		Summary:     game.Summary,
+		Developer:   game.Developer,
+		Publisher:   game.Publisher,
+		CoverURL:    game.CoverUrl,
+		CreatedBy:   game.CreatedBy.Bytes,
+		TimeStamps: domain.TimeStamps{
+			InsertedAt: game.InsertedAt.Time,
+			UpdatedAt:  game.UpdatedAt.Time,
+		},
+	}
+}
+
+// highlightMarkers turns the private-use markers SearchGames wraps matches in
+// into <mark> tags once the snippet itself has been escaped.
+var highlightMarkers = strings.NewReplacer("\ue000", "<mark>", "\ue001", "</mark>")
+
+func highlight(snippet string) string {
+	return highlightMarkers.Replace(html.EscapeString(snippet))
+}
+
+func text(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func date(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: t, Valid: !t.IsZero()}
 }
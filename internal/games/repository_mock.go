@@ -26,9 +26,53 @@ func (m *MockGameRepository) GetGameByID(ctx context.Context, id uuid.UUID) (dom
 	return args.Get(0).(domain.Game), args.Error(1)
 }
 
-func (m *MockGameRepository) ListGames(ctx context.Context) ([]domain.Game, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]domain.Game), args.Error(1)
+func (m *MockGameRepository) GetGameByNormalizedTitle(ctx context.Context, normalizedTitle string) (domain.Game, error) {
+	args := m.Called(ctx, normalizedTitle)
+	return args.Get(0).(domain.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) GetGameByExternalID(ctx context.Context, externalID domain.ExternalID) (domain.Game, error) {
+	args := m.Called(ctx, externalID)
+	return args.Get(0).(domain.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) ListGames(ctx context.Context, params domain.ListGamesParams) (domain.GamePage, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(domain.GamePage), args.Error(1)
+}
+
+func (m *MockGameRepository) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameSearchResult, error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).([]domain.GameSearchResult), args.Error(1)
+}
+
+func (m *MockGameRepository) UpdateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	args := m.Called(ctx, game)
+	return args.Get(0).(domain.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) ListExternalIDs(ctx context.Context, gameID uuid.UUID) ([]domain.ExternalID, error) {
+	args := m.Called(ctx, gameID)
+	return args.Get(0).([]domain.ExternalID), args.Error(1)
+}
+
+func (m *MockGameRepository) AddExternalIDs(ctx context.Context, gameID uuid.UUID, externalIDs []domain.ExternalID) error {
+	args := m.Called(ctx, gameID, externalIDs)
+	return args.Error(0)
+}
+
+func (m *MockGameRepository) CreateGameWithExternalIDs(
+	ctx context.Context,
+	game domain.Game,
+	externalIDs []domain.ExternalID,
+) (domain.Game, error) {
+	args := m.Called(ctx, game, externalIDs)
+	return args.Get(0).(domain.Game), args.Error(1)
+}
+
+func (m *MockGameRepository) MergeGames(ctx context.Context, sourceID uuid.UUID, target domain.Game) (domain.Game, error) {
+	args := m.Called(ctx, sourceID, target)
+	return args.Get(0).(domain.Game), args.Error(1)
 }
 
 func (m *MockGameRepository) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
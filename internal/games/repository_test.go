@@ -7,6 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
 	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
 	"github.com/kalogs-c/nerd-backlog/internal/testutils"
@@ -16,6 +19,7 @@ import (
 )
 
 var testQueries *sqlc.Queries
+var testDB *pgxpool.Pool
 
 func TestMain(m *testing.M) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -26,9 +30,9 @@ func TestMain(m *testing.M) {
 		log.Fatalln(err)
 	}
 
-	db := postgres.MustConnect(ctx, dsn, nil)
-	gooseProvider := migrations.MustProvide(db)
-	testQueries = sqlc.New(db)
+	testDB = postgres.MustConnect(ctx, dsn, nil)
+	gooseProvider := migrations.MustProvide(testDB)
+	testQueries = sqlc.New(testDB)
 
 	_, err = gooseProvider.Up(context.Background())
 	if err != nil {
@@ -45,7 +49,7 @@ func TestMain(m *testing.M) {
 }
 
 func TestRepository_CreateAndGetGame(t *testing.T) {
-	repo := NewRepository(testQueries)
+	repo := NewRepository(testQueries, testDB)
 	ctx := context.Background()
 
 	game, err := repo.CreateGame(ctx, domain.Game{Title: "Backlog, the game"})
@@ -58,8 +62,201 @@ func TestRepository_CreateAndGetGame(t *testing.T) {
 	require.Equal(t, game.ID, got.ID)
 }
 
+func TestRepository_CreateGameWithMetadata(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+
+	game, err := repo.CreateGame(ctx, domain.Game{
+		Title:       "Hollow Knight",
+		ReleaseDate: time.Date(2017, time.February, 24, 0, 0, 0, 0, time.UTC),
+		Summary:     "A challenging 2D action-adventure.",
+		Developer:   "Team Cherry",
+		Publisher:   "Team Cherry",
+		CoverURL:    "https://example.com/hollow-knight.png",
+		Platforms:   []string{"pc", "switch"},
+		Genres:      []string{"metroidvania"},
+	})
+	require.NoError(t, err)
+
+	got, err := repo.GetGameByID(ctx, game.ID)
+	require.NoError(t, err)
+	require.Equal(t, "2017-02-24", got.ReleaseDate.Format("2006-01-02"))
+	require.Equal(t, "Team Cherry", got.Developer)
+	require.Equal(t, "https://example.com/hollow-knight.png", got.CoverURL)
+	require.ElementsMatch(t, []string{"pc", "switch"}, got.Platforms)
+	require.Equal(t, []string{"metroidvania"}, got.Genres)
+
+	page, err := repo.ListGames(ctx, domain.ListGamesParams{
+		Sort:        domain.GameSortTitle,
+		TitlePrefix: "hollow",
+		Limit:       50,
+	})
+	require.NoError(t, err)
+
+	for _, listed := range page.Games {
+		if listed.ID == game.ID {
+			require.ElementsMatch(t, []string{"pc", "switch"}, listed.Platforms)
+		}
+	}
+}
+
+func TestRepository_ListGames_Pagination(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+
+	genre := "pagination-" + uuid.NewString()
+	titles := []string{"Bravo", "alpha", "Charlie", "delta", "Echo"}
+	for i, title := range titles {
+		_, err := repo.CreateGame(ctx, domain.Game{
+			Title:       title,
+			ReleaseDate: time.Date(2000+i, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Genres:      []string{genre},
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := repo.CreateGame(ctx, domain.Game{Title: "Foxtrot", Genres: []string{genre}})
+	require.NoError(t, err)
+
+	collect := func(params domain.ListGamesParams) []string {
+		var got []string
+		for {
+			page, err := repo.ListGames(ctx, params)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Games), params.Limit)
+
+			for _, game := range page.Games {
+				got = append(got, game.Title)
+			}
+
+			if page.Next == nil {
+				return got
+			}
+			params.After = page.Next
+		}
+	}
+
+	byTitle := collect(domain.ListGamesParams{Sort: domain.GameSortTitle, Genre: genre, Limit: 2})
+	require.Equal(t, []string{"alpha", "Bravo", "Charlie", "delta", "Echo", "Foxtrot"}, byTitle)
+
+	byTitleDesc := collect(domain.ListGamesParams{Sort: domain.GameSortTitle, Descending: true, Genre: genre, Limit: 4})
+	require.Equal(t, []string{"Foxtrot", "Echo", "delta", "Charlie", "Bravo", "alpha"}, byTitleDesc)
+
+	byReleaseDate := collect(domain.ListGamesParams{Sort: domain.GameSortReleaseDate, Genre: genre, Limit: 3})
+	require.Equal(t, []string{"Bravo", "alpha", "Charlie", "delta", "Echo", "Foxtrot"}, byReleaseDate)
+
+	// Missing release dates sort last in either direction.
+	byReleaseDateDesc := collect(domain.ListGamesParams{Sort: domain.GameSortReleaseDate, Descending: true, Genre: genre, Limit: 1})
+	require.Equal(t, []string{"Echo", "delta", "Charlie", "alpha", "Bravo", "Foxtrot"}, byReleaseDateDesc)
+
+	byInsertedAt := collect(domain.ListGamesParams{Sort: domain.GameSortInsertedAt, Descending: true, Genre: genre, Limit: 5})
+	require.Equal(t, []string{"Foxtrot", "Echo", "delta", "Charlie", "alpha", "Bravo"}, byInsertedAt)
+
+	prefixed := collect(domain.ListGamesParams{Sort: domain.GameSortTitle, Genre: genre, TitlePrefix: "CH", Limit: 10})
+	require.Equal(t, []string{"Charlie"}, prefixed)
+
+	none := collect(domain.ListGamesParams{Sort: domain.GameSortTitle, Genre: genre, Platform: "dreamcast", Limit: 10})
+	require.Empty(t, none)
+}
+
+func TestRepository_ListGames_PaginatesNonASCIITitles(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+
+	genre := "pagination-" + uuid.NewString()
+	for _, title := range []string{"Ökami", "Zelda", "Élan", "abzû", "ÉLITE"} {
+		_, err := repo.CreateGame(ctx, domain.Game{Title: title, Genres: []string{genre}})
+		require.NoError(t, err)
+	}
+
+	for _, descending := range []bool{false, true} {
+		params := domain.ListGamesParams{Sort: domain.GameSortTitle, Descending: descending, Genre: genre, Limit: 10}
+		whole, err := repo.ListGames(ctx, params)
+		require.NoError(t, err)
+		require.Len(t, whole.Games, 5)
+
+		var paged []domain.Game
+		params.Limit = 1
+		for {
+			page, err := repo.ListGames(ctx, params)
+			require.NoError(t, err)
+			paged = append(paged, page.Games...)
+			if page.Next == nil {
+				break
+			}
+			params.After = page.Next
+		}
+		require.Equal(t, whole.Games, paged)
+	}
+}
+
+func TestRepository_SearchGames(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+
+	witcher, err := repo.CreateGame(ctx, domain.Game{
+		Title:   "The Witcher 3: Wild Hunt",
+		Summary: "Geralt of Rivia hunts monsters for coin across the Northern Kingdoms.",
+	})
+	require.NoError(t, err)
+
+	_, err = repo.CreateGame(ctx, domain.Game{Title: "Stardew Valley", Summary: "Farming and friendship."})
+	require.NoError(t, err)
+
+	results, err := repo.SearchGames(ctx, "witcher 3", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, witcher.ID, results[0].Game.ID)
+	require.Positive(t, results[0].Score)
+	require.Contains(t, results[0].TitleSnippet, "<mark>Witcher</mark>")
+
+	results, err = repo.SearchGames(ctx, "witchr", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, witcher.ID, results[0].Game.ID)
+
+	results, err = repo.SearchGames(ctx, "monsters", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Contains(t, results[0].SummarySnippet, "<mark>monsters</mark>")
+}
+
+func TestRepository_UpdateGame(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+
+	game, err := repo.CreateGame(ctx, domain.Game{
+		Title:     "Celest",
+		Platforms: []string{"pc"},
+		Genres:    []string{"platformer"},
+	})
+	require.NoError(t, err)
+
+	game.Title = "Celeste"
+	game.Platforms = []string{"switch"}
+	game.Genres = nil
+
+	updated, err := repo.UpdateGame(ctx, game)
+	require.NoError(t, err)
+	require.Equal(t, game.ID, updated.ID)
+	require.False(t, updated.UpdatedAt.Before(game.UpdatedAt))
+
+	got, err := repo.GetGameByID(ctx, game.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Celeste", got.Title)
+	require.Equal(t, []string{"switch"}, got.Platforms)
+	require.Empty(t, got.Genres)
+}
+
+func TestRepository_UpdateGame_NotFound(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+
+	_, err := repo.UpdateGame(context.Background(), domain.Game{ID: uuid.New(), Title: "Missing"})
+	require.ErrorIs(t, err, domain.ErrGameNotFound)
+}
+
 func TestRepository_DeleteGame(t *testing.T) {
-	repo := NewRepository(testQueries)
+	repo := NewRepository(testQueries, testDB)
 	ctx := context.Background()
 
 	game, err := repo.CreateGame(ctx, domain.Game{Title: "Backlog, the game"})
@@ -69,3 +266,132 @@ func TestRepository_DeleteGame(t *testing.T) {
 	err = repo.DeleteGameByID(ctx, game.ID)
 	require.NoError(t, err)
 }
+
+func TestRepository_ExternalIDs(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+
+	game, err := repo.CreateGame(ctx, domain.Game{Title: "Disco Elysium: The Final Cut"})
+	require.NoError(t, err)
+
+	steamID := domain.ExternalID{Source: domain.ExternalSourceSteam, ID: "632470"}
+	require.NoError(t, repo.AddExternalIDs(ctx, game.ID, []domain.ExternalID{steamID}))
+	// Adding an ID that is already mapped is a no-op.
+	require.NoError(t, repo.AddExternalIDs(ctx, game.ID, []domain.ExternalID{steamID}))
+
+	got, err := repo.GetGameByExternalID(ctx, steamID)
+	require.NoError(t, err)
+	require.Equal(t, game.ID, got.ID)
+
+	ids, err := repo.ListExternalIDs(ctx, game.ID)
+	require.NoError(t, err)
+	require.Equal(t, []domain.ExternalID{steamID}, ids)
+
+	got, err = repo.GetGameByNormalizedTitle(ctx, "disco elysium the final cut")
+	require.NoError(t, err)
+	require.Equal(t, game.ID, got.ID)
+
+	_, err = repo.GetGameByExternalID(ctx, domain.ExternalID{Source: domain.ExternalSourceGOG, ID: "632470"})
+	require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+	err = repo.AddExternalIDs(ctx, uuid.New(), []domain.ExternalID{{Source: domain.ExternalSourceGOG, ID: "1"}})
+	require.ErrorIs(t, err, domain.ErrReferenceNotFound)
+	require.NotContains(t, err.Error(), "SQLSTATE")
+}
+
+func TestRepository_MergeGames(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+
+	source, err := repo.CreateGame(ctx, domain.Game{Title: "Outer Wilds", Platforms: []string{"pc"}})
+	require.NoError(t, err)
+	target, err := repo.CreateGame(ctx, domain.Game{Title: "Outer Wilds (2019)", Platforms: []string{"ps4"}})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AddExternalIDs(ctx, source.ID, []domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: "753640"}}))
+	require.NoError(t, repo.AddExternalIDs(ctx, target.ID, []domain.ExternalID{{Source: domain.ExternalSourceIGDB, ID: "11737"}}))
+
+	// One account only has the duplicate, the other has both and keeps the
+	// entry it already had for the target.
+	onlySource, err := testQueries.CreateAccount(ctx, sqlc.CreateAccountParams{
+		Nickname: "hearthian", Email: "hearthian@example.com", HashedPassword: "x",
+	})
+	require.NoError(t, err)
+	both, err := testQueries.CreateAccount(ctx, sqlc.CreateAccountParams{
+		Nickname: "nomai", Email: "nomai@example.com", HashedPassword: "x",
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.CreateLibraryEntry(ctx, sqlc.CreateLibraryEntryParams{
+		AccountID: onlySource.ID, GameID: source.ID, Status: string(domain.LibraryStatusPlaying),
+	})
+	require.NoError(t, err)
+
+	duplicate, err := testQueries.CreateLibraryEntry(ctx, sqlc.CreateLibraryEntryParams{
+		AccountID: both.ID, GameID: source.ID, Status: string(domain.LibraryStatusCompleted),
+		FinishedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Rating:     pgtype.Int2{Int16: 9, Valid: true},
+		Notes:      "Finished the Eye ending.",
+	})
+	require.NoError(t, err)
+	require.NoError(t, testQueries.CreateLibraryEntryEvent(ctx, sqlc.CreateLibraryEntryEventParams{
+		EntryID:   duplicate.ID,
+		AccountID: both.ID,
+		Kind:      string(domain.LibraryEntryEventStatusChanged),
+		ToValue:   pgtype.Text{String: string(domain.LibraryStatusCompleted), Valid: true},
+	}))
+
+	kept, err := testQueries.CreateLibraryEntry(ctx, sqlc.CreateLibraryEntryParams{
+		AccountID: both.ID, GameID: target.ID, Status: string(domain.LibraryStatusBacklog),
+		Notes: "Bought on sale.",
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.ImportLibraryEntry(ctx, sqlc.ImportLibraryEntryParams{
+		AccountID: both.ID, GameID: source.ID, Status: string(domain.LibraryStatusBacklog), PlaytimeMinutes: 300,
+	})
+	require.NoError(t, err)
+	_, err = testQueries.ImportLibraryEntry(ctx, sqlc.ImportLibraryEntryParams{
+		AccountID: both.ID, GameID: target.ID, Status: string(domain.LibraryStatusBacklog), PlaytimeMinutes: 120,
+	})
+	require.NoError(t, err)
+
+	target.Platforms = []string{"ps4", "pc"}
+	merged, err := repo.MergeGames(ctx, source.ID, target)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, merged.ID)
+	require.ElementsMatch(t, []string{"pc", "ps4"}, merged.Platforms)
+
+	_, err = repo.GetGameByID(ctx, source.ID)
+	require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+	ids, err := repo.ListExternalIDs(ctx, target.ID)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	entries, err := testQueries.ListLibraryEntries(ctx, onlySource.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, target.ID, entries[0].GameID)
+
+	// The account with both keeps its target entry, taking the more advanced
+	// status, the rating, both notes, the summed playtime and the history of
+	// the duplicate.
+	entries, err = testQueries.ListLibraryEntries(ctx, both.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, kept.ID, entries[0].ID)
+	require.Equal(t, string(domain.LibraryStatusCompleted), entries[0].Status)
+	require.True(t, entries[0].FinishedAt.Valid)
+	require.Equal(t, pgtype.Int2{Int16: 9, Valid: true}, entries[0].Rating)
+	require.Equal(t, "Bought on sale.\n\nFinished the Eye ending.", entries[0].Notes)
+	require.EqualValues(t, 420, entries[0].PlaytimeMinutes)
+
+	events, err := testQueries.ListLibraryEntryEvents(ctx, sqlc.ListLibraryEntryEventsParams{
+		EntryID: kept.ID, AccountID: both.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, string(domain.LibraryStatusCompleted), events[0].ToValue.String)
+	require.Equal(t, pgtype.UUID{Bytes: target.ID, Valid: true}, events[0].GameID)
+}
@@ -2,21 +2,33 @@ package games
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 )
 
 type service struct {
 	repository domain.GameRepository
+	metadata   domain.MetadataProvider
 }
 
-func NewService(gameRepository domain.GameRepository) domain.GameService {
-	return &service{gameRepository}
+// NewService builds the games service. metadata may be nil when no provider
+// is configured, in which case enrichment reports ErrMetadataUnavailable.
+func NewService(gameRepository domain.GameRepository, metadata domain.MetadataProvider) domain.GameService {
+	return &service{gameRepository, metadata}
 }
 
-func (s *service) CreateGame(ctx context.Context, title string) (domain.Game, error) {
-	game := domain.Game{Title: title}
+func (s *service) CreateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return domain.Game{}, auth.ErrInvalidToken
+	}
+
+	game.CreatedBy = accountID
 	return s.repository.CreateGame(ctx, game)
 }
 
@@ -24,15 +36,260 @@ func (s *service) GetGameByID(ctx context.Context, id uuid.UUID) (domain.Game, e
 	return s.repository.GetGameByID(ctx, id)
 }
 
-func (s *service) ListGames(ctx context.Context) ([]domain.Game, error) {
-	return s.repository.ListGames(ctx)
+func (s *service) ListGames(ctx context.Context, params domain.ListGamesParams) (domain.GamePage, error) {
+	return s.repository.ListGames(ctx, params)
 }
 
-func (s *service) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
-	_, err := s.repository.GetGameByID(ctx, id)
+func (s *service) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameSearchResult, error) {
+	return s.repository.SearchGames(ctx, query, limit)
+}
+
+func (s *service) UpdateGame(ctx context.Context, id uuid.UUID, patch domain.GamePatch) (domain.Game, error) {
+	game, err := s.getOwnGame(ctx, id)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	return s.repository.UpdateGame(ctx, applyPatch(game, patch))
+}
+
+func applyPatch(game domain.Game, patch domain.GamePatch) domain.Game {
+	if patch.Title != nil {
+		game.Title = *patch.Title
+	}
+	if patch.ReleaseDate != nil {
+		game.ReleaseDate = *patch.ReleaseDate
+	}
+	if patch.Summary != nil {
+		game.Summary = *patch.Summary
+	}
+	if patch.Developer != nil {
+		game.Developer = *patch.Developer
+	}
+	if patch.Publisher != nil {
+		game.Publisher = *patch.Publisher
+	}
+	if patch.CoverURL != nil {
+		game.CoverURL = *patch.CoverURL
+	}
+	if patch.Platforms != nil {
+		game.Platforms = *patch.Platforms
+	}
+	if patch.Genres != nil {
+		game.Genres = *patch.Genres
+	}
+
+	return game
+}
+
+// EnrichGame fills in whatever metadata the catalog is missing. Games already
+// mapped to the provider are fetched by ID, others are looked up by title.
+// Fields already set are kept.
+func (s *service) EnrichGame(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	if s.metadata == nil {
+		return domain.Game{}, domain.ErrMetadataUnavailable
+	}
+
+	game, err := s.getOwnGame(ctx, id)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	metadata, err := s.findMetadata(ctx, game)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	if err := s.repository.AddExternalIDs(ctx, game.ID, metadata.ExternalIDs); err != nil {
+		return domain.Game{}, err
+	}
+
+	return s.repository.UpdateGame(ctx, enrich(game, metadata))
+}
+
+func (s *service) findMetadata(ctx context.Context, game domain.Game) (domain.GameMetadata, error) {
+	externalIDs, err := s.repository.ListExternalIDs(ctx, game.ID)
+	if err != nil {
+		return domain.GameMetadata{}, err
+	}
+
+	for _, externalID := range externalIDs {
+		if externalID.Source == s.metadata.Source() {
+			metadata, err := s.metadata.GetGame(ctx, externalID.ID)
+			return metadata, providerError(err)
+		}
+	}
+
+	matches, err := s.metadata.SearchGames(ctx, game.Title, 1)
+	if err != nil {
+		return domain.GameMetadata{}, providerError(err)
+	}
+	if len(matches) == 0 {
+		return domain.GameMetadata{}, domain.ErrMetadataNotFound
+	}
+
+	return matches[0], nil
+}
+
+// providerError marks failures of the provider itself, as opposed to it not
+// knowing the game.
+func providerError(err error) error {
+	if err == nil || errors.Is(err, domain.ErrMetadataNotFound) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", domain.ErrMetadataFetch, err)
+}
+
+func enrich(game domain.Game, metadata domain.GameMetadata) domain.Game {
+	if game.ReleaseDate.IsZero() {
+		game.ReleaseDate = metadata.ReleaseDate
+	}
+	if game.Summary == "" {
+		game.Summary = metadata.Summary
+	}
+	if game.Developer == "" {
+		game.Developer = metadata.Developer
+	}
+	if game.Publisher == "" {
+		game.Publisher = metadata.Publisher
+	}
+	if game.CoverURL == "" {
+		game.CoverURL = metadata.CoverURL
+	}
+	if len(game.Platforms) == 0 {
+		game.Platforms = metadata.Platforms
+	}
+	if len(game.Genres) == 0 {
+		game.Genres = metadata.Genres
+	}
+
+	return game
+}
+
+func (s *service) ResolveOrCreateGame(
+	ctx context.Context,
+	game domain.Game,
+	externalIDs []domain.ExternalID,
+) (domain.Game, error) {
+	resolved, err := s.resolve(ctx, game, externalIDs)
+	if errors.Is(err, domain.ErrGameNotFound) {
+		// Another import may create the same game between the lookup and
+		// here; the repository then hands back that game instead.
+		resolved, err = s.repository.CreateGameWithExternalIDs(ctx, game, externalIDs)
+	}
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	if err := s.repository.AddExternalIDs(ctx, resolved.ID, externalIDs); err != nil {
+		return domain.Game{}, err
+	}
+
+	return resolved, nil
+}
+
+// resolve prefers external IDs, which identify a game exactly, over the
+// normalized title.
+func (s *service) resolve(ctx context.Context, game domain.Game, externalIDs []domain.ExternalID) (domain.Game, error) {
+	for _, externalID := range externalIDs {
+		found, err := s.repository.GetGameByExternalID(ctx, externalID)
+		if !errors.Is(err, domain.ErrGameNotFound) {
+			return found, err
+		}
+	}
+
+	normalizedTitle := domain.NormalizeTitle(game.Title)
+	if normalizedTitle == "" {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	return s.repository.GetGameByNormalizedTitle(ctx, normalizedTitle)
+}
+
+func (s *service) MergeGames(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) (domain.Game, error) {
+	if sourceID == targetID {
+		return domain.Game{}, domain.ErrMergeSameGame
+	}
+
+	// Merging rewrites every account's library, so owning both games is not
+	// enough.
+	if auth.RoleFromContext(ctx) != auth.RoleAdmin {
+		return domain.Game{}, domain.ErrForbidden
+	}
+
+	source, err := s.getOwnGame(ctx, sourceID)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	target, err := s.getOwnGame(ctx, targetID)
 	if err != nil {
+		return domain.Game{}, err
+	}
+
+	return s.repository.MergeGames(ctx, source.ID, absorb(target, source))
+}
+
+// absorb fills the target's empty fields from the source and unions their
+// platforms and genres.
+func absorb(target domain.Game, source domain.Game) domain.Game {
+	if target.ReleaseDate.IsZero() {
+		target.ReleaseDate = source.ReleaseDate
+	}
+	if target.Summary == "" {
+		target.Summary = source.Summary
+	}
+	if target.Developer == "" {
+		target.Developer = source.Developer
+	}
+	if target.Publisher == "" {
+		target.Publisher = source.Publisher
+	}
+	if target.CoverURL == "" {
+		target.CoverURL = source.CoverURL
+	}
+	target.Platforms = union(target.Platforms, source.Platforms)
+	target.Genres = union(target.Genres, source.Genres)
+
+	return target
+}
+
+func union(values []string, others []string) []string {
+	merged := slices.Clone(values)
+	for _, other := range others {
+		if !slices.Contains(merged, other) {
+			merged = append(merged, other)
+		}
+	}
+
+	return merged
+}
+
+func (s *service) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getOwnGame(ctx, id); err != nil {
 		return err
 	}
 
 	return s.repository.DeleteGameByID(ctx, id)
 }
+
+// getOwnGame loads a game the caller is about to change. The catalog is shared
+// by every backlog, so only the game's creator and admins may edit it.
+func (s *service) getOwnGame(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return domain.Game{}, auth.ErrInvalidToken
+	}
+
+	game, err := s.repository.GetGameByID(ctx, id)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	if auth.RoleFromContext(ctx) != auth.RoleAdmin && game.CreatedBy != accountID {
+		return domain.Game{}, domain.ErrForbidden
+	}
+
+	return game, nil
+}
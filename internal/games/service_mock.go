@@ -16,8 +16,8 @@ func NewMockGameService() domain.GameService {
 	return new(MockGameService)
 }
 
-func (m *MockGameService) CreateGame(ctx context.Context, title string) (domain.Game, error) {
-	args := m.Called(ctx, title)
+func (m *MockGameService) CreateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	args := m.Called(ctx, game)
 	return args.Get(0).(domain.Game), args.Error(1)
 }
 
@@ -26,9 +26,38 @@ func (m *MockGameService) GetGameByID(ctx context.Context, id uuid.UUID) (domain
 	return args.Get(0).(domain.Game), args.Error(1)
 }
 
-func (m *MockGameService) ListGames(ctx context.Context) ([]domain.Game, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]domain.Game), args.Error(1)
+func (m *MockGameService) ListGames(ctx context.Context, params domain.ListGamesParams) (domain.GamePage, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(domain.GamePage), args.Error(1)
+}
+
+func (m *MockGameService) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameSearchResult, error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).([]domain.GameSearchResult), args.Error(1)
+}
+
+func (m *MockGameService) UpdateGame(ctx context.Context, id uuid.UUID, patch domain.GamePatch) (domain.Game, error) {
+	args := m.Called(ctx, id, patch)
+	return args.Get(0).(domain.Game), args.Error(1)
+}
+
+func (m *MockGameService) EnrichGame(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Game), args.Error(1)
+}
+
+func (m *MockGameService) ResolveOrCreateGame(
+	ctx context.Context,
+	game domain.Game,
+	externalIDs []domain.ExternalID,
+) (domain.Game, error) {
+	args := m.Called(ctx, game, externalIDs)
+	return args.Get(0).(domain.Game), args.Error(1)
+}
+
+func (m *MockGameService) MergeGames(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) (domain.Game, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Get(0).(domain.Game), args.Error(1)
 }
 
 func (m *MockGameService) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
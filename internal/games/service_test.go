@@ -4,47 +4,72 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/metadata"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+// creatorID owns the games the tests change.
+var creatorID = uuid.New()
+
+func creatorContext() context.Context {
+	return auth.WithAccountID(context.Background(), creatorID)
+}
+
+func adminContext() context.Context {
+	return auth.WithRole(auth.WithAccountID(context.Background(), uuid.New()), auth.RoleAdmin)
+}
+
 func TestService_CreateGame(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
-	ctx := context.Background()
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
 
 	gameIn := domain.Game{Title: "Hollow Knight"}
-	gameOut := domain.Game{ID: uuid.New(), Title: "Hollow Knight"}
+	gameOut := domain.Game{ID: uuid.New(), Title: "Hollow Knight", CreatedBy: creatorID}
 
-	mockRepo.On("CreateGame", ctx, gameIn).Return(gameOut, nil)
+	mockRepo.On("CreateGame", ctx, domain.Game{Title: "Hollow Knight", CreatedBy: creatorID}).Return(gameOut, nil)
 
-	got, err := svc.CreateGame(ctx, "Hollow Knight")
+	got, err := svc.CreateGame(ctx, gameIn)
 	require.NoError(t, err)
 	require.Equal(t, gameOut.ID, got.ID)
 	require.Equal(t, "Hollow Knight", got.Title)
+	require.Equal(t, creatorID, got.CreatedBy)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestService_CreateGame_Error(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
-	ctx := context.Background()
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
 
 	gameIn := domain.Game{Title: "Error Game"}
-	mockRepo.On("CreateGame", ctx, gameIn).Return(domain.Game{}, errors.New("db error"))
+	mockRepo.On("CreateGame", ctx, domain.Game{Title: "Error Game", CreatedBy: creatorID}).Return(domain.Game{}, errors.New("db error"))
 
-	_, err := svc.CreateGame(ctx, "Error Game")
+	_, err := svc.CreateGame(ctx, gameIn)
 	require.Error(t, err)
 	require.EqualError(t, err, "db error")
 	mockRepo.AssertExpectations(t)
 }
 
+func TestService_CreateGame_Anonymous(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+
+	_, err := svc.CreateGame(context.Background(), domain.Game{Title: "Orphan"})
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+	mockRepo.AssertNotCalled(t, "CreateGame", mock.Anything, mock.Anything)
+}
+
 func TestService_GetGameByID(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
+	svc := NewService(mockRepo, nil)
 	ctx := context.Background()
 	id := uuid.New()
 
@@ -59,7 +84,7 @@ func TestService_GetGameByID(t *testing.T) {
 
 func TestService_GetGameByID_Error(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
+	svc := NewService(mockRepo, nil)
 	ctx := context.Background()
 	id := uuid.New()
 
@@ -73,31 +98,33 @@ func TestService_GetGameByID_Error(t *testing.T) {
 
 func TestService_ListGames(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
+	svc := NewService(mockRepo, nil)
 	ctx := context.Background()
 
-	want := []domain.Game{
+	params := domain.ListGamesParams{Sort: domain.GameSortTitle, Limit: 2}
+	want := domain.GamePage{Games: []domain.Game{
 		{ID: uuid.New(), Title: "Hades"},
 		{ID: uuid.New(), Title: "Celeste"},
-	}
+	}}
 
-	mockRepo.On("ListGames", ctx).Return(want, nil)
+	mockRepo.On("ListGames", ctx, params).Return(want, nil)
 
-	got, err := svc.ListGames(ctx)
+	got, err := svc.ListGames(ctx, params)
 	require.NoError(t, err)
-	require.Len(t, got, 2)
-	require.Equal(t, "Hades", got[0].Title)
+	require.Len(t, got.Games, 2)
+	require.Equal(t, "Hades", got.Games[0].Title)
 	mockRepo.AssertExpectations(t)
 }
 
 func TestService_ListGames_Error(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
+	svc := NewService(mockRepo, nil)
 	ctx := context.Background()
 
-	mockRepo.On("ListGames", ctx).Return([]domain.Game{}, errors.New("db error"))
+	params := domain.ListGamesParams{Sort: domain.GameSortTitle, Limit: 50}
+	mockRepo.On("ListGames", ctx, params).Return(domain.GamePage{}, errors.New("db error"))
 
-	_, err := svc.ListGames(ctx)
+	_, err := svc.ListGames(ctx, params)
 	require.Error(t, err)
 	require.EqualError(t, err, "db error")
 	mockRepo.AssertExpectations(t)
@@ -105,11 +132,11 @@ func TestService_ListGames_Error(t *testing.T) {
 
 func TestService_DeleteGameByID_Success(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
-	ctx := context.Background()
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
 	id := uuid.New()
 
-	game := domain.Game{ID: id, Title: "To Delete"}
+	game := domain.Game{ID: id, Title: "To Delete", CreatedBy: creatorID}
 	mockRepo.On("GetGameByID", ctx, id).Return(game, nil)
 	mockRepo.On("DeleteGameByID", ctx, id).Return(nil)
 
@@ -123,8 +150,8 @@ func TestService_DeleteGameByID_Success(t *testing.T) {
 
 func TestService_DeleteGameByID_NotFound(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
-	ctx := context.Background()
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
 	id := uuid.New()
 
 	mockRepo.On("GetGameByID", ctx, id).Return(domain.Game{}, errors.New("not found"))
@@ -140,11 +167,11 @@ func TestService_DeleteGameByID_NotFound(t *testing.T) {
 
 func TestService_DeleteGameByID_DeleteFails(t *testing.T) {
 	mockRepo := new(MockGameRepository)
-	svc := NewService(mockRepo)
-	ctx := context.Background()
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
 	id := uuid.New()
 
-	game := domain.Game{ID: id, Title: "Fail Delete"}
+	game := domain.Game{ID: id, Title: "Fail Delete", CreatedBy: creatorID}
 	mockRepo.On("GetGameByID", ctx, id).Return(game, nil)
 	mockRepo.On("DeleteGameByID", ctx, id).Return(errors.New("delete error"))
 
@@ -153,3 +180,378 @@ func TestService_DeleteGameByID_DeleteFails(t *testing.T) {
 	require.EqualError(t, err, "delete error")
 	mockRepo.AssertExpectations(t)
 }
+
+func TestService_DeleteGameByID_NotCreator(t *testing.T) {
+	for name, createdBy := range map[string]uuid.UUID{
+		"someone else's": uuid.New(),
+		"unowned":        uuid.Nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockRepo := new(MockGameRepository)
+			svc := NewService(mockRepo, nil)
+			ctx := creatorContext()
+			id := uuid.New()
+
+			mockRepo.On("GetGameByID", ctx, id).Return(domain.Game{ID: id, Title: "Shared", CreatedBy: createdBy}, nil)
+
+			err := svc.DeleteGameByID(ctx, id)
+			require.ErrorIs(t, err, domain.ErrForbidden)
+			mockRepo.AssertNotCalled(t, "DeleteGameByID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_DeleteGameByID_Admin(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := adminContext()
+	id := uuid.New()
+
+	mockRepo.On("GetGameByID", ctx, id).Return(domain.Game{ID: id, Title: "Shared", CreatedBy: uuid.New()}, nil)
+	mockRepo.On("DeleteGameByID", ctx, id).Return(nil)
+
+	require.NoError(t, svc.DeleteGameByID(ctx, id))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_DeleteGameByID_Anonymous(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+
+	err := svc.DeleteGameByID(context.Background(), uuid.New())
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+	mockRepo.AssertNotCalled(t, "GetGameByID", mock.Anything, mock.Anything)
+}
+
+func TestService_UpdateGame(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
+	id := uuid.New()
+
+	current := domain.Game{
+		ID:        id,
+		Title:     "Hollow Knigth",
+		Developer: "Team Cherry",
+		Platforms: []string{"pc"},
+		CreatedBy: creatorID,
+	}
+	title := "Hollow Knight"
+	platforms := []string{"pc", "switch"}
+	developer := ""
+
+	want := domain.Game{ID: id, Title: title, Platforms: platforms, CreatedBy: creatorID}
+	mockRepo.On("GetGameByID", ctx, id).Return(current, nil)
+	mockRepo.On("UpdateGame", ctx, want).Return(want, nil)
+
+	got, err := svc.UpdateGame(ctx, id, domain.GamePatch{
+		Title:     &title,
+		Developer: &developer,
+		Platforms: &platforms,
+	})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_UpdateGame_NotFound(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
+	id := uuid.New()
+
+	mockRepo.On("GetGameByID", ctx, id).Return(domain.Game{}, domain.ErrGameNotFound)
+
+	_, err := svc.UpdateGame(ctx, id, domain.GamePatch{})
+	require.ErrorIs(t, err, domain.ErrGameNotFound)
+	mockRepo.AssertNotCalled(t, "UpdateGame", ctx, mock.Anything)
+}
+
+func TestService_UpdateGame_NotCreator(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
+	id := uuid.New()
+	title := "Vandalized"
+
+	mockRepo.On("GetGameByID", ctx, id).Return(domain.Game{ID: id, Title: "Hades", CreatedBy: uuid.New()}, nil)
+
+	_, err := svc.UpdateGame(ctx, id, domain.GamePatch{Title: &title})
+	require.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "UpdateGame", mock.Anything, mock.Anything)
+}
+
+func TestService_SearchGames(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := context.Background()
+
+	want := []domain.GameSearchResult{{
+		Game:         domain.Game{ID: uuid.New(), Title: "The Witcher 3: Wild Hunt"},
+		Score:        0.9,
+		TitleSnippet: "The <mark>Witcher</mark> <mark>3</mark>: Wild Hunt",
+	}}
+	mockRepo.On("SearchGames", ctx, "witcher 3", 10).Return(want, nil)
+
+	got, err := svc.SearchGames(ctx, "witcher 3", 10)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_EnrichGame(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	mockProvider := new(metadata.MockMetadataProvider)
+	svc := NewService(mockRepo, mockProvider)
+	ctx := creatorContext()
+	id := uuid.New()
+
+	game := domain.Game{ID: id, Title: "Hades", Developer: "Supergiant", Genres: []string{"roguelike"}, CreatedBy: creatorID}
+	found := domain.GameMetadata{
+		Title:       "Hades",
+		ReleaseDate: time.Date(2020, time.September, 17, 0, 0, 0, 0, time.UTC),
+		Summary:     "Defy the god of the dead.",
+		Developer:   "Supergiant Games",
+		CoverURL:    "https://images.igdb.com/igdb/image/upload/t_cover_big/co39vc.jpg",
+		Platforms:   []string{"pc", "switch"},
+		Genres:      []string{"role-playing-rpg"},
+		ExternalIDs: []domain.ExternalID{{Source: domain.ExternalSourceIGDB, ID: "113112"}},
+	}
+	enriched := game
+	enriched.ReleaseDate = found.ReleaseDate
+	enriched.Summary = found.Summary
+	enriched.CoverURL = found.CoverURL
+	enriched.Platforms = found.Platforms
+
+	mockRepo.On("GetGameByID", ctx, id).Return(game, nil)
+	mockRepo.On("ListExternalIDs", ctx, id).Return([]domain.ExternalID{}, nil)
+	mockProvider.On("SearchGames", ctx, "Hades", 1).Return([]domain.GameMetadata{found}, nil)
+	mockRepo.On("AddExternalIDs", ctx, id, found.ExternalIDs).Return(nil)
+	mockRepo.On("UpdateGame", ctx, enriched).Return(enriched, nil)
+
+	got, err := svc.EnrichGame(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "Supergiant", got.Developer)
+	require.Equal(t, []string{"roguelike"}, got.Genres)
+	require.Equal(t, []string{"pc", "switch"}, got.Platforms)
+	mockRepo.AssertExpectations(t)
+	mockProvider.AssertExpectations(t)
+}
+
+func TestService_EnrichGame_KnownExternalID(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	mockProvider := new(metadata.MockMetadataProvider)
+	svc := NewService(mockRepo, mockProvider)
+	ctx := creatorContext()
+	id := uuid.New()
+
+	game := domain.Game{ID: id, Title: "Hades II", CreatedBy: creatorID}
+	externalIDs := []domain.ExternalID{
+		{Source: domain.ExternalSourceSteam, ID: "1145350"},
+		{Source: domain.ExternalSourceIGDB, ID: "217590"},
+	}
+	found := domain.GameMetadata{Title: "Hades II", Developer: "Supergiant Games"}
+	enriched := game
+	enriched.Developer = found.Developer
+
+	mockRepo.On("GetGameByID", ctx, id).Return(game, nil)
+	mockRepo.On("ListExternalIDs", ctx, id).Return(externalIDs, nil)
+	mockProvider.On("Source").Return(domain.ExternalSourceIGDB)
+	mockProvider.On("GetGame", ctx, "217590").Return(found, nil)
+	mockRepo.On("AddExternalIDs", ctx, id, []domain.ExternalID(nil)).Return(nil)
+	mockRepo.On("UpdateGame", ctx, enriched).Return(enriched, nil)
+
+	got, err := svc.EnrichGame(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, "Supergiant Games", got.Developer)
+	mockProvider.AssertNotCalled(t, "SearchGames", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_EnrichGame_NoMatch(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	mockProvider := new(metadata.MockMetadataProvider)
+	svc := NewService(mockRepo, mockProvider)
+	ctx := creatorContext()
+	id := uuid.New()
+
+	mockRepo.On("GetGameByID", ctx, id).Return(domain.Game{ID: id, Title: "Homebrew", CreatedBy: creatorID}, nil)
+	mockRepo.On("ListExternalIDs", ctx, id).Return([]domain.ExternalID{}, nil)
+	mockProvider.On("SearchGames", ctx, "Homebrew", 1).Return([]domain.GameMetadata{}, nil)
+
+	_, err := svc.EnrichGame(ctx, id)
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+	mockRepo.AssertNotCalled(t, "UpdateGame", mock.Anything, mock.Anything)
+}
+
+func TestService_EnrichGame_ProviderFailure(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	mockProvider := new(metadata.MockMetadataProvider)
+	svc := NewService(mockRepo, mockProvider)
+	ctx := creatorContext()
+	id := uuid.New()
+
+	providerErr := errors.New("call igdb /games: unexpected status 500")
+	mockRepo.On("GetGameByID", ctx, id).Return(domain.Game{ID: id, Title: "Hades", CreatedBy: creatorID}, nil)
+	mockRepo.On("ListExternalIDs", ctx, id).Return([]domain.ExternalID{}, nil)
+	mockProvider.On("SearchGames", ctx, "Hades", 1).Return([]domain.GameMetadata(nil), providerErr)
+
+	_, err := svc.EnrichGame(ctx, id)
+	require.ErrorIs(t, err, domain.ErrMetadataFetch)
+	require.ErrorIs(t, err, providerErr)
+	mockRepo.AssertNotCalled(t, "UpdateGame", mock.Anything, mock.Anything)
+}
+
+func TestService_EnrichGame_Unavailable(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+
+	_, err := svc.EnrichGame(context.Background(), uuid.New())
+	require.ErrorIs(t, err, domain.ErrMetadataUnavailable)
+	mockRepo.AssertNotCalled(t, "GetGameByID", mock.Anything, mock.Anything)
+}
+
+func TestService_ResolveOrCreateGame_ByExternalID(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := context.Background()
+
+	existing := domain.Game{ID: uuid.New(), Title: "Hades"}
+	externalIDs := []domain.ExternalID{
+		{Source: domain.ExternalSourceGOG, ID: "1207665853"},
+		{Source: domain.ExternalSourceSteam, ID: "1145360"},
+	}
+
+	mockRepo.On("GetGameByExternalID", ctx, externalIDs[0]).Return(domain.Game{}, domain.ErrGameNotFound)
+	mockRepo.On("GetGameByExternalID", ctx, externalIDs[1]).Return(existing, nil)
+	mockRepo.On("AddExternalIDs", ctx, existing.ID, externalIDs).Return(nil)
+
+	got, err := svc.ResolveOrCreateGame(ctx, domain.Game{Title: "HADES"}, externalIDs)
+	require.NoError(t, err)
+	require.Equal(t, existing.ID, got.ID)
+	mockRepo.AssertNotCalled(t, "GetGameByNormalizedTitle", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateGameWithExternalIDs", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ResolveOrCreateGame_ByTitle(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := context.Background()
+
+	existing := domain.Game{ID: uuid.New(), Title: "The Witcher 3: Wild Hunt"}
+	externalIDs := []domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: "292030"}}
+
+	mockRepo.On("GetGameByExternalID", ctx, externalIDs[0]).Return(domain.Game{}, domain.ErrGameNotFound)
+	mockRepo.On("GetGameByNormalizedTitle", ctx, "the witcher 3 wild hunt").Return(existing, nil)
+	mockRepo.On("AddExternalIDs", ctx, existing.ID, externalIDs).Return(nil)
+
+	got, err := svc.ResolveOrCreateGame(ctx, domain.Game{Title: "The Witcher® 3 - Wild Hunt"}, externalIDs)
+	require.NoError(t, err)
+	require.Equal(t, existing.ID, got.ID)
+	mockRepo.AssertNotCalled(t, "CreateGameWithExternalIDs", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ResolveOrCreateGame_Creates(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := context.Background()
+
+	game := domain.Game{Title: "Celeste", Platforms: []string{"pc"}}
+	created := domain.Game{ID: uuid.New(), Title: "Celeste", Platforms: []string{"pc"}}
+	externalIDs := []domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: "504230"}}
+
+	mockRepo.On("GetGameByExternalID", ctx, externalIDs[0]).Return(domain.Game{}, domain.ErrGameNotFound)
+	mockRepo.On("GetGameByNormalizedTitle", ctx, "celeste").Return(domain.Game{}, domain.ErrGameNotFound)
+	mockRepo.On("CreateGameWithExternalIDs", ctx, game, externalIDs).Return(created, nil)
+	mockRepo.On("AddExternalIDs", ctx, created.ID, externalIDs).Return(nil)
+
+	got, err := svc.ResolveOrCreateGame(ctx, game, externalIDs)
+	require.NoError(t, err)
+	require.Equal(t, created.ID, got.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ResolveOrCreateGame_Error(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := context.Background()
+
+	externalIDs := []domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: "504230"}}
+	mockRepo.On("GetGameByExternalID", ctx, externalIDs[0]).Return(domain.Game{}, errors.New("db down"))
+
+	_, err := svc.ResolveOrCreateGame(ctx, domain.Game{Title: "Celeste"}, externalIDs)
+	require.EqualError(t, err, "db down")
+	mockRepo.AssertNotCalled(t, "CreateGameWithExternalIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_MergeGames(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := adminContext()
+
+	source := domain.Game{
+		ID:        uuid.New(),
+		Title:     "Hades",
+		Summary:   "Defy the god of the dead.",
+		Developer: "Someone Else",
+		Platforms: []string{"pc", "switch"},
+		Genres:    []string{"roguelike"},
+	}
+	target := domain.Game{
+		ID:        uuid.New(),
+		Title:     "Hades",
+		Developer: "Supergiant Games",
+		Platforms: []string{"pc"},
+	}
+	merged := target
+	merged.Summary = source.Summary
+	merged.Platforms = []string{"pc", "switch"}
+	merged.Genres = []string{"roguelike"}
+
+	mockRepo.On("GetGameByID", ctx, source.ID).Return(source, nil)
+	mockRepo.On("GetGameByID", ctx, target.ID).Return(target, nil)
+	mockRepo.On("MergeGames", ctx, source.ID, merged).Return(merged, nil)
+
+	got, err := svc.MergeGames(ctx, source.ID, target.ID)
+	require.NoError(t, err)
+	require.Equal(t, target.ID, got.ID)
+	require.Equal(t, "Supergiant Games", got.Developer)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_MergeGames_SameGame(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	id := uuid.New()
+
+	_, err := svc.MergeGames(context.Background(), id, id)
+	require.ErrorIs(t, err, domain.ErrMergeSameGame)
+	mockRepo.AssertNotCalled(t, "GetGameByID", mock.Anything, mock.Anything)
+}
+
+func TestService_MergeGames_RequiresAdmin(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := creatorContext()
+
+	_, err := svc.MergeGames(ctx, uuid.New(), uuid.New())
+	require.ErrorIs(t, err, domain.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "GetGameByID", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "MergeGames", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_MergeGames_NotFound(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+	svc := NewService(mockRepo, nil)
+	ctx := adminContext()
+	sourceID, targetID := uuid.New(), uuid.New()
+
+	mockRepo.On("GetGameByID", ctx, sourceID).Return(domain.Game{ID: sourceID}, nil)
+	mockRepo.On("GetGameByID", ctx, targetID).Return(domain.Game{}, domain.ErrGameNotFound)
+
+	_, err := svc.MergeGames(ctx, sourceID, targetID)
+	require.ErrorIs(t, err, domain.ErrGameNotFound)
+	mockRepo.AssertNotCalled(t, "MergeGames", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,103 @@
+// Package health serves the liveness and readiness probes.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Check is one dependency a probe reports on. Details, when set, adds what it
+// returns to the check's result whether Run failed or not.
+type Check struct {
+	Name    string
+	Run     func(ctx context.Context) error
+	Details func() any
+}
+
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+	Details   any     `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Run runs every check concurrently, each bounded by timeout. The report
+// fails if any check does.
+func Run(ctx context.Context, checks []Check, timeout time.Duration) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range checks {
+		wg.Go(func() {
+			result := run(ctx, check, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		})
+	}
+	wg.Wait()
+
+	return report
+}
+
+func run(ctx context.Context, check Check, timeout time.Duration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := CheckResult{
+		Status:    StatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	if check.Details != nil {
+		result.Details = check.Details()
+	}
+
+	return result
+}
+
+// Handler answers 200 with the report when every check passes and 503
+// otherwise.
+func Handler(checks []Check, timeout time.Duration, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := Run(r.Context(), checks, timeout)
+
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+			logger.Warn("health check failed", "path", r.URL.Path, "checks", report.Checks)
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		if err := httpjson.Encode(w, r, status, report); err != nil {
+			logger.Error("failed to encode health report", "err", err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		{Name: "fine", Run: func(context.Context) error { return nil }},
+		{Name: "broken", Run: func(context.Context) error { return errors.New("connection refused") }},
+		{Name: "hung", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		{
+			Name:    "detailed",
+			Run:     func(context.Context) error { return nil },
+			Details: func() any { return []int{1, 2} },
+		},
+	}
+
+	report := Run(context.Background(), checks, 20*time.Millisecond)
+
+	require.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 4)
+	require.Equal(t, StatusOK, report.Checks["fine"].Status)
+	require.Equal(t, StatusFail, report.Checks["broken"].Status)
+	require.Equal(t, "connection refused", report.Checks["broken"].Error)
+	require.Equal(t, StatusFail, report.Checks["hung"].Status)
+	require.GreaterOrEqual(t, report.Checks["hung"].LatencyMS, float64(20))
+	require.Equal(t, []int{1, 2}, report.Checks["detailed"].Details)
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		want   string
+	}{
+		{"passing", nil, http.StatusOK, StatusOK},
+		{"failing", errors.New("down"), http.StatusServiceUnavailable, StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := []Check{{Name: "database", Run: func(context.Context) error { return tt.err }}}
+			handler := Handler(checks, time.Second, slog.New(slog.DiscardHandler))
+
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			require.Equal(t, tt.status, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			var report Report
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+			require.Equal(t, tt.want, report.Status)
+			require.Equal(t, tt.want, report.Checks["database"].Status)
+		})
+	}
+}
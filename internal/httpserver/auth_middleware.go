@@ -5,14 +5,13 @@ import (
 	"log/slog"
 	"net/http"
 
-	"github.com/google/uuid"
-
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
 	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
 )
 
 type SessionStore interface {
-	GetSessionAccountID(ctx context.Context, token string) (uuid.UUID, error)
+	GetSessionAccount(ctx context.Context, token string) (domain.Account, error)
 }
 
 func WithAuth(sessionStore SessionStore, logger *slog.Logger) Middleware {
@@ -23,25 +22,50 @@ func WithAuth(sessionStore SessionStore, logger *slog.Logger) Middleware {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if sessionStore == nil {
-				httpjson.NotifyHTTPError(w, r, logger, http.StatusUnauthorized, "missing session", auth.ErrInvalidToken)
+				httpjson.NotifyProblem(w, r, logger, "missing session", auth.ErrInvalidToken)
 				return
 			}
 
 			cookie, err := r.Cookie(auth.SessionCookieName)
 			if err != nil || cookie.Value == "" {
-				httpjson.NotifyHTTPError(w, r, logger, http.StatusUnauthorized, "missing session", auth.ErrInvalidToken)
+				httpjson.NotifyProblem(w, r, logger, "missing session", auth.ErrInvalidToken)
 				return
 			}
 
-			accountID, err := sessionStore.GetSessionAccountID(r.Context(), cookie.Value)
+			account, err := sessionStore.GetSessionAccount(r.Context(), cookie.Value)
 			if err != nil {
-				httpjson.NotifyHTTPError(w, r, logger, http.StatusUnauthorized, "invalid session", err)
+				httpjson.NotifyProblem(w, r, logger, "invalid session", err)
 				return
 			}
 
-			ctx := auth.WithAccountID(r.Context(), accountID)
+			ctx := auth.WithAccountID(r.Context(), account.ID)
+			ctx = auth.WithRole(ctx, account.Role)
 			ctx = auth.WithSessionToken(ctx, cookie.Value)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// RequireRole answers 403 unless the account WithAuth put in the context has
+// role. Mount it after WithAuth.
+func RequireRole(role auth.Role, logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := auth.AccountIDFromContext(r.Context()); !ok {
+				httpjson.NotifyProblem(w, r, logger, "missing session", auth.ErrInvalidToken)
+				return
+			}
+
+			if auth.RoleFromContext(r.Context()) != role {
+				httpjson.NotifyProblem(w, r, logger, "missing role "+string(role), domain.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -9,19 +9,27 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/httpserver/problems"
 	"github.com/kalogs-c/nerd-backlog/pkg/auth"
 )
 
+// Adapters answer with the problems the router registers.
+func init() {
+	problems.Register()
+}
+
 type stubSessionStore struct {
 	accountID uuid.UUID
+	role      auth.Role
 	err       error
 }
 
-func (s stubSessionStore) GetSessionAccountID(ctx context.Context, token string) (uuid.UUID, error) {
+func (s stubSessionStore) GetSessionAccount(ctx context.Context, token string) (domain.Account, error) {
 	if s.err != nil {
-		return uuid.Nil, s.err
+		return domain.Account{}, s.err
 	}
-	return s.accountID, nil
+	return domain.Account{ID: s.accountID, Role: s.role}, nil
 }
 
 func TestWithAuth_MissingToken(t *testing.T) {
@@ -96,3 +104,43 @@ func TestWithAuth_SessionCookie(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestRequireRole(t *testing.T) {
+	cases := []struct {
+		name  string
+		store SessionStore
+		code  int
+	}{
+		{"admin", stubSessionStore{accountID: uuid.New(), role: auth.RoleAdmin}, http.StatusOK},
+		{"member", stubSessionStore{accountID: uuid.New(), role: auth.RoleMember}, http.StatusForbidden},
+		{"no session", stubSessionStore{err: domain.ErrSessionNotFound}, http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := WithAuth(tc.store, nil)(RequireRole(auth.RoleAdmin, nil)(ok))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/admin/accounts", nil)
+			req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: "session-token"})
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func TestRequireRole_WithoutAuth(t *testing.T) {
+	handler := RequireRole(auth.RoleAdmin, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/accounts", nil))
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,121 @@
+package httpserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+const metricsNamespace = "nerd_backlog"
+
+// collectTimeout bounds the queries collectors run on each scrape.
+const collectTimeout = 2 * time.Second
+
+// Metrics is the registry /metrics serves. It holds the request metrics
+// WithMetrics records, next to whatever collectors it was built with.
+type Metrics struct {
+	registry *prometheus.Registry
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func NewMetrics(collectors ...prometheus.Collector) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by chi route pattern and status class.",
+		}, []string{"method", "route", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by chi route pattern.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+	}
+
+	m.registry.MustRegister(m.requests, m.duration)
+	m.registry.MustRegister(standardCollectors()...)
+	m.registry.MustRegister(collectors...)
+
+	return m
+}
+
+func standardCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	}
+}
+
+func (m *Metrics) observe(method string, route string, status int, elapsed time.Duration) {
+	m.requests.WithLabelValues(method, route, statusClass(status)).Inc()
+	m.duration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// Handler serves the registry in the Prometheus text format. A collector that
+// fails is logged and left out rather than failing the scrape.
+func (m *Metrics) Handler(logger *slog.Logger) http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog:      slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
+		ErrorHandling: promhttp.ContinueOnError,
+		Registry:      m.registry,
+	})
+}
+
+func statusClass(status int) string {
+	switch {
+	case status >= 500:
+		return "5xx"
+	case status >= 400:
+		return "4xx"
+	case status >= 300:
+		return "3xx"
+	case status >= 200:
+		return "2xx"
+	default:
+		return "1xx"
+	}
+}
+
+// sessionCollector reports the live session count at scrape time.
+type sessionCollector struct {
+	accounts domain.AccountRepository
+	active   *prometheus.Desc
+}
+
+func newSessionCollector(accounts domain.AccountRepository) prometheus.Collector {
+	return &sessionCollector{
+		accounts: accounts,
+		active: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "active_sessions"),
+			"Sessions that have not expired.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *sessionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.active
+}
+
+func (c *sessionCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	count, err := c.accounts.CountActiveSessions(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.active, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, float64(count))
+}
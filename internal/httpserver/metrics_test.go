@@ -0,0 +1,43 @@
+package httpserver
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, router http.Handler) string {
+	t.Helper()
+
+	w := serve(router, http.MethodGet, "/metrics", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestRoutes_Metrics(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"nerd","email":"nerd@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	cookie := sessionCookie(t, w)
+
+	serve(router, http.MethodGet, "/api/games/00000000-0000-0000-0000-000000000001", "", cookie)
+	serve(router, http.MethodGet, "/api/games/00000000-0000-0000-0000-000000000002", "", cookie)
+	serve(router, http.MethodGet, "/api/games", "", nil)
+	serve(router, http.MethodGet, "/nowhere/at/all", "", nil)
+
+	metrics := scrape(t, router)
+
+	require.Contains(t, metrics, `nerd_backlog_http_requests_total{method="POST",route="/api/register",status_class="2xx"} 1`)
+	require.Contains(t, metrics, `nerd_backlog_http_requests_total{method="GET",route="/api/games/{id}",status_class="4xx"} 2`)
+	require.Contains(t, metrics, `nerd_backlog_http_requests_total{method="GET",route="/api/games",status_class="4xx"} 1`)
+	require.Contains(t, metrics, `nerd_backlog_http_requests_total{method="GET",route="unmatched",status_class="4xx"} 1`)
+	require.Contains(t, metrics, `nerd_backlog_http_request_duration_seconds_count{method="GET",route="/api/games/{id}"} 2`)
+	require.Contains(t, metrics, "nerd_backlog_active_sessions 1")
+	require.NotContains(t, metrics, "00000000-0000-0000-0000-000000000001")
+}
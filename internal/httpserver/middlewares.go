@@ -1,11 +1,21 @@
 package httpserver
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/kalogs-c/nerd-backlog/internal/httpserver"
+
 type Middleware = func(next http.Handler) http.Handler
 
 type statusRecorder struct {
@@ -26,7 +36,7 @@ func WithLogging(logger *slog.Logger) Middleware {
 
 			next.ServeHTTP(&rec, r)
 
-			logger.Info("New Request",
+			logger.InfoContext(r.Context(), "New Request",
 				"method", r.Method,
 				"path", r.URL.Path,
 				"status", rec.status,
@@ -36,3 +46,59 @@ func WithLogging(logger *slog.Logger) Middleware {
 		})
 	}
 }
+
+// WithMetrics records every request under the chi route pattern it matched,
+// so "/api/games/{id}" is one series however many games there are. Requests
+// no route matched share the "unmatched" label.
+func WithMetrics(metrics *Metrics) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rec := statusRecorder{w, 200}
+
+			next.ServeHTTP(&rec, r)
+
+			metrics.observe(r.Method, routePattern(r), rec.status, time.Since(start))
+		})
+	}
+}
+
+// WithTracing starts a server span per request, continuing the caller's trace
+// when the request carries a traceparent header. The route is only known once
+// chi has matched it, so the span is renamed after the handler returns.
+func WithTracing() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := otel.Tracer(tracerName).Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			rec := statusRecorder{w, 200}
+			next.ServeHTTP(&rec, r.WithContext(ctx))
+
+			route := routePattern(r.WithContext(ctx))
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(
+				semconv.HTTPRoute(route),
+				semconv.HTTPResponseStatusCode(rec.status),
+			)
+			if rec.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, fmt.Sprintf("status %d", rec.status))
+			}
+		})
+	}
+}
+
+// routePattern is the chi pattern the request matched, or "unmatched".
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+		return rctx.RoutePattern()
+	}
+	return "unmatched"
+}
@@ -0,0 +1,58 @@
+// Package problems maps the errors services return on purpose to RFC 9457
+// problems, so adapters answer with the same status, title and type wherever
+// an error surfaces.
+package problems
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
+)
+
+var once sync.Once
+
+// Register adds every problem to httpjson.DefaultRegistry. Only the first call
+// registers, so routers built more than once, as in tests, share one set.
+// Types are part of the API: rename them only with a version bump.
+func Register() {
+	once.Do(register)
+}
+
+func register() {
+	add := func(err error, slug string, title string, status int) {
+		httpjson.RegisterError(err, httpjson.Problem{
+			Type:   "/problems/" + slug,
+			Title:  title,
+			Status: status,
+		})
+	}
+
+	add(auth.ErrInvalidToken, "unauthenticated", "Authentication required", http.StatusUnauthorized)
+	add(domain.ErrForbidden, "forbidden", "Not allowed", http.StatusForbidden)
+	add(domain.ErrSessionNotFound, "unauthenticated", "Authentication required", http.StatusUnauthorized)
+	add(domain.ErrInvalidCredentials, "invalid-credentials", "Invalid email or password", http.StatusUnauthorized)
+	add(domain.ErrAccountNotFound, "account-not-found", "Account not found", http.StatusNotFound)
+	add(domain.ErrAccountDisabled, "account-disabled", "Account disabled", http.StatusForbidden)
+	add(domain.ErrDisableSelf, "disable-self", "Cannot disable your own account", http.StatusUnprocessableEntity)
+	add(domain.ErrEmailTaken, "email-taken", "Email already registered", http.StatusConflict)
+
+	add(domain.ErrGameNotFound, "game-not-found", "Game not found", http.StatusNotFound)
+	add(domain.ErrMergeSameGame, "merge-same-game", "Cannot merge a game into itself", http.StatusUnprocessableEntity)
+	add(domain.ErrMetadataNotFound, "metadata-not-found", "No metadata found", http.StatusNotFound)
+	add(domain.ErrMetadataUnavailable, "metadata-unavailable", "Metadata provider unavailable", http.StatusServiceUnavailable)
+	add(domain.ErrMetadataFetch, "metadata-fetch-failed", "Metadata provider request failed", http.StatusBadGateway)
+
+	add(domain.ErrLibraryEntryNotFound, "library-entry-not-found", "Library entry not found", http.StatusNotFound)
+	add(domain.ErrInvalidLibraryStatus, "invalid-library-status", "Invalid library status", http.StatusUnprocessableEntity)
+	add(domain.ErrInvalidStatusTransition, "invalid-status-transition", "Invalid status transition", http.StatusConflict)
+
+	add(domain.ErrImportJobNotFound, "import-job-not-found", "Import job not found", http.StatusNotFound)
+	add(domain.ErrJobNotFound, "job-not-found", "Job not found", http.StatusNotFound)
+
+	add(domain.ErrAlreadyExists, "already-exists", "Record already exists", http.StatusConflict)
+	add(domain.ErrReferenceNotFound, "reference-not-found", "Referenced record not found", http.StatusUnprocessableEntity)
+	add(domain.ErrConcurrentUpdate, "concurrent-update", "Concurrent update, retry the request", http.StatusConflict)
+}
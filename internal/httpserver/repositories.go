@@ -0,0 +1,91 @@
+package httpserver
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pressly/goose/v3"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kalogs-c/nerd-backlog/internal/accounts"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/health"
+	"github.com/kalogs-c/nerd-backlog/internal/imports"
+	"github.com/kalogs-c/nerd-backlog/internal/library"
+	"github.com/kalogs-c/nerd-backlog/internal/metadata"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/memory"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/migrate"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/sqlite"
+	sqlitemigrations "github.com/kalogs-c/nerd-backlog/internal/storage/sqlite/migrations"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+// Repositories is the storage the routes are built on. Backends that don't
+// implement a repository leave it nil, and the routes needing it aren't
+// mounted.
+type Repositories struct {
+	Accounts      domain.AccountRepository
+	Games         domain.GameRepository
+	Library       domain.LibraryRepository
+	Imports       domain.ImportRepository
+	MetadataCache domain.MetadataCacheRepository
+	// Checks tell /readyz whether the storage can serve requests.
+	Checks []health.Check
+	// Collectors report storage internals on /metrics.
+	Collectors []prometheus.Collector
+}
+
+func PostgresRepositories(db *pgxpool.Pool) Repositories {
+	queries := sqlc.New(db)
+
+	return Repositories{
+		Accounts:      accounts.NewRepository(queries, db),
+		Games:         games.NewRepository(queries, db),
+		Library:       library.NewRepository(queries, db),
+		Imports:       imports.NewRepository(queries),
+		MetadataCache: metadata.NewRepository(queries),
+		Checks: []health.Check{
+			{Name: "database", Run: db.Ping},
+			migrationsCheck(migrations.Provide(db)),
+		},
+		Collectors: []prometheus.Collector{postgres.NewPoolCollector(db)},
+	}
+}
+
+// SQLiteRepositories covers the catalog and accounts only, so the library,
+// imports and web UI stay off.
+func SQLiteRepositories(db *sql.DB) Repositories {
+	return Repositories{
+		Accounts: sqlite.NewAccountRepository(db),
+		Games:    sqlite.NewGameRepository(db),
+		Checks: []health.Check{
+			{Name: "database", Run: db.PingContext},
+			migrationsCheck(sqlitemigrations.Provide(db)),
+		},
+	}
+}
+
+// MemoryRepositories keeps the catalog, accounts and libraries in memory, for
+// tests and the demo server. Imports stay off.
+func MemoryRepositories() Repositories {
+	games := memory.NewGameRepository()
+
+	return Repositories{
+		Accounts: memory.NewAccountRepository(),
+		Games:    games,
+		Library:  memory.NewLibraryRepository(games),
+	}
+}
+
+func migrationsCheck(provider *goose.Provider, err error) health.Check {
+	return health.Check{Name: "migrations", Run: func(ctx context.Context) error {
+		if err != nil {
+			return err
+		}
+		return migrate.EnsureCurrent(ctx, provider)
+	}}
+}
@@ -2,51 +2,228 @@ package httpserver
 
 import (
 	"log/slog"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/kalogs-c/nerd-backlog/config"
 	"github.com/kalogs-c/nerd-backlog/internal/accounts"
 	"github.com/kalogs-c/nerd-backlog/internal/domain"
 	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/health"
+	"github.com/kalogs-c/nerd-backlog/internal/httpserver/problems"
+	"github.com/kalogs-c/nerd-backlog/internal/imports"
+	"github.com/kalogs-c/nerd-backlog/internal/imports/steam"
+	"github.com/kalogs-c/nerd-backlog/internal/jobs"
+	"github.com/kalogs-c/nerd-backlog/internal/library"
+	"github.com/kalogs-c/nerd-backlog/internal/metadata"
+	"github.com/kalogs-c/nerd-backlog/internal/metadata/igdb"
+	"github.com/kalogs-c/nerd-backlog/internal/web"
 	"github.com/kalogs-c/nerd-backlog/pkg/auth"
-	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
 )
 
+// setupRoutes mounts every route and returns the metadata provider the games
+// routes use, nil when enrichment is disabled, so its background refreshes can
+// be awaited on shutdown.
 func setupRoutes(
 	router chi.Router,
 	logger *slog.Logger,
-	queries *sqlc.Queries,
-) {
+	repos Repositories,
+	config *config.HTTPConfig,
+	runner *jobs.Runner,
+) domain.MetadataProvider {
+	problems.Register()
 	sessionManager := auth.NewSessionManager(time.Hour * 24 * 7)
-	accountsRepo := accounts.NewRepository(queries)
+	metadataProvider := newMetadataProvider(logger, repos.MetadataCache, config)
+
+	// Metrics come first: chi only accepts middlewares before any route.
+	setupMetrics(router, logger, repos, runner)
+	setupHealth(router, logger, repos.Checks, runner)
 
 	router.Route("/api", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
-			r.Use(WithAuth(accountsRepo, logger))
-			setupGames(r, logger, queries)
-			setupAccountsProtected(r, logger, accountsRepo, sessionManager)
+			r.Use(WithAuth(repos.Accounts, logger))
+			setupGames(r, logger, repos, metadataProvider)
+			if repos.Library != nil {
+				setupLibrary(r, logger, repos)
+			}
+			if repos.Library != nil && repos.Imports != nil && runner != nil {
+				setupImports(r, logger, repos, config, runner)
+			}
+			setupAccountsProtected(r, logger, repos.Accounts, sessionManager)
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(RequireRole(auth.RoleAdmin, logger))
+				setupAdmin(r, logger, repos, sessionManager)
+			})
 		})
 
-		setupAccounts(r, logger, accountsRepo, sessionManager)
+		setupAccounts(r, logger, repos.Accounts, sessionManager)
 	})
+
+	if repos.Library != nil {
+		setupWeb(router, logger, repos, sessionManager)
+	}
+
+	return metadataProvider
+}
+
+func setupMetrics(
+	router chi.Router,
+	logger *slog.Logger,
+	repos Repositories,
+	runner *jobs.Runner,
+) {
+	collectors := slices.Clone(repos.Collectors)
+	collectors = append(collectors, newSessionCollector(repos.Accounts))
+	if runner != nil {
+		collectors = append(collectors, runner)
+	}
+	metrics := NewMetrics(collectors...)
+
+	router.Use(WithMetrics(metrics))
+	router.Handle("/metrics", metrics.Handler(logger))
+}
+
+// healthCheckTimeout bounds each readiness check, so a hung database fails
+// the probe instead of stalling it.
+const healthCheckTimeout = 2 * time.Second
+
+// setupHealth mounts the probes at the root, outside any session check, for
+// the reverse proxy and container runtime.
+func setupHealth(
+	router chi.Router,
+	logger *slog.Logger,
+	checks []health.Check,
+	runner *jobs.Runner,
+) {
+	if runner != nil {
+		checks = append(slices.Clone(checks), health.Check{
+			Name:    "workers",
+			Run:     runner.CheckHeartbeats,
+			Details: func() any { return runner.Heartbeats() },
+		})
+	}
+
+	router.Get("/healthz", health.Handler(nil, healthCheckTimeout, logger))
+	router.Get("/readyz", health.Handler(checks, healthCheckTimeout, logger))
 }
 
 func setupGames(
 	router chi.Router,
 	logger *slog.Logger,
-	queries *sqlc.Queries,
+	repos Repositories,
+	metadataProvider domain.MetadataProvider,
 ) {
-	repo := games.NewRepository(queries)
-	service := games.NewService(repo)
+	service := games.NewService(repos.Games, metadataProvider)
 	adapter := games.NewHTTPAdapter(service, logger)
 
 	router.Get("/games", adapter.ListGames)
+	router.Get("/games/search", adapter.SearchGames)
 	router.Get("/games/{id}", adapter.GetGameByID)
 	router.Post("/games", adapter.CreateGame)
+	router.Patch("/games/{id}", adapter.UpdateGame)
+	router.Put("/games/{id}", adapter.ReplaceGame)
+	router.Post("/games/{id}/enrich", adapter.EnrichGame)
 	router.Delete("/games/{id}", adapter.DeleteGameByID)
 }
 
+// newMetadataProvider returns nil when IGDB credentials are missing, which
+// leaves enrichment disabled. Without a cache repository IGDB is queried
+// directly.
+func newMetadataProvider(
+	logger *slog.Logger,
+	cache domain.MetadataCacheRepository,
+	config *config.HTTPConfig,
+) domain.MetadataProvider {
+	if config.IGDBClientID == "" || config.IGDBClientSecret == "" {
+		return nil
+	}
+
+	client := igdb.NewClient(igdb.Config{
+		BaseURL:      config.IGDBBaseURL,
+		TokenURL:     config.TwitchTokenURL,
+		ClientID:     config.IGDBClientID,
+		ClientSecret: config.IGDBClientSecret,
+	}, nil)
+	if cache == nil {
+		return client
+	}
+
+	return metadata.NewCachedProvider(client, cache, config.MetadataCacheTTL, logger)
+}
+
+func setupLibrary(
+	router chi.Router,
+	logger *slog.Logger,
+	repos Repositories,
+) {
+	service := library.NewService(repos.Library, repos.Games)
+	adapter := library.NewHTTPAdapter(service, logger)
+
+	router.Get("/library", adapter.ListEntries)
+	router.Get("/library/{id}", adapter.GetEntry)
+	router.Post("/library", adapter.AddEntry)
+	router.Patch("/library/{id}", adapter.UpdateEntry)
+	router.Delete("/library/{id}", adapter.DeleteEntry)
+	router.Get("/library/{id}/history", adapter.GetEntryHistory)
+}
+
+func setupImports(
+	router chi.Router,
+	logger *slog.Logger,
+	repos Repositories,
+	config *config.HTTPConfig,
+	runner *jobs.Runner,
+) {
+	steamImporter := steam.NewImporter(
+		steam.NewHTTPClient(config.SteamAPIBaseURL, config.SteamAPIKey, nil),
+		repos.Imports,
+		games.NewService(repos.Games, nil),
+		repos.Library,
+	)
+	imports.RegisterJobs(runner, repos.Imports, steamImporter)
+
+	service := imports.NewService(repos.Imports, runner)
+	adapter := imports.NewHTTPAdapter(service, logger)
+
+	router.Get("/imports", adapter.ListJobs)
+	router.Get("/imports/{id}", adapter.GetJob)
+	router.Get("/imports/{id}/items", adapter.ListJobItems)
+	router.Post("/imports/steam", adapter.ImportSteamLibrary)
+}
+
+func setupWeb(
+	router chi.Router,
+	logger *slog.Logger,
+	repos Repositories,
+	sessionManager auth.SessionManager,
+) {
+	handler := web.NewHandler(
+		accounts.NewService(repos.Accounts, sessionManager),
+		games.NewService(repos.Games, nil),
+		library.NewService(repos.Library, repos.Games),
+		repos.Accounts,
+		logger,
+	)
+
+	router.Handle("/static/*", http.StripPrefix("/static/", web.Static()))
+	router.Get("/login", handler.LoginPage)
+	router.Post("/login", handler.Login)
+	router.Get("/register", handler.RegisterPage)
+	router.Post("/register", handler.Register)
+
+	router.Group(func(r chi.Router) {
+		r.Use(handler.RequireSession)
+		r.Get("/", handler.Index)
+		r.Get("/library", handler.Library)
+		r.Get("/games/{id}", handler.Game)
+		r.Post("/logout", handler.Logout)
+	})
+}
+
 func setupAccounts(
 	router chi.Router,
 	logger *slog.Logger,
@@ -71,3 +248,18 @@ func setupAccountsProtected(
 
 	router.Post("/logout", adapter.Logout)
 }
+
+func setupAdmin(
+	router chi.Router,
+	logger *slog.Logger,
+	repos Repositories,
+	sessionManager auth.SessionManager,
+) {
+	accountsAdapter := accounts.NewHTTPAdapter(accounts.NewService(repos.Accounts, sessionManager), logger)
+	gamesAdapter := games.NewHTTPAdapter(games.NewService(repos.Games, nil), logger)
+
+	router.Get("/accounts", accountsAdapter.ListAccounts)
+	router.Post("/accounts/{id}/disable", accountsAdapter.DisableAccount)
+	router.Post("/accounts/{id}/promote", accountsAdapter.PromoteAccount)
+	router.Post("/games/merge", gamesAdapter.MergeGames)
+}
@@ -0,0 +1,337 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/config"
+	"github.com/kalogs-c/nerd-backlog/internal/accounts"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/health"
+	"github.com/kalogs-c/nerd-backlog/internal/library"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/sqlite"
+	sqlitemigrations "github.com/kalogs-c/nerd-backlog/internal/storage/sqlite/migrations"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+// newTestRouter wires the real routes over in-memory repositories.
+func newTestRouter() http.Handler {
+	router := chi.NewRouter()
+	setupRoutes(router, slog.Default(), MemoryRepositories(), &config.HTTPConfig{}, nil)
+	return router
+}
+
+func serve(router http.Handler, method string, path string, body string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func sessionCookie(t *testing.T, w *httptest.ResponseRecorder) *http.Cookie {
+	t.Helper()
+
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == auth.SessionCookieName {
+			return cookie
+		}
+	}
+
+	t.Fatal("no session cookie set")
+	return nil
+}
+
+func TestRoutes_GamesRequireSession(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodGet, "/api/games", "", nil)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRoutes_RegisterCreateAndFindGame(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"nerd","email":"nerd@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	cookie := sessionCookie(t, w)
+
+	w = serve(router, http.MethodPost, "/api/games",
+		`{"title":"Secret of Mana","summary":"Three heroes restore the Mana Tree.","platforms":["snes"]}`, cookie)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created games.GameResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+
+	w = serve(router, http.MethodGet, "/api/games/"+created.ID.String(), "", cookie)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(router, http.MethodGet, "/api/games?platform=snes", "", cookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	var page games.GamesPageResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&page))
+	require.Len(t, page.Items, 1)
+	require.Equal(t, created.ID, page.Items[0].ID)
+
+	w = serve(router, http.MethodGet, "/api/games/search?q=mana", "", cookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	var results []games.GameSearchResultResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&results))
+	require.Len(t, results, 1)
+	require.Equal(t, "Secret of <mark>Mana</mark>", results[0].TitleSnippet)
+}
+
+func TestRoutes_OnlyCreatorChangesGame(t *testing.T) {
+	router := newTestRouter()
+
+	// The first account becomes admin, which may change any game.
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"admin","email":"admin@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"owner","email":"owner@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	owner := sessionCookie(t, w)
+
+	w = serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"other","email":"other@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	other := sessionCookie(t, w)
+
+	w = serve(router, http.MethodPost, "/api/games", `{"title":"Okami"}`, owner)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created games.GameResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	require.NotNil(t, created.CreatedBy)
+
+	path := "/api/games/" + created.ID.String()
+	require.Equal(t, http.StatusForbidden, serve(router, http.MethodPatch, path, `{"title":"Okami HD"}`, other).Code)
+	require.Equal(t, http.StatusForbidden, serve(router, http.MethodDelete, path, "", other).Code)
+
+	require.Equal(t, http.StatusOK, serve(router, http.MethodPatch, path, `{"title":"Okami HD"}`, owner).Code)
+	require.Equal(t, http.StatusOK, serve(router, http.MethodDelete, path, "", owner).Code)
+}
+
+func TestRoutes_OnlyAdminsMergeGames(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"admin","email":"admin@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	admin := sessionCookie(t, w)
+
+	w = serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"member","email":"member@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	member := sessionCookie(t, w)
+
+	ids := make([]string, 2)
+	for i, title := range []string{"Chrono Trigger", "Chrono Trigger (DS)"} {
+		w = serve(router, http.MethodPost, "/api/games", `{"title":"`+title+`"}`, member)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var created games.GameResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+		ids[i] = created.ID.String()
+	}
+
+	// The member created both games and still may not merge them.
+	body := `{"source_id":"` + ids[1] + `","target_id":"` + ids[0] + `"}`
+	require.Equal(t, http.StatusForbidden, serve(router, http.MethodPost, "/api/admin/games/merge", body, member).Code)
+	require.Equal(t, http.StatusMethodNotAllowed, serve(router, http.MethodPost, "/api/games/merge", body, member).Code)
+
+	require.Equal(t, http.StatusOK, serve(router, http.MethodPost, "/api/admin/games/merge", body, admin).Code)
+	require.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, "/api/games/"+ids[1], "", member).Code)
+}
+
+func TestRoutes_AdminManagesAccounts(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"admin","email":"admin@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var admin accounts.AccountResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&admin))
+	require.Equal(t, string(auth.RoleAdmin), admin.Role)
+	adminCookie := sessionCookie(t, w)
+
+	w = serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"member","email":"member@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var member accounts.AccountResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&member))
+	require.Equal(t, string(auth.RoleMember), member.Role)
+	memberCookie := sessionCookie(t, w)
+
+	require.Equal(t, http.StatusForbidden, serve(router, http.MethodGet, "/api/admin/accounts", "", memberCookie).Code)
+	require.Equal(t, http.StatusUnauthorized, serve(router, http.MethodGet, "/api/admin/accounts", "", nil).Code)
+
+	w = serve(router, http.MethodGet, "/api/admin/accounts", "", adminCookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listed []accounts.AccountResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&listed))
+	require.Len(t, listed, 2)
+	require.Equal(t, admin.ID, listed[0].ID)
+
+	w = serve(router, http.MethodPost, "/api/admin/accounts/"+admin.ID.String()+"/disable", "", adminCookie)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	w = serve(router, http.MethodPost, "/api/admin/accounts/"+member.ID.String()+"/promote", "", adminCookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/api/admin/accounts", "", memberCookie).Code)
+
+	w = serve(router, http.MethodPost, "/api/admin/accounts/"+member.ID.String()+"/disable", "", adminCookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	var disabled accounts.AccountResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&disabled))
+	require.NotNil(t, disabled.DisabledAt)
+
+	require.Equal(t, http.StatusUnauthorized, serve(router, http.MethodGet, "/api/games", "", memberCookie).Code)
+	w = serve(router, http.MethodPost, "/api/login", `{"email":"member@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestRoutes_Logout(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"nerd","email":"nerd@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	cookie := sessionCookie(t, w)
+
+	w = serve(router, http.MethodPost, "/api/logout", "", cookie)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = serve(router, http.MethodGet, "/api/games", "", cookie)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRoutes_RegisterTwice(t *testing.T) {
+	router := newTestRouter()
+	body := `{"nickname":"nerd","email":"nerd@example.com","password":"password"}`
+
+	require.Equal(t, http.StatusCreated, serve(router, http.MethodPost, "/api/register", body, nil).Code)
+	require.Equal(t, http.StatusConflict, serve(router, http.MethodPost, "/api/register", body, nil).Code)
+}
+
+func TestRoutes_ProblemResponses(t *testing.T) {
+	router := newTestRouter()
+	w := serve(router, http.MethodPost, "/api/register", `{"nickname":"nerd","email":"nerd@example.com","password":"password"}`, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	cookie := sessionCookie(t, w)
+
+	cases := []struct {
+		name        string
+		method      string
+		path        string
+		body        string
+		cookie      *http.Cookie
+		status      int
+		problemType string
+	}{
+		{"wrong password", http.MethodPost, "/api/login", `{"email":"nerd@example.com","password":"nope"}`, nil, http.StatusUnauthorized, "/problems/invalid-credentials"},
+		{"no session", http.MethodGet, "/api/games", "", nil, http.StatusUnauthorized, "/problems/unauthenticated"},
+		{"malformed json", http.MethodPost, "/api/games", `{"title":`, cookie, http.StatusBadRequest, "/problems/invalid-payload"},
+		{"invalid game", http.MethodPost, "/api/games", `{}`, cookie, http.StatusUnprocessableEntity, "/problems/validation-failed"},
+		{"unknown game", http.MethodGet, "/api/games/00000000-0000-0000-0000-000000000001", "", cookie, http.StatusNotFound, "/problems/game-not-found"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(router, tc.method, tc.path, tc.body, tc.cookie)
+			require.Equal(t, tc.status, w.Code)
+			require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+			var problem map[string]any
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&problem))
+			require.Equal(t, tc.problemType, problem["type"])
+			require.Equal(t, float64(tc.status), problem["status"])
+			require.Equal(t, tc.path, problem["instance"])
+			require.NotEmpty(t, problem["title"])
+		})
+	}
+}
+
+func TestRoutes_OptionalFeaturesNotMounted(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"nerd","email":"nerd@example.com","password":"password"}`, nil)
+	cookie := sessionCookie(t, w)
+
+	require.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, "/api/imports", "", cookie).Code)
+}
+
+func TestRoutes_MemoryLibraryAndWeb(t *testing.T) {
+	router := newTestRouter()
+
+	w := serve(router, http.MethodPost, "/api/register",
+		`{"nickname":"nerd","email":"nerd@example.com","password":"password"}`, nil)
+	cookie := sessionCookie(t, w)
+
+	w = serve(router, http.MethodPost, "/api/games", `{"title":"Chrono Trigger"}`, cookie)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var game games.GameResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&game))
+
+	w = serve(router, http.MethodPost, "/api/library", `{"game_id":"`+game.ID.String()+`","status":"backlog"}`, cookie)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = serve(router, http.MethodGet, "/api/library", "", cookie)
+	require.Equal(t, http.StatusOK, w.Code)
+	var entries []library.LibraryEntryResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&entries))
+	require.Len(t, entries, 1)
+	require.Equal(t, "Chrono Trigger", entries[0].GameTitle)
+
+	require.Equal(t, http.StatusOK, serve(router, http.MethodGet, "/login", "", nil).Code)
+}
+
+func TestRoutes_HealthProbesNeedNoSession(t *testing.T) {
+	router := newTestRouter()
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		w := serve(router, http.MethodGet, path, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, path)
+
+		var report health.Report
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		require.Equal(t, health.StatusOK, report.Status)
+	}
+}
+
+func TestRoutes_ReadinessChecksSchema(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Connect(ctx, "file:"+t.TempDir()+"/backlog.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	router := chi.NewRouter()
+	setupRoutes(router, slog.Default(), SQLiteRepositories(db), &config.HTTPConfig{}, nil)
+
+	w := serve(router, http.MethodGet, "/readyz", "", nil)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var report health.Report
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Equal(t, health.StatusOK, report.Checks["database"].Status)
+	require.Equal(t, health.StatusFail, report.Checks["migrations"].Status)
+
+	_, err = sqlitemigrations.MustProvide(db).Up(ctx)
+	require.NoError(t, err)
+
+	w = serve(router, http.MethodGet, "/readyz", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+}
@@ -1,28 +1,34 @@
 package httpserver
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
-	"os"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/kalogs-c/nerd-backlog/config"
-	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/jobs"
 )
 
 type HTTPServer struct {
-	logger  *slog.Logger
-	queries *sqlc.Queries
-	config  *config.HTTPConfig
-	server  http.Server
+	logger   *slog.Logger
+	config   *config.HTTPConfig
+	server   http.Server
+	listener net.Listener
+	done     chan error
+	metadata domain.MetadataProvider
 }
 
 func NewHTTPServer(
 	logger *slog.Logger,
-	queries *sqlc.Queries,
+	repos Repositories,
 	config *config.HTTPConfig,
+	runner *jobs.Runner,
 	middlewares ...Middleware,
 ) *HTTPServer {
 	router := chi.NewRouter()
@@ -30,23 +36,86 @@ func NewHTTPServer(
 		router.Use(m)
 	}
 
-	setupRoutes(router, logger, queries)
+	metadataProvider := setupRoutes(router, logger, repos, config, runner)
 
 	return &HTTPServer{
-		logger:  logger,
-		queries: queries,
-		config:  config,
+		logger: logger,
+		config: config,
 		server: http.Server{
-			Addr:    net.JoinHostPort(config.Host, config.Port),
-			Handler: router,
+			Addr:         net.JoinHostPort(config.Host, config.Port),
+			Handler:      router,
+			ReadTimeout:  config.ReadTimeout,
+			WriteTimeout: config.WriteTimeout,
+			IdleTimeout:  config.IdleTimeout,
+			ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
 		},
+		done:     make(chan error, 1),
+		metadata: metadataProvider,
 	}
 }
 
-func (s *HTTPServer) MustServe() {
-	s.logger.Info("Server up and running!", "host", s.config.Host, "port", s.config.Port)
-	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		s.logger.Error("error listening and serving", "err", err)
-		os.Exit(1)
+// Start binds the address, so a port already in use fails here, and serves in
+// the background. Done reports when serving stops.
+func (s *HTTPServer) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", s.server.Addr, err)
+	}
+	s.listener = listener
+
+	s.logger.Info("Server up and running!", "addr", listener.Addr().String())
+	go func() {
+		err := s.server.Serve(listener)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		s.done <- err
+	}()
+
+	return nil
+}
+
+// Addr is the address the server listens on once started, which tells the
+// port picked for ":0".
+func (s *HTTPServer) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Done yields nil after a Shutdown, or the error that stopped serving.
+func (s *HTTPServer) Done() <-chan error {
+	return s.done
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests until
+// ctx ends. Requests still running then are cut off. Metadata refreshes the
+// requests started in the background are waited for too, within the same ctx,
+// since they write to the database.
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		_ = s.server.Close()
+		return fmt.Errorf("drain requests: %w", err)
+	}
+
+	s.logger.Info("Server stopped accepting requests")
+
+	refresher, ok := s.metadata.(interface{ Wait() })
+	if !ok {
+		return nil
+	}
+
+	refreshed := make(chan struct{})
+	go func() {
+		refresher.Wait()
+		close(refreshed)
+	}()
+
+	select {
+	case <-refreshed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait for metadata refreshes: %w", ctx.Err())
 	}
 }
@@ -0,0 +1,132 @@
+package httpserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/config"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+// blockOn holds requests to path until release is closed, after signalling
+// started.
+func blockOn(path string, started chan<- struct{}, release <-chan struct{}) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == path {
+				started <- struct{}{}
+				<-release
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func startTestServer(t *testing.T, middlewares ...Middleware) *HTTPServer {
+	t.Helper()
+
+	cfg := &config.HTTPConfig{Host: "127.0.0.1", Port: "0"}
+	server := NewHTTPServer(slog.Default(), MemoryRepositories(), cfg, nil, middlewares...)
+	require.NoError(t, server.Start())
+	return server
+}
+
+func TestHTTPServer_ShutdownDrainsRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := startTestServer(t, blockOn("/slow", started, release))
+	url := "http://" + server.Addr().String()
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(url + "/slow")
+		if err != nil {
+			status <- 0
+			return
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- server.Shutdown(context.Background())
+	}()
+
+	// New connections are refused while the slow request drains.
+	require.Eventually(t, func() bool {
+		_, err := http.Get(url + "/api/games")
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	require.Equal(t, http.StatusNoContent, <-status)
+	require.NoError(t, <-shutdown)
+	require.NoError(t, <-server.Done())
+}
+
+func TestHTTPServer_ShutdownDeadline(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	server := startTestServer(t, blockOn("/slow", started, release))
+
+	go func() {
+		resp, err := http.Get("http://" + server.Addr().String() + "/slow")
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, server.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+// pendingRefresh stands in for a metadata provider with a background
+// refresh that lasts until release is closed.
+type pendingRefresh struct {
+	domain.MetadataProvider
+	release chan struct{}
+}
+
+func (p pendingRefresh) Wait() {
+	<-p.release
+}
+
+func TestHTTPServer_ShutdownWaitsForMetadataRefreshes(t *testing.T) {
+	server := startTestServer(t)
+	refresh := pendingRefresh{release: make(chan struct{})}
+	server.metadata = refresh
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, server.Shutdown(ctx), context.DeadlineExceeded)
+
+	close(refresh.release)
+	require.NoError(t, server.Shutdown(context.Background()))
+}
+
+func TestHTTPServer_StartAddressInUse(t *testing.T) {
+	server := startTestServer(t)
+	defer func() { _ = server.Shutdown(context.Background()) }()
+
+	host, port, err := net.SplitHostPort(server.Addr().String())
+	require.NoError(t, err)
+
+	other := NewHTTPServer(slog.Default(), MemoryRepositories(), &config.HTTPConfig{Host: host, Port: port}, nil)
+	require.Error(t, other.Start())
+}
@@ -0,0 +1,99 @@
+package imports
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/validator"
+)
+
+const steamIDLength = 17
+
+type ImportJobResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	Source            string     `json:"source"`
+	ExternalAccountID string     `json:"external_account_id"`
+	Status            string     `json:"status"`
+	TotalItems        int        `json:"total_items"`
+	ProcessedItems    int        `json:"processed_items"`
+	FailedItems       int        `json:"failed_items"`
+	Error             string     `json:"error,omitempty"`
+	FinishedAt        *time.Time `json:"finished_at"`
+	InsertedAt        time.Time  `json:"inserted_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+func MountImportJobResponse(job domain.ImportJob) ImportJobResponse {
+	var finishedAt *time.Time
+	if !job.FinishedAt.IsZero() {
+		finishedAt = &job.FinishedAt
+	}
+
+	return ImportJobResponse{
+		ID:                job.ID,
+		Source:            string(job.Source),
+		ExternalAccountID: job.ExternalAccountID,
+		Status:            string(job.Status),
+		TotalItems:        job.TotalItems,
+		ProcessedItems:    job.ProcessedItems,
+		FailedItems:       job.FailedItems,
+		Error:             job.Error,
+		FinishedAt:        finishedAt,
+		InsertedAt:        job.InsertedAt,
+		UpdatedAt:         job.UpdatedAt,
+	}
+}
+
+func MountImportJobsResponse(jobs []domain.ImportJob) []ImportJobResponse {
+	response := make([]ImportJobResponse, len(jobs))
+	for i, job := range jobs {
+		response[i] = MountImportJobResponse(job)
+	}
+	return response
+}
+
+type ImportJobItemResponse struct {
+	ID         int64      `json:"id"`
+	ExternalID string     `json:"external_id"`
+	Title      string     `json:"title"`
+	Status     string     `json:"status"`
+	GameID     *uuid.UUID `json:"game_id"`
+	Error      string     `json:"error,omitempty"`
+}
+
+func MountImportJobItemsResponse(items []domain.ImportJobItem) []ImportJobItemResponse {
+	response := make([]ImportJobItemResponse, len(items))
+	for i, item := range items {
+		response[i] = ImportJobItemResponse{
+			ID:         item.ID,
+			ExternalID: item.ExternalID,
+			Title:      item.Title,
+			Status:     string(item.Status),
+			Error:      item.Error,
+		}
+		if item.GameID != uuid.Nil {
+			response[i].GameID = &item.GameID
+		}
+	}
+	return response
+}
+
+type SteamImportPayload struct {
+	SteamID string `json:"steam_id"`
+}
+
+func (p *SteamImportPayload) Valid(ctx context.Context) validator.Problems {
+	problems := make(validator.Problems)
+
+	p.SteamID = strings.TrimSpace(p.SteamID)
+	if p.SteamID == "" {
+		problems.Add("steam_id", "steam_id is required")
+	} else if len(p.SteamID) != steamIDLength || strings.Trim(p.SteamID, "0123456789") != "" {
+		problems.Add("steam_id", "steam_id must be a 17 digit SteamID64")
+	}
+
+	return problems
+}
@@ -0,0 +1,95 @@
+package imports
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
+)
+
+type HTTPAdapter struct {
+	service domain.ImportService
+	logger  *slog.Logger
+}
+
+func NewHTTPAdapter(s domain.ImportService, logger *slog.Logger) *HTTPAdapter {
+	return &HTTPAdapter{s, logger}
+}
+
+func (h *HTTPAdapter) error(w http.ResponseWriter, r *http.Request, code int, title string, err error) {
+	httpjson.NotifyHTTPError(w, r, h.logger, code, title, err)
+}
+
+func (h *HTTPAdapter) serviceError(w http.ResponseWriter, r *http.Request, action string, err error) {
+	httpjson.NotifyProblem(w, r, h.logger, action, err)
+}
+
+func (h *HTTPAdapter) ImportSteamLibrary(w http.ResponseWriter, r *http.Request) {
+	payload, err := httpjson.DecodeValid[*SteamImportPayload](r)
+	if err != nil {
+		h.serviceError(w, r, "invalid payload", err)
+		return
+	}
+
+	job, err := h.service.ImportSteamLibrary(r.Context(), payload.SteamID)
+	if err != nil {
+		h.serviceError(w, r, "failed to import steam library", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusAccepted, MountImportJobResponse(job)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode import job", err)
+	}
+}
+
+func (h *HTTPAdapter) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	job, err := h.service.GetJob(r.Context(), id)
+	if err != nil {
+		h.serviceError(w, r, "failed to retrieve import job", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountImportJobResponse(job)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode import job", err)
+	}
+}
+
+func (h *HTTPAdapter) ListJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.service.ListJobs(r.Context())
+	if err != nil {
+		h.serviceError(w, r, "failed to list import jobs", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountImportJobsResponse(jobs)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode import jobs", err)
+	}
+}
+
+func (h *HTTPAdapter) ListJobItems(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	items, err := h.service.ListJobItems(r.Context(), id)
+	if err != nil {
+		h.serviceError(w, r, "failed to list import job items", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountImportJobItemsResponse(items)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode import job items", err)
+	}
+}
@@ -0,0 +1,117 @@
+package imports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/httpserver/problems"
+)
+
+// Adapters answer with the problems the router registers.
+func init() {
+	problems.Register()
+}
+
+func withRouteParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHTTPAdapter_ImportSteamLibrary(t *testing.T) {
+	mockSvc := new(MockImportService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	want := domain.ImportJob{
+		ID:                uuid.New(),
+		Source:            domain.ImportSourceSteam,
+		ExternalAccountID: "76561197960287930",
+		Status:            domain.ImportJobPending,
+	}
+	mockSvc.On("ImportSteamLibrary", mock.Anything, "76561197960287930").Return(want, nil)
+
+	body := bytes.NewBufferString(`{"steam_id":"76561197960287930"}`)
+	req := httptest.NewRequest(http.MethodPost, "/imports/steam", body)
+	w := httptest.NewRecorder()
+
+	handler.ImportSteamLibrary(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var got ImportJobResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Equal(t, want.ID, got.ID)
+	require.Equal(t, "pending", got.Status)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_ImportSteamLibrary_InvalidSteamID(t *testing.T) {
+	mockSvc := new(MockImportService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	for _, body := range []string{`{}`, `{"steam_id":"gaben"}`, `{"steam_id":"7656119796028793"}`} {
+		req := httptest.NewRequest(http.MethodPost, "/imports/steam", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+
+		handler.ImportSteamLibrary(w, req)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, body)
+	}
+
+	mockSvc.AssertNotCalled(t, "ImportSteamLibrary", mock.Anything, mock.Anything)
+}
+
+func TestHTTPAdapter_GetJob_NotFound(t *testing.T) {
+	mockSvc := new(MockImportService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	mockSvc.On("GetJob", mock.Anything, id).Return(domain.ImportJob{}, domain.ErrImportJobNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/imports/"+id.String(), nil)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.GetJob(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_ListJobItems(t *testing.T) {
+	mockSvc := new(MockImportService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	gameID := uuid.New()
+	mockSvc.On("ListJobItems", mock.Anything, id).Return([]domain.ImportJobItem{
+		{ID: 1, JobID: id, ExternalID: "292030", Title: "The Witcher 3: Wild Hunt", Status: domain.ImportJobItemImported, GameID: gameID},
+		{ID: 2, JobID: id, ExternalID: "10", Title: "Counter-Strike", Status: domain.ImportJobItemFailed, Error: "db down"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/imports/"+id.String()+"/items", nil)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.ListJobItems(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got []ImportJobItemResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Len(t, got, 2)
+	require.Equal(t, &gameID, got[0].GameID)
+	require.Nil(t, got[1].GameID)
+	require.Equal(t, "db down", got[1].Error)
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/jobs"
+)
+
+const SteamImportJobKind = "imports.steam"
+
+type SteamImportTask struct {
+	ImportJobID uuid.UUID `json:"import_job_id"`
+	AccountID   uuid.UUID `json:"account_id"`
+}
+
+func RegisterJobs(runner *jobs.Runner, repository domain.ImportRepository, steamImporter domain.Importer) {
+	jobs.Register(runner, SteamImportJobKind, steamImportHandler(repository, steamImporter))
+	jobs.OnDead(runner, SteamImportJobKind, failSteamImport(repository))
+}
+
+func steamImportHandler(
+	repository domain.ImportRepository,
+	steamImporter domain.Importer,
+) func(ctx context.Context, task SteamImportTask) error {
+	return func(ctx context.Context, task SteamImportTask) error {
+		job, err := repository.GetImportJob(ctx, task.AccountID, task.ImportJobID)
+		if errors.Is(err, domain.ErrImportJobNotFound) {
+			return jobs.Permanent(err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if job.Status == domain.ImportJobCompleted {
+			return nil
+		}
+
+		_, err = steamImporter.Import(ctx, job)
+		return err
+	}
+}
+
+// failSteamImport marks the import as failed once the runner stops retrying
+// it, so the import only reports failure when no attempt is left.
+func failSteamImport(
+	repository domain.ImportRepository,
+) func(ctx context.Context, task SteamImportTask, cause error) error {
+	return func(ctx context.Context, task SteamImportTask, cause error) error {
+		job, err := repository.GetImportJob(ctx, task.AccountID, task.ImportJobID)
+		if errors.Is(err, domain.ErrImportJobNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		job.Status = domain.ImportJobFailed
+		job.Error = cause.Error()
+		job.FinishedAt = time.Now().UTC()
+
+		_, err = repository.UpdateImportJob(ctx, job)
+		return err
+	}
+}
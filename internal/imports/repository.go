@@ -0,0 +1,151 @@
+package imports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+type repository struct {
+	db *sqlc.Queries
+}
+
+func NewRepository(q *sqlc.Queries) domain.ImportRepository {
+	return &repository{q}
+}
+
+func (r *repository) CreateImportJob(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	created, err := r.db.CreateImportJob(ctx, sqlc.CreateImportJobParams{
+		AccountID:         job.AccountID,
+		Source:            string(job.Source),
+		ExternalAccountID: job.ExternalAccountID,
+		Status:            string(job.Status),
+	})
+	if err != nil {
+		return domain.ImportJob{}, postgres.TranslateError(err, nil)
+	}
+
+	return toDomainJob(created), nil
+}
+
+func (r *repository) GetImportJob(ctx context.Context, accountID uuid.UUID, id uuid.UUID) (domain.ImportJob, error) {
+	job, err := r.db.GetImportJob(ctx, sqlc.GetImportJobParams{
+		ID:        id,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return domain.ImportJob{}, postgres.TranslateError(err, domain.ErrImportJobNotFound)
+	}
+
+	return toDomainJob(job), nil
+}
+
+func (r *repository) ListImportJobs(ctx context.Context, accountID uuid.UUID) ([]domain.ImportJob, error) {
+	jobs, err := r.db.ListImportJobs(ctx, accountID)
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	jobsList := make([]domain.ImportJob, len(jobs))
+	for i, job := range jobs {
+		jobsList[i] = toDomainJob(job)
+	}
+
+	return jobsList, nil
+}
+
+func (r *repository) UpdateImportJob(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	updated, err := r.db.UpdateImportJob(ctx, sqlc.UpdateImportJobParams{
+		ID:             job.ID,
+		Status:         string(job.Status),
+		TotalItems:     int32(job.TotalItems),
+		ProcessedItems: int32(job.ProcessedItems),
+		FailedItems:    int32(job.FailedItems),
+		Error:          job.Error,
+		FinishedAt:     pgtype.Timestamptz{Time: job.FinishedAt, Valid: !job.FinishedAt.IsZero()},
+	})
+	if err != nil {
+		return domain.ImportJob{}, postgres.TranslateError(err, domain.ErrImportJobNotFound)
+	}
+
+	return toDomainJob(updated), nil
+}
+
+func (r *repository) CreateImportJobItem(ctx context.Context, item domain.ImportJobItem) (domain.ImportJobItem, error) {
+	created, err := r.db.CreateImportJobItem(ctx, sqlc.CreateImportJobItemParams{
+		JobID:      item.JobID,
+		ExternalID: item.ExternalID,
+		Title:      item.Title,
+	})
+	if err != nil {
+		return domain.ImportJobItem{}, postgres.TranslateError(err, nil)
+	}
+
+	return toDomainItem(created), nil
+}
+
+func (r *repository) UpdateImportJobItem(ctx context.Context, item domain.ImportJobItem) error {
+	err := r.db.UpdateImportJobItem(ctx, sqlc.UpdateImportJobItemParams{
+		ID:     item.ID,
+		Status: string(item.Status),
+		GameID: pgtype.UUID{Bytes: item.GameID, Valid: item.GameID != uuid.Nil},
+		Error:  item.Error,
+	})
+	return postgres.TranslateError(err, nil)
+}
+
+func (r *repository) ListImportJobItems(ctx context.Context, accountID uuid.UUID, jobID uuid.UUID) ([]domain.ImportJobItem, error) {
+	items, err := r.db.ListImportJobItems(ctx, sqlc.ListImportJobItemsParams{
+		JobID:     jobID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	itemsList := make([]domain.ImportJobItem, len(items))
+	for i, item := range items {
+		itemsList[i] = toDomainItem(item)
+	}
+
+	return itemsList, nil
+}
+
+func toDomainJob(job sqlc.ImportJob) domain.ImportJob {
+	return domain.ImportJob{
+		ID:                job.ID,
+		AccountID:         job.AccountID,
+		Source:            domain.ImportSource(job.Source),
+		ExternalAccountID: job.ExternalAccountID,
+		Status:            domain.ImportJobStatus(job.Status),
+		TotalItems:        int(job.TotalItems),
+		ProcessedItems:    int(job.ProcessedItems),
+		FailedItems:       int(job.FailedItems),
+		Error:             job.Error,
+		FinishedAt:        job.FinishedAt.Time,
+		TimeStamps: domain.TimeStamps{
+			InsertedAt: job.InsertedAt.Time,
+			UpdatedAt:  job.UpdatedAt.Time,
+		},
+	}
+}
+
+func toDomainItem(item sqlc.ImportJobItem) domain.ImportJobItem {
+	return domain.ImportJobItem{
+		ID:         item.ID,
+		JobID:      item.JobID,
+		ExternalID: item.ExternalID,
+		Title:      item.Title,
+		Status:     domain.ImportJobItemStatus(item.Status),
+		GameID:     item.GameID.Bytes,
+		Error:      item.Error,
+		TimeStamps: domain.TimeStamps{
+			InsertedAt: item.InsertedAt.Time,
+			UpdatedAt:  item.UpdatedAt.Time,
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package imports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportRepository struct {
+	mock.Mock
+}
+
+func NewMockImportRepository() domain.ImportRepository {
+	return new(MockImportRepository)
+}
+
+func (m *MockImportRepository) CreateImportJob(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	args := m.Called(ctx, job)
+	return args.Get(0).(domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportRepository) GetImportJob(ctx context.Context, accountID uuid.UUID, id uuid.UUID) (domain.ImportJob, error) {
+	args := m.Called(ctx, accountID, id)
+	return args.Get(0).(domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportRepository) ListImportJobs(ctx context.Context, accountID uuid.UUID) ([]domain.ImportJob, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportRepository) UpdateImportJob(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	args := m.Called(ctx, job)
+	return args.Get(0).(domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportRepository) CreateImportJobItem(ctx context.Context, item domain.ImportJobItem) (domain.ImportJobItem, error) {
+	args := m.Called(ctx, item)
+	return args.Get(0).(domain.ImportJobItem), args.Error(1)
+}
+
+func (m *MockImportRepository) UpdateImportJobItem(ctx context.Context, item domain.ImportJobItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockImportRepository) ListImportJobItems(ctx context.Context, accountID uuid.UUID, jobID uuid.UUID) ([]domain.ImportJobItem, error) {
+	args := m.Called(ctx, accountID, jobID)
+	return args.Get(0).([]domain.ImportJobItem), args.Error(1)
+}
@@ -0,0 +1,135 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kalogs-c/nerd-backlog/internal/accounts"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/testutils"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+	"github.com/stretchr/testify/require"
+)
+
+var testQueries *sqlc.Queries
+var testDB *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dsn, terminate, err := testutils.StartPostgresContainer(ctx)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	testDB = postgres.MustConnect(ctx, dsn, nil)
+	gooseProvider := migrations.MustProvide(testDB)
+	testQueries = sqlc.New(testDB)
+
+	_, err = gooseProvider.Up(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	exitCode := m.Run()
+
+	if err := terminate(context.Background()); err != nil {
+		log.Println(err)
+	}
+
+	os.Exit(exitCode)
+}
+
+func createAccount(t *testing.T, ctx context.Context) domain.Account {
+	t.Helper()
+
+	account, err := accounts.NewRepository(testQueries, testDB).CreateAccount(ctx, domain.Account{
+		Nickname:       "importer",
+		Email:          fmt.Sprintf("imports_test%d@example.com", rand.Uint64()),
+		HashedPassword: "salt$hash",
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestRepository_ImportJobLifecycle(t *testing.T) {
+	repo := NewRepository(testQueries)
+	ctx := context.Background()
+	account := createAccount(t, ctx)
+
+	job, err := repo.CreateImportJob(ctx, domain.ImportJob{
+		AccountID:         account.ID,
+		Source:            domain.ImportSourceSteam,
+		ExternalAccountID: "76561197960287930",
+		Status:            domain.ImportJobPending,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, job.ID)
+
+	game, err := games.NewRepository(testQueries, testDB).CreateGame(ctx, domain.Game{Title: "Hades"})
+	require.NoError(t, err)
+
+	item, err := repo.CreateImportJobItem(ctx, domain.ImportJobItem{JobID: job.ID, ExternalID: "1145360", Title: "Hades"})
+	require.NoError(t, err)
+	require.Equal(t, domain.ImportJobItemPending, item.Status)
+
+	item.Status = domain.ImportJobItemImported
+	item.GameID = game.ID
+	require.NoError(t, repo.UpdateImportJobItem(ctx, item))
+
+	job.Status = domain.ImportJobCompleted
+	job.TotalItems = 1
+	job.ProcessedItems = 1
+	job.FinishedAt = time.Now().UTC()
+	_, err = repo.UpdateImportJob(ctx, job)
+	require.NoError(t, err)
+
+	got, err := repo.GetImportJob(ctx, account.ID, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.ImportJobCompleted, got.Status)
+	require.Equal(t, 1, got.ProcessedItems)
+	require.False(t, got.FinishedAt.IsZero())
+
+	items, err := repo.ListImportJobItems(ctx, account.ID, job.ID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, game.ID, items[0].GameID)
+	require.Equal(t, domain.ImportJobItemImported, items[0].Status)
+
+	jobs, err := repo.ListImportJobs(ctx, account.ID)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+}
+
+func TestRepository_GetImportJob_OtherAccount(t *testing.T) {
+	repo := NewRepository(testQueries)
+	ctx := context.Background()
+	owner := createAccount(t, ctx)
+	stranger := createAccount(t, ctx)
+
+	job, err := repo.CreateImportJob(ctx, domain.ImportJob{
+		AccountID:         owner.ID,
+		Source:            domain.ImportSourceSteam,
+		ExternalAccountID: "76561197960287930",
+		Status:            domain.ImportJobPending,
+	})
+	require.NoError(t, err)
+
+	_, err = repo.GetImportJob(ctx, stranger.ID, job.ID)
+	require.ErrorIs(t, err, domain.ErrImportJobNotFound)
+
+	items, err := repo.ListImportJobItems(ctx, stranger.ID, job.ID)
+	require.NoError(t, err)
+	require.Empty(t, items)
+}
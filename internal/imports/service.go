@@ -0,0 +1,72 @@
+package imports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+type service struct {
+	repository domain.ImportRepository
+	queue      domain.JobQueue
+}
+
+func NewService(repository domain.ImportRepository, queue domain.JobQueue) domain.ImportService {
+	return &service{repository, queue}
+}
+
+func (s *service) ImportSteamLibrary(ctx context.Context, steamID string) (domain.ImportJob, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return domain.ImportJob{}, auth.ErrInvalidToken
+	}
+
+	job, err := s.repository.CreateImportJob(ctx, domain.ImportJob{
+		AccountID:         accountID,
+		Source:            domain.ImportSourceSteam,
+		ExternalAccountID: steamID,
+		Status:            domain.ImportJobPending,
+	})
+	if err != nil {
+		return domain.ImportJob{}, err
+	}
+
+	_, err = s.queue.Enqueue(ctx, SteamImportJobKind, SteamImportTask{
+		ImportJobID: job.ID,
+		AccountID:   job.AccountID,
+	})
+	if err != nil {
+		return domain.ImportJob{}, err
+	}
+
+	return job, nil
+}
+
+func (s *service) GetJob(ctx context.Context, id uuid.UUID) (domain.ImportJob, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return domain.ImportJob{}, auth.ErrInvalidToken
+	}
+
+	return s.repository.GetImportJob(ctx, accountID, id)
+}
+
+func (s *service) ListJobs(ctx context.Context) ([]domain.ImportJob, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	}
+
+	return s.repository.ListImportJobs(ctx, accountID)
+}
+
+func (s *service) ListJobItems(ctx context.Context, id uuid.UUID) ([]domain.ImportJobItem, error) {
+	job, err := s.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListImportJobItems(ctx, job.AccountID, job.ID)
+}
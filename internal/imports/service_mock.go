@@ -0,0 +1,46 @@
+package imports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImportService struct {
+	mock.Mock
+}
+
+func NewMockImportService() domain.ImportService {
+	return new(MockImportService)
+}
+
+func (m *MockImportService) ImportSteamLibrary(ctx context.Context, steamID string) (domain.ImportJob, error) {
+	args := m.Called(ctx, steamID)
+	return args.Get(0).(domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) GetJob(ctx context.Context, id uuid.UUID) (domain.ImportJob, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) ListJobs(ctx context.Context) ([]domain.ImportJob, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) ListJobItems(ctx context.Context, id uuid.UUID) ([]domain.ImportJobItem, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]domain.ImportJobItem), args.Error(1)
+}
+
+type MockImporter struct {
+	mock.Mock
+}
+
+func (m *MockImporter) Import(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	args := m.Called(ctx, job)
+	return args.Get(0).(domain.ImportJob), args.Error(1)
+}
@@ -0,0 +1,172 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/jobs"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ImportSteamLibrary(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	mockQueue := new(jobs.MockJobQueue)
+	svc := NewService(mockRepo, mockQueue)
+
+	accountID := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	pending := domain.ImportJob{
+		AccountID:         accountID,
+		Source:            domain.ImportSourceSteam,
+		ExternalAccountID: "76561197960287930",
+		Status:            domain.ImportJobPending,
+	}
+	created := pending
+	created.ID = uuid.New()
+
+	mockRepo.On("CreateImportJob", ctx, pending).Return(created, nil)
+	mockQueue.On("Enqueue", ctx, SteamImportJobKind, SteamImportTask{
+		ImportJobID: created.ID,
+		AccountID:   accountID,
+	}).Return(domain.Job{ID: 1, Kind: SteamImportJobKind}, nil)
+
+	got, err := svc.ImportSteamLibrary(ctx, "76561197960287930")
+	require.NoError(t, err)
+	require.Equal(t, created, got)
+
+	mockRepo.AssertExpectations(t)
+	mockQueue.AssertExpectations(t)
+}
+
+func TestService_ImportSteamLibrary_MissingAccount(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	mockQueue := new(jobs.MockJobQueue)
+	svc := NewService(mockRepo, mockQueue)
+
+	_, err := svc.ImportSteamLibrary(context.Background(), "76561197960287930")
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	mockRepo.AssertNotCalled(t, "CreateImportJob", mock.Anything, mock.Anything)
+	mockQueue.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestService_ListJobItems(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	svc := NewService(mockRepo, new(jobs.MockJobQueue))
+
+	accountID := uuid.New()
+	jobID := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	items := []domain.ImportJobItem{{ID: 1, JobID: jobID, ExternalID: "292030", Title: "The Witcher 3: Wild Hunt"}}
+	mockRepo.On("GetImportJob", ctx, accountID, jobID).Return(domain.ImportJob{ID: jobID, AccountID: accountID}, nil)
+	mockRepo.On("ListImportJobItems", ctx, accountID, jobID).Return(items, nil)
+
+	got, err := svc.ListJobItems(ctx, jobID)
+	require.NoError(t, err)
+	require.Equal(t, items, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_ListJobItems_NotFound(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	svc := NewService(mockRepo, new(jobs.MockJobQueue))
+
+	accountID := uuid.New()
+	jobID := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	mockRepo.On("GetImportJob", ctx, accountID, jobID).Return(domain.ImportJob{}, domain.ErrImportJobNotFound)
+
+	_, err := svc.ListJobItems(ctx, jobID)
+	require.ErrorIs(t, err, domain.ErrImportJobNotFound)
+	mockRepo.AssertNotCalled(t, "ListImportJobItems", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSteamImportHandler(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	mockImporter := new(MockImporter)
+	handle := steamImportHandler(mockRepo, mockImporter)
+
+	ctx := context.Background()
+	task := SteamImportTask{ImportJobID: uuid.New(), AccountID: uuid.New()}
+	pending := domain.ImportJob{ID: task.ImportJobID, AccountID: task.AccountID, Status: domain.ImportJobPending}
+
+	mockRepo.On("GetImportJob", ctx, task.AccountID, task.ImportJobID).Return(pending, nil)
+	mockImporter.On("Import", ctx, pending).Return(domain.ImportJob{Status: domain.ImportJobCompleted}, nil)
+
+	require.NoError(t, handle(ctx, task))
+	mockImporter.AssertExpectations(t)
+}
+
+func TestSteamImportHandler_SkipsFinishedJobs(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	mockImporter := new(MockImporter)
+	handle := steamImportHandler(mockRepo, mockImporter)
+
+	ctx := context.Background()
+	task := SteamImportTask{ImportJobID: uuid.New(), AccountID: uuid.New()}
+
+	mockRepo.On("GetImportJob", ctx, task.AccountID, task.ImportJobID).
+		Return(domain.ImportJob{ID: task.ImportJobID, Status: domain.ImportJobCompleted}, nil)
+
+	require.NoError(t, handle(ctx, task))
+	mockImporter.AssertNotCalled(t, "Import", mock.Anything, mock.Anything)
+}
+
+func TestSteamImportHandler_RetriesFailedJobs(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	mockImporter := new(MockImporter)
+	handle := steamImportHandler(mockRepo, mockImporter)
+
+	ctx := context.Background()
+	task := SteamImportTask{ImportJobID: uuid.New(), AccountID: uuid.New()}
+	failed := domain.ImportJob{ID: task.ImportJobID, AccountID: task.AccountID, Status: domain.ImportJobFailed}
+
+	mockRepo.On("GetImportJob", ctx, task.AccountID, task.ImportJobID).Return(failed, nil)
+	mockImporter.On("Import", ctx, failed).Return(failed, errors.New("steam is down"))
+
+	err := handle(ctx, task)
+	require.EqualError(t, err, "steam is down")
+	require.False(t, jobs.IsPermanent(err))
+}
+
+func TestFailSteamImport(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	fail := failSteamImport(mockRepo)
+
+	ctx := context.Background()
+	task := SteamImportTask{ImportJobID: uuid.New(), AccountID: uuid.New()}
+	running := domain.ImportJob{ID: task.ImportJobID, AccountID: task.AccountID, Status: domain.ImportJobRunning}
+
+	mockRepo.On("GetImportJob", ctx, task.AccountID, task.ImportJobID).Return(running, nil)
+	mockRepo.On("UpdateImportJob", ctx, mock.MatchedBy(func(job domain.ImportJob) bool {
+		return job.ID == running.ID &&
+			job.Status == domain.ImportJobFailed &&
+			job.Error == "unexpected status 503" &&
+			!job.FinishedAt.IsZero()
+	})).Return(domain.ImportJob{}, nil)
+
+	require.NoError(t, fail(ctx, task, errors.New("unexpected status 503")))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSteamImportHandler_MissingJobIsPermanent(t *testing.T) {
+	mockRepo := new(MockImportRepository)
+	handle := steamImportHandler(mockRepo, new(MockImporter))
+
+	ctx := context.Background()
+	task := SteamImportTask{ImportJobID: uuid.New(), AccountID: uuid.New()}
+
+	mockRepo.On("GetImportJob", ctx, task.AccountID, task.ImportJobID).Return(domain.ImportJob{}, domain.ErrImportJobNotFound)
+
+	err := handle(ctx, task)
+	require.ErrorIs(t, err, domain.ErrImportJobNotFound)
+	require.True(t, jobs.IsPermanent(err))
+}
@@ -0,0 +1,154 @@
+package steam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type OwnedGame struct {
+	AppID           int
+	Name            string
+	PlaytimeMinutes int
+	LastPlayedAt    time.Time
+}
+
+type RecentlyPlayedGame struct {
+	AppID                   int
+	Name                    string
+	PlaytimeMinutes         int
+	PlaytimeTwoWeeksMinutes int
+}
+
+type SteamClient interface {
+	GetOwnedGames(ctx context.Context, steamID string) ([]OwnedGame, error)
+	GetRecentlyPlayedGames(ctx context.Context, steamID string) ([]RecentlyPlayedGame, error)
+}
+
+// HTTPClient talks to the Steam Web API. The base URL is configurable so tests
+// can point it at a fake server.
+type HTTPClient struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewHTTPClient(baseURL string, apiKey string, client *http.Client) *HTTPClient {
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+
+	return &HTTPClient{strings.TrimRight(baseURL, "/"), apiKey, client}
+}
+
+type ownedGamesResponse struct {
+	Response struct {
+		Games []struct {
+			AppID           int    `json:"appid"`
+			Name            string `json:"name"`
+			PlaytimeForever int    `json:"playtime_forever"`
+			RTimeLastPlayed int64  `json:"rtime_last_played"`
+		} `json:"games"`
+	} `json:"response"`
+}
+
+func (c *HTTPClient) GetOwnedGames(ctx context.Context, steamID string) ([]OwnedGame, error) {
+	var body ownedGamesResponse
+	err := c.get(ctx, "/IPlayerService/GetOwnedGames/v1/", url.Values{
+		"steamid":                   {steamID},
+		"include_appinfo":           {"1"},
+		"include_played_free_games": {"1"},
+	}, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]OwnedGame, len(body.Response.Games))
+	for i, game := range body.Response.Games {
+		games[i] = OwnedGame{
+			AppID:           game.AppID,
+			Name:            game.Name,
+			PlaytimeMinutes: game.PlaytimeForever,
+		}
+		if game.RTimeLastPlayed > 0 {
+			games[i].LastPlayedAt = time.Unix(game.RTimeLastPlayed, 0).UTC()
+		}
+	}
+
+	return games, nil
+}
+
+type recentlyPlayedGamesResponse struct {
+	Response struct {
+		Games []struct {
+			AppID           int    `json:"appid"`
+			Name            string `json:"name"`
+			Playtime2Weeks  int    `json:"playtime_2weeks"`
+			PlaytimeForever int    `json:"playtime_forever"`
+		} `json:"games"`
+	} `json:"response"`
+}
+
+func (c *HTTPClient) GetRecentlyPlayedGames(ctx context.Context, steamID string) ([]RecentlyPlayedGame, error) {
+	var body recentlyPlayedGamesResponse
+	err := c.get(ctx, "/IPlayerService/GetRecentlyPlayedGames/v1/", url.Values{
+		"steamid": {steamID},
+	}, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	games := make([]RecentlyPlayedGame, len(body.Response.Games))
+	for i, game := range body.Response.Games {
+		games[i] = RecentlyPlayedGame{
+			AppID:                   game.AppID,
+			Name:                    game.Name,
+			PlaytimeMinutes:         game.PlaytimeForever,
+			PlaytimeTwoWeeksMinutes: game.Playtime2Weeks,
+		}
+	}
+
+	return games, nil
+}
+
+func (c *HTTPClient) get(ctx context.Context, path string, query url.Values, v any) error {
+	query.Set("key", c.apiKey)
+	query.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("build steam request: %w", withoutURL(err))
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call steam %s: %w", path, withoutURL(err))
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("call steam %s: unexpected status %d", path, res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode steam %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// withoutURL unwraps the *url.Error the HTTP client returns. Its message
+// quotes the request URL, and with it the API key, which must not reach job
+// errors or logs.
+func withoutURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
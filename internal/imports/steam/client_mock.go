@@ -0,0 +1,25 @@
+package steam
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSteamClient struct {
+	mock.Mock
+}
+
+func NewMockSteamClient() SteamClient {
+	return new(MockSteamClient)
+}
+
+func (m *MockSteamClient) GetOwnedGames(ctx context.Context, steamID string) ([]OwnedGame, error) {
+	args := m.Called(ctx, steamID)
+	return args.Get(0).([]OwnedGame), args.Error(1)
+}
+
+func (m *MockSteamClient) GetRecentlyPlayedGames(ctx context.Context, steamID string) ([]RecentlyPlayedGame, error) {
+	args := m.Called(ctx, steamID)
+	return args.Get(0).([]RecentlyPlayedGame), args.Error(1)
+}
@@ -0,0 +1,92 @@
+package steam
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newFakeSteam(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/IPlayerService/GetOwnedGames/v1/", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "secret", r.URL.Query().Get("key"))
+		require.Equal(t, "76561197960287930", r.URL.Query().Get("steamid"))
+		require.Equal(t, "1", r.URL.Query().Get("include_appinfo"))
+
+		_, _ = w.Write([]byte(`{"response":{"game_count":2,"games":[
+			{"appid":292030,"name":"The Witcher 3: Wild Hunt","playtime_forever":5400,"rtime_last_played":1700000000},
+			{"appid":1145360,"name":"Hades","playtime_forever":0,"rtime_last_played":0}
+		]}}`))
+	})
+	mux.HandleFunc("/IPlayerService/GetRecentlyPlayedGames/v1/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"response":{"total_count":1,"games":[
+			{"appid":292030,"name":"The Witcher 3: Wild Hunt","playtime_2weeks":120,"playtime_forever":5400}
+		]}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestHTTPClient_GetOwnedGames(t *testing.T) {
+	server := newFakeSteam(t)
+	client := NewHTTPClient(server.URL+"/", "secret", server.Client())
+
+	games, err := client.GetOwnedGames(context.Background(), "76561197960287930")
+	require.NoError(t, err)
+	require.Equal(t, []OwnedGame{
+		{
+			AppID:           292030,
+			Name:            "The Witcher 3: Wild Hunt",
+			PlaytimeMinutes: 5400,
+			LastPlayedAt:    time.Unix(1700000000, 0).UTC(),
+		},
+		{AppID: 1145360, Name: "Hades"},
+	}, games)
+}
+
+func TestHTTPClient_GetRecentlyPlayedGames(t *testing.T) {
+	server := newFakeSteam(t)
+	client := NewHTTPClient(server.URL, "secret", server.Client())
+
+	games, err := client.GetRecentlyPlayedGames(context.Background(), "76561197960287930")
+	require.NoError(t, err)
+	require.Equal(t, []RecentlyPlayedGame{
+		{AppID: 292030, Name: "The Witcher 3: Wild Hunt", PlaytimeMinutes: 5400, PlaytimeTwoWeeksMinutes: 120},
+	}, games)
+}
+
+func TestHTTPClient_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewHTTPClient(server.URL, "bad-key", server.Client())
+
+	_, err := client.GetOwnedGames(context.Background(), "76561197960287930")
+	require.ErrorContains(t, err, "unexpected status 403")
+}
+
+func TestHTTPClient_ErrorHidesAPIKey(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	client := NewHTTPClient("http://"+addr, "secret", nil)
+
+	_, err = client.GetOwnedGames(context.Background(), "76561197960287930")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "/IPlayerService/GetOwnedGames/v1/")
+	require.NotContains(t, err.Error(), "secret")
+}
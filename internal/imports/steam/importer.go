@@ -0,0 +1,138 @@
+package steam
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/library"
+)
+
+const platformPC = "pc"
+
+// Importer copies a Steam library into an account. Games are resolved by their
+// Steam appid or title and only created when the catalog lacks them, and every
+// owned game becomes a library entry carrying its playtime. Steam failures are
+// returned so the job runner retries them; a game that fails to import is
+// recorded on its item and does not fail the job.
+type Importer struct {
+	client        SteamClient
+	jobs          domain.ImportRepository
+	games         domain.GameService
+	library       domain.LibraryRepository
+	statusMachine library.StatusMachine
+}
+
+func NewImporter(
+	client SteamClient,
+	jobs domain.ImportRepository,
+	games domain.GameService,
+	libraryRepository domain.LibraryRepository,
+) *Importer {
+	return &Importer{client, jobs, games, libraryRepository, library.NewStatusMachine()}
+}
+
+func (i *Importer) Import(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	// Imports can be retried by the job runner, so progress starts over.
+	job.Status = domain.ImportJobRunning
+	job.ProcessedItems = 0
+	job.FailedItems = 0
+	job.Error = ""
+	job, err := i.jobs.UpdateImportJob(ctx, job)
+	if err != nil {
+		return job, err
+	}
+
+	owned, err := i.client.GetOwnedGames(ctx, job.ExternalAccountID)
+	if err != nil {
+		return job, err
+	}
+
+	recent, err := i.client.GetRecentlyPlayedGames(ctx, job.ExternalAccountID)
+	if err != nil {
+		return job, err
+	}
+
+	recentlyPlayed := make(map[int]bool, len(recent))
+	for _, game := range recent {
+		recentlyPlayed[game.AppID] = true
+	}
+
+	job.TotalItems = len(owned)
+	job, err = i.jobs.UpdateImportJob(ctx, job)
+	if err != nil {
+		return job, err
+	}
+
+	for _, game := range owned {
+		item, err := i.jobs.CreateImportJobItem(ctx, domain.ImportJobItem{
+			JobID:      job.ID,
+			ExternalID: strconv.Itoa(game.AppID),
+			Title:      game.Name,
+		})
+		if err != nil {
+			return job, err
+		}
+
+		gameID, err := i.importGame(ctx, job.AccountID, game, recentlyPlayed[game.AppID])
+		if err != nil {
+			item.Status = domain.ImportJobItemFailed
+			item.Error = err.Error()
+			job.FailedItems++
+		} else {
+			item.Status = domain.ImportJobItemImported
+			item.GameID = gameID
+			item.Error = ""
+		}
+
+		if err := i.jobs.UpdateImportJobItem(ctx, item); err != nil {
+			return job, err
+		}
+
+		job.ProcessedItems++
+		job, err = i.jobs.UpdateImportJob(ctx, job)
+		if err != nil {
+			return job, err
+		}
+	}
+
+	job.Status = domain.ImportJobCompleted
+	job.FinishedAt = time.Now().UTC()
+
+	return i.jobs.UpdateImportJob(ctx, job)
+}
+
+func (i *Importer) importGame(ctx context.Context, accountID uuid.UUID, owned OwnedGame, recentlyPlayed bool) (uuid.UUID, error) {
+	game, err := i.games.ResolveOrCreateGame(ctx, domain.Game{
+		Title:     owned.Name,
+		Platforms: []string{platformPC},
+	}, []domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: strconv.Itoa(owned.AppID)}})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	status := domain.LibraryStatusBacklog
+	if recentlyPlayed {
+		status = domain.LibraryStatusPlaying
+	}
+
+	entry := i.statusMachine.Enter(domain.LibraryEntry{
+		AccountID:       accountID,
+		GameID:          game.ID,
+		Status:          status,
+		PlaytimeMinutes: owned.PlaytimeMinutes,
+		LastPlayedAt:    owned.LastPlayedAt,
+	}, time.Now().UTC())
+
+	events := []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventStatusChanged, To: string(entry.Status)},
+	}
+
+	if _, _, err := i.library.ImportLibraryEntry(ctx, entry, events); err != nil {
+		return uuid.Nil, err
+	}
+
+	return game.ID, nil
+}
@@ -0,0 +1,170 @@
+package steam
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/library"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// jobRecorder keeps the latest state of the job and its items so tests can
+// assert on the progress the importer recorded.
+type jobRecorder struct {
+	domain.ImportRepository
+	job   domain.ImportJob
+	items map[string]domain.ImportJobItem
+}
+
+func newJobRecorder() *jobRecorder {
+	return &jobRecorder{items: make(map[string]domain.ImportJobItem)}
+}
+
+func (r *jobRecorder) UpdateImportJob(ctx context.Context, job domain.ImportJob) (domain.ImportJob, error) {
+	r.job = job
+	return job, nil
+}
+
+// CreateImportJobItem hands back the item already recorded for the external
+// ID, as the repository's upsert does when a job is retried.
+func (r *jobRecorder) CreateImportJobItem(ctx context.Context, item domain.ImportJobItem) (domain.ImportJobItem, error) {
+	if existing, ok := r.items[item.ExternalID]; ok {
+		return existing, nil
+	}
+	item.ID = int64(len(r.items) + 1)
+	r.items[item.ExternalID] = item
+	return item, nil
+}
+
+func (r *jobRecorder) UpdateImportJobItem(ctx context.Context, item domain.ImportJobItem) error {
+	r.items[item.ExternalID] = item
+	return nil
+}
+
+func TestImporter_Import(t *testing.T) {
+	client := new(MockSteamClient)
+	jobs := newJobRecorder()
+	gameService := new(games.MockGameService)
+	libraryRepo := new(library.MockLibraryRepository)
+	importer := NewImporter(client, jobs, gameService, libraryRepo)
+	ctx := context.Background()
+
+	lastPlayed := time.Unix(1700000000, 0).UTC()
+	job := domain.ImportJob{
+		ID:                uuid.New(),
+		AccountID:         uuid.New(),
+		Source:            domain.ImportSourceSteam,
+		ExternalAccountID: "76561197960287930",
+		Status:            domain.ImportJobPending,
+	}
+
+	client.On("GetOwnedGames", ctx, job.ExternalAccountID).Return([]OwnedGame{
+		{AppID: 292030, Name: "The Witcher 3: Wild Hunt", PlaytimeMinutes: 5400, LastPlayedAt: lastPlayed},
+		{AppID: 1145360, Name: "Hades"},
+		{AppID: 10, Name: "Counter-Strike"},
+	}, nil)
+	client.On("GetRecentlyPlayedGames", ctx, job.ExternalAccountID).Return([]RecentlyPlayedGame{
+		{AppID: 292030, Name: "The Witcher 3: Wild Hunt"},
+	}, nil)
+
+	witcher := domain.Game{ID: uuid.New(), Title: "The Witcher 3: Wild Hunt"}
+	hades := domain.Game{ID: uuid.New(), Title: "Hades"}
+	gameService.On("ResolveOrCreateGame", ctx,
+		domain.Game{Title: "The Witcher 3: Wild Hunt", Platforms: []string{"pc"}},
+		[]domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: "292030"}},
+	).Return(witcher, nil)
+	gameService.On("ResolveOrCreateGame", ctx,
+		domain.Game{Title: "Hades", Platforms: []string{"pc"}},
+		[]domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: "1145360"}},
+	).Return(hades, nil)
+	gameService.On("ResolveOrCreateGame", ctx,
+		domain.Game{Title: "Counter-Strike", Platforms: []string{"pc"}},
+		[]domain.ExternalID{{Source: domain.ExternalSourceSteam, ID: "10"}},
+	).Return(domain.Game{}, errors.New("db down"))
+
+	libraryRepo.On("ImportLibraryEntry", ctx, mock.MatchedBy(func(entry domain.LibraryEntry) bool {
+		return entry.GameID == witcher.ID &&
+			entry.Status == domain.LibraryStatusPlaying &&
+			entry.PlaytimeMinutes == 5400 &&
+			entry.LastPlayedAt.Equal(lastPlayed) &&
+			!entry.StartedAt.IsZero()
+	}), mock.Anything).Return(domain.LibraryEntry{}, true, nil)
+	libraryRepo.On("ImportLibraryEntry", ctx, mock.MatchedBy(func(entry domain.LibraryEntry) bool {
+		return entry.GameID == hades.ID && entry.Status == domain.LibraryStatusBacklog
+	}), []domain.LibraryEntryEvent{{Kind: domain.LibraryEntryEventStatusChanged, To: "backlog"}}).Return(domain.LibraryEntry{}, true, nil)
+
+	got, err := importer.Import(ctx, job)
+	require.NoError(t, err)
+	require.Equal(t, domain.ImportJobCompleted, got.Status)
+	require.Equal(t, 3, got.TotalItems)
+	require.Equal(t, 3, got.ProcessedItems)
+	require.Equal(t, 1, got.FailedItems)
+	require.False(t, got.FinishedAt.IsZero())
+
+	require.Equal(t, got, jobs.job)
+
+	require.Equal(t, domain.ImportJobItemImported, jobs.items["292030"].Status)
+	require.Equal(t, witcher.ID, jobs.items["292030"].GameID)
+	require.Equal(t, domain.ImportJobItemImported, jobs.items["1145360"].Status)
+	require.Equal(t, hades.ID, jobs.items["1145360"].GameID)
+	require.Equal(t, domain.ImportJobItemFailed, jobs.items["10"].Status)
+	require.Equal(t, "db down", jobs.items["10"].Error)
+
+	gameService.AssertExpectations(t)
+	libraryRepo.AssertExpectations(t)
+}
+
+func TestImporter_Import_SteamFailure(t *testing.T) {
+	client := new(MockSteamClient)
+	jobs := newJobRecorder()
+	importer := NewImporter(client, jobs, new(games.MockGameService), new(library.MockLibraryRepository))
+	ctx := context.Background()
+
+	job := domain.ImportJob{ID: uuid.New(), ExternalAccountID: "76561197960287930"}
+
+	client.On("GetOwnedGames", ctx, job.ExternalAccountID).Return([]OwnedGame(nil), errors.New("unexpected status 403"))
+
+	// The error goes back to the job runner so it can retry, and the import
+	// stays running until the runner gives up on it.
+	got, err := importer.Import(ctx, job)
+	require.EqualError(t, err, "unexpected status 403")
+	require.Equal(t, domain.ImportJobRunning, got.Status)
+	require.True(t, got.FinishedAt.IsZero())
+	require.Empty(t, jobs.items)
+}
+
+func TestImporter_Import_RetryClearsItemError(t *testing.T) {
+	client := new(MockSteamClient)
+	jobs := newJobRecorder()
+	gameService := new(games.MockGameService)
+	libraryRepo := new(library.MockLibraryRepository)
+	importer := NewImporter(client, jobs, gameService, libraryRepo)
+	ctx := context.Background()
+
+	job := domain.ImportJob{ID: uuid.New(), AccountID: uuid.New(), ExternalAccountID: "76561197960287930"}
+
+	client.On("GetOwnedGames", ctx, job.ExternalAccountID).Return([]OwnedGame{{AppID: 1145360, Name: "Hades"}}, nil)
+	client.On("GetRecentlyPlayedGames", ctx, job.ExternalAccountID).Return([]RecentlyPlayedGame(nil), nil)
+
+	hades := domain.Game{ID: uuid.New(), Title: "Hades"}
+	gameService.On("ResolveOrCreateGame", ctx, mock.Anything, mock.Anything).Return(domain.Game{}, errors.New("db down")).Once()
+	gameService.On("ResolveOrCreateGame", ctx, mock.Anything, mock.Anything).Return(hades, nil).Once()
+	libraryRepo.On("ImportLibraryEntry", ctx, mock.Anything, mock.Anything).Return(domain.LibraryEntry{}, true, nil)
+
+	_, err := importer.Import(ctx, job)
+	require.NoError(t, err)
+	require.Equal(t, domain.ImportJobItemFailed, jobs.items["1145360"].Status)
+	require.Equal(t, "db down", jobs.items["1145360"].Error)
+
+	_, err = importer.Import(ctx, job)
+	require.NoError(t, err)
+	require.Equal(t, domain.ImportJobItemImported, jobs.items["1145360"].Status)
+	require.Equal(t, hades.ID, jobs.items["1145360"].GameID)
+	require.Empty(t, jobs.items["1145360"].Error)
+}
@@ -0,0 +1,134 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+type repository struct {
+	db *sqlc.Queries
+}
+
+func NewRepository(q *sqlc.Queries) domain.JobRepository {
+	return &repository{q}
+}
+
+func (r *repository) EnqueueJob(ctx context.Context, job domain.Job) (domain.Job, error) {
+	created, err := r.db.EnqueueJob(ctx, sqlc.EnqueueJobParams{
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		MaxAttempts: int32(job.MaxAttempts),
+		RunAt:       timestamp(job.RunAt),
+	})
+	if err != nil {
+		return domain.Job{}, postgres.TranslateError(err, nil)
+	}
+
+	return toDomainJob(created), nil
+}
+
+func (r *repository) GetJob(ctx context.Context, id int64) (domain.Job, error) {
+	job, err := r.db.GetJob(ctx, id)
+	if err != nil {
+		return domain.Job{}, postgres.TranslateError(err, domain.ErrJobNotFound)
+	}
+
+	return toDomainJob(job), nil
+}
+
+func (r *repository) ClaimJob(ctx context.Context, staleBefore time.Time) (domain.Job, error) {
+	job, err := r.db.ClaimJob(ctx, timestamp(staleBefore))
+	if err != nil {
+		return domain.Job{}, postgres.TranslateError(err, domain.ErrNoJobAvailable)
+	}
+
+	return toDomainJob(job), nil
+}
+
+func (r *repository) ExtendJobLock(ctx context.Context, id int64, attempt int) error {
+	rows, err := r.db.ExtendJobLock(ctx, sqlc.ExtendJobLockParams{
+		ID:       id,
+		Attempts: int32(attempt),
+	})
+	return claimed(rows, err)
+}
+
+func (r *repository) CompleteJob(ctx context.Context, id int64, attempt int) error {
+	rows, err := r.db.CompleteJob(ctx, sqlc.CompleteJobParams{
+		ID:       id,
+		Attempts: int32(attempt),
+	})
+	return claimed(rows, err)
+}
+
+func (r *repository) RetryJob(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error {
+	rows, err := r.db.RetryJob(ctx, sqlc.RetryJobParams{
+		ID:        id,
+		RunAt:     timestamp(runAt),
+		LastError: lastError,
+		Attempts:  int32(attempt),
+	})
+	return claimed(rows, err)
+}
+
+func (r *repository) KillJob(ctx context.Context, id int64, attempt int, lastError string) error {
+	rows, err := r.db.KillJob(ctx, sqlc.KillJobParams{
+		ID:        id,
+		LastError: lastError,
+		Attempts:  int32(attempt),
+	})
+	return claimed(rows, err)
+}
+
+// claimed turns an update that matched no row into ErrJobLost: the job is no
+// longer held by the claim the update was made for.
+func claimed(rows int64, err error) error {
+	if err != nil {
+		return postgres.TranslateError(err, nil)
+	}
+	if rows == 0 {
+		return domain.ErrJobLost
+	}
+	return nil
+}
+
+func (r *repository) CountJobs(ctx context.Context) (map[domain.JobStatus]int, error) {
+	rows, err := r.db.CountJobsByStatus(ctx)
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	counts := make(map[domain.JobStatus]int, len(rows))
+	for _, row := range rows {
+		counts[domain.JobStatus(row.Status)] = int(row.Count)
+	}
+
+	return counts, nil
+}
+
+func timestamp(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
+
+func toDomainJob(job sqlc.Job) domain.Job {
+	return domain.Job{
+		ID:          job.ID,
+		Kind:        job.Kind,
+		Payload:     job.Payload,
+		Status:      domain.JobStatus(job.Status),
+		Attempts:    int(job.Attempts),
+		MaxAttempts: int(job.MaxAttempts),
+		RunAt:       job.RunAt.Time,
+		LockedAt:    job.LockedAt.Time,
+		LastError:   job.LastError,
+		TimeStamps: domain.TimeStamps{
+			InsertedAt: job.InsertedAt.Time,
+			UpdatedAt:  job.UpdatedAt.Time,
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJobRepository struct {
+	mock.Mock
+}
+
+func NewMockJobRepository() domain.JobRepository {
+	return new(MockJobRepository)
+}
+
+func (m *MockJobRepository) EnqueueJob(ctx context.Context, job domain.Job) (domain.Job, error) {
+	args := m.Called(ctx, job)
+	return args.Get(0).(domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) GetJob(ctx context.Context, id int64) (domain.Job, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) ClaimJob(ctx context.Context, staleBefore time.Time) (domain.Job, error) {
+	args := m.Called(ctx, staleBefore)
+	return args.Get(0).(domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) ExtendJobLock(ctx context.Context, id int64, attempt int) error {
+	args := m.Called(ctx, id, attempt)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) CompleteJob(ctx context.Context, id int64, attempt int) error {
+	args := m.Called(ctx, id, attempt)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) RetryJob(ctx context.Context, id int64, attempt int, runAt time.Time, lastError string) error {
+	args := m.Called(ctx, id, attempt, runAt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) KillJob(ctx context.Context, id int64, attempt int, lastError string) error {
+	args := m.Called(ctx, id, attempt, lastError)
+	return args.Error(0)
+}
+
+type MockJobQueue struct {
+	mock.Mock
+}
+
+func (m *MockJobQueue) Enqueue(ctx context.Context, kind string, payload any) (domain.Job, error) {
+	args := m.Called(ctx, kind, payload)
+	return args.Get(0).(domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) CountJobs(ctx context.Context) (map[domain.JobStatus]int, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(map[domain.JobStatus]int), args.Error(1)
+}
@@ -0,0 +1,151 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/testutils"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+	"github.com/stretchr/testify/require"
+)
+
+var testQueries *sqlc.Queries
+var testDB *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dsn, terminate, err := testutils.StartPostgresContainer(ctx)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	testDB = postgres.MustConnect(ctx, dsn, nil)
+	gooseProvider := migrations.MustProvide(testDB)
+	testQueries = sqlc.New(testDB)
+
+	_, err = gooseProvider.Up(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	exitCode := m.Run()
+
+	if err := terminate(context.Background()); err != nil {
+		log.Println(err)
+	}
+
+	os.Exit(exitCode)
+}
+
+func TestRepository_ClaimLifecycle(t *testing.T) {
+	repo := NewRepository(testQueries)
+	ctx := context.Background()
+	staleBefore := time.Now().Add(-time.Hour)
+
+	job, err := repo.EnqueueJob(ctx, domain.Job{
+		Kind:        "test.lifecycle",
+		Payload:     []byte(`{"name":"Geralt"}`),
+		MaxAttempts: 3,
+		RunAt:       time.Now().Add(-time.Second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, domain.JobQueued, job.Status)
+
+	claimed, err := repo.ClaimJob(ctx, staleBefore)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, claimed.ID)
+	require.Equal(t, domain.JobRunning, claimed.Status)
+	require.Equal(t, 1, claimed.Attempts)
+	require.JSONEq(t, `{"name":"Geralt"}`, string(claimed.Payload))
+
+	_, err = repo.ClaimJob(ctx, staleBefore)
+	require.ErrorIs(t, err, domain.ErrNoJobAvailable)
+
+	require.NoError(t, repo.ExtendJobLock(ctx, job.ID, 1))
+	require.NoError(t, repo.RetryJob(ctx, job.ID, 1, time.Now().Add(time.Hour), "later"))
+	_, err = repo.ClaimJob(ctx, staleBefore)
+	require.ErrorIs(t, err, domain.ErrNoJobAvailable)
+
+	// Only the claim that is running the job may finish it.
+	require.ErrorIs(t, repo.RetryJob(ctx, job.ID, 1, time.Now(), "again"), domain.ErrJobLost)
+
+	_, err = testDB.Exec(ctx, "UPDATE jobs SET run_at = now() - interval '1 second' WHERE id = $1", job.ID)
+	require.NoError(t, err)
+	claimed, err = repo.ClaimJob(ctx, staleBefore)
+	require.NoError(t, err)
+	require.Equal(t, 2, claimed.Attempts)
+	require.Equal(t, "later", claimed.LastError)
+
+	require.ErrorIs(t, repo.CompleteJob(ctx, job.ID, 1), domain.ErrJobLost)
+	require.ErrorIs(t, repo.ExtendJobLock(ctx, job.ID, 1), domain.ErrJobLost)
+	require.NoError(t, repo.CompleteJob(ctx, job.ID, 2))
+	got, err := repo.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.JobSucceeded, got.Status)
+	require.True(t, got.LockedAt.IsZero())
+	require.Empty(t, got.LastError)
+}
+
+func TestRepository_ClaimJob_SkipsLockedRows(t *testing.T) {
+	repo := NewRepository(testQueries)
+	ctx := context.Background()
+	staleBefore := time.Now().Add(-time.Hour)
+
+	for range 2 {
+		_, err := repo.EnqueueJob(ctx, domain.Job{Kind: "test.concurrent", Payload: []byte(`{}`), MaxAttempts: 1, RunAt: time.Now()})
+		require.NoError(t, err)
+	}
+
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	first, err := NewRepository(testQueries.WithTx(tx)).ClaimJob(ctx, staleBefore)
+	require.NoError(t, err)
+
+	second, err := repo.ClaimJob(ctx, staleBefore)
+	require.NoError(t, err)
+	require.NotEqual(t, first.ID, second.ID)
+
+	require.NoError(t, repo.KillJob(ctx, second.ID, second.Attempts, "gave up"))
+	dead, err := repo.GetJob(ctx, second.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.JobDead, dead.Status)
+	require.Equal(t, "gave up", dead.LastError)
+
+	// Rolling back undoes the first claim, so it can no longer finish the job.
+	require.NoError(t, tx.Rollback(ctx))
+	require.ErrorIs(t, repo.CompleteJob(ctx, first.ID, first.Attempts), domain.ErrJobLost)
+}
+
+func TestRepository_ClaimJob_ReclaimsStaleLocks(t *testing.T) {
+	repo := NewRepository(testQueries)
+	ctx := context.Background()
+
+	job, err := repo.EnqueueJob(ctx, domain.Job{Kind: "test.stale", Payload: []byte(`{}`), MaxAttempts: 3, RunAt: time.Now()})
+	require.NoError(t, err)
+
+	_, err = testDB.Exec(ctx, "UPDATE jobs SET status = 'running', locked_at = now() - interval '1 hour' WHERE id = $1", job.ID)
+	require.NoError(t, err)
+
+	_, err = repo.ClaimJob(ctx, time.Now().Add(-2*time.Hour))
+	require.ErrorIs(t, err, domain.ErrNoJobAvailable)
+
+	reclaimed, err := repo.ClaimJob(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, job.ID, reclaimed.ID)
+}
+
+func TestRepository_GetJob_NotFound(t *testing.T) {
+	_, err := NewRepository(testQueries).GetJob(context.Background(), -1)
+	require.ErrorIs(t, err, domain.ErrJobNotFound)
+}
@@ -0,0 +1,488 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+type Options struct {
+	Workers      int
+	PollInterval time.Duration
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// LockTimeout is how long a running job's lock lasts before another
+	// worker assumes its owner died and claims it again. Owners extend the
+	// lock while the job runs.
+	LockTimeout time.Duration
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Workers:      2,
+		PollInterval: time.Second,
+		MaxAttempts:  5,
+		BaseBackoff:  5 * time.Second,
+		MaxBackoff:   10 * time.Minute,
+		LockTimeout:  15 * time.Minute,
+	}
+}
+
+func (o Options) withDefaults() Options {
+	defaults := DefaultOptions()
+	if o.Workers <= 0 {
+		o.Workers = defaults.Workers
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaults.PollInterval
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = defaults.MaxAttempts
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = defaults.BaseBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaults.MaxBackoff
+	}
+	if o.LockTimeout <= 0 {
+		o.LockTimeout = defaults.LockTimeout
+	}
+	return o
+}
+
+// handler runs jobs of one kind. dead, when set, is told about jobs that
+// failed for good so the work they stood for can be marked as failed.
+type handler struct {
+	run  func(ctx context.Context, payload []byte) error
+	dead func(ctx context.Context, payload []byte, cause error) error
+}
+
+// Runner polls the jobs table and dispatches each claimed job to the handler
+// registered for its kind. Failed jobs are retried with exponential backoff
+// until they run out of attempts, then left in the dead state for inspection
+// and handed to the kind's dead-letter hook, if any.
+type Runner struct {
+	repository domain.JobRepository
+	logger     *slog.Logger
+	options    Options
+
+	mu       sync.RWMutex
+	handlers map[string]handler
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// jobCtx is handed to claimed jobs. It outlives the polling context so a
+	// job isn't cut off by Stop unless Stop runs out of time.
+	jobCtx context.Context
+	abort  context.CancelFunc
+
+	// heartbeats holds when each worker last polled, indexed by worker.
+	beatMu     sync.Mutex
+	heartbeats []time.Time
+
+	failures   *prometheus.CounterVec
+	queueDepth *prometheus.Desc
+}
+
+func NewRunner(repository domain.JobRepository, logger *slog.Logger, options Options) *Runner {
+	return &Runner{
+		repository: repository,
+		logger:     logger,
+		options:    options.withDefaults(),
+		handlers:   make(map[string]handler),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "nerd_backlog",
+			Name:      "job_failures_total",
+			Help:      "Failed job attempts, by kind and whether the job was retried or is dead.",
+		}, []string{"kind", "outcome"}),
+		queueDepth: prometheus.NewDesc(
+			"nerd_backlog_jobs",
+			"Jobs in the queue, by status.",
+			[]string{"status"}, nil,
+		),
+	}
+}
+
+// Register binds handle to kind. Payloads are decoded into T before the
+// handler runs; a payload that does not decode kills the job right away.
+func Register[T any](r *Runner, kind string, handle func(ctx context.Context, payload T) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h := r.handlers[kind]
+	h.run = func(ctx context.Context, raw []byte) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return Permanent(fmt.Errorf("decode %s payload: %w", kind, err))
+		}
+
+		return handle(ctx, payload)
+	}
+	r.handlers[kind] = h
+}
+
+// OnDead registers a dead-letter hook for kind. It runs once a job of that
+// kind is killed, either because it failed permanently or because it ran out
+// of attempts, with the error of the last attempt.
+func OnDead[T any](r *Runner, kind string, hook func(ctx context.Context, payload T, cause error) error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h := r.handlers[kind]
+	h.dead = func(ctx context.Context, raw []byte, cause error) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return fmt.Errorf("decode %s payload: %w", kind, err)
+		}
+
+		return hook(ctx, payload, cause)
+	}
+	r.handlers[kind] = h
+}
+
+func (r *Runner) Enqueue(ctx context.Context, kind string, payload any) (domain.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("encode %s payload: %w", kind, err)
+	}
+
+	return r.repository.EnqueueJob(ctx, domain.Job{
+		Kind:        kind,
+		Payload:     raw,
+		MaxAttempts: r.options.MaxAttempts,
+		RunAt:       time.Now().UTC(),
+	})
+}
+
+// Start launches the workers. They keep polling until Stop is called or ctx
+// is cancelled.
+func (r *Runner) Start(ctx context.Context) {
+	r.jobCtx, r.abort = context.WithCancel(context.WithoutCancel(ctx))
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.beatMu.Lock()
+	r.heartbeats = make([]time.Time, r.options.Workers)
+	for worker := range r.heartbeats {
+		r.heartbeats[worker] = time.Now()
+	}
+	r.beatMu.Unlock()
+
+	for worker := range r.options.Workers {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.work(ctx, worker)
+		}()
+	}
+
+	r.logger.Info("job runner started", "workers", r.options.Workers)
+}
+
+// abortGrace is how long jobs cancelled by Stop get to record their outcome.
+const abortGrace = 5 * time.Second
+
+// Stop stops claiming new jobs and waits for the ones in flight to finish.
+// When ctx ends first, in-flight jobs are cancelled and put back in the queue,
+// and Stop gives up on any that don't return within abortGrace; their lock
+// goes stale and another worker picks them up.
+func (r *Runner) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		r.abort()
+		r.logger.Info("job runner stopped")
+		return nil
+	case <-ctx.Done():
+	}
+
+	r.logger.Warn("cancelling in-flight jobs", "err", ctx.Err())
+	r.abort()
+
+	select {
+	case <-stopped:
+		r.logger.Info("job runner stopped")
+		return nil
+	case <-time.After(abortGrace):
+		return fmt.Errorf("jobs still running after cancel: %w", ctx.Err())
+	}
+}
+
+func (r *Runner) work(ctx context.Context, worker int) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		r.beat(worker)
+
+		if r.runNext(ctx) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.options.PollInterval):
+		}
+	}
+}
+
+func (r *Runner) beat(worker int) {
+	r.beatMu.Lock()
+	defer r.beatMu.Unlock()
+	r.heartbeats[worker] = time.Now()
+}
+
+// Heartbeats returns when each worker last polled for jobs, or nil before
+// Start.
+func (r *Runner) Heartbeats() []time.Time {
+	r.beatMu.Lock()
+	defer r.beatMu.Unlock()
+	return slices.Clone(r.heartbeats)
+}
+
+// CheckHeartbeats fails when the runner isn't started or a worker has been
+// silent for longer than a job may hold its lock. A worker only beats between
+// jobs, so a shorter limit would flag long imports.
+func (r *Runner) CheckHeartbeats(ctx context.Context) error {
+	heartbeats := r.Heartbeats()
+	if len(heartbeats) == 0 {
+		return errors.New("job runner not started")
+	}
+
+	limit := r.options.LockTimeout + r.options.PollInterval
+	for worker, beat := range heartbeats {
+		if silent := time.Since(beat); silent > limit {
+			return fmt.Errorf("worker %d silent for %s", worker, silent.Round(time.Second))
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) runNext(ctx context.Context) bool {
+	staleBefore := time.Now().UTC().Add(-r.options.LockTimeout)
+	job, err := r.repository.ClaimJob(ctx, staleBefore)
+	if errors.Is(err, domain.ErrNoJobAvailable) {
+		return false
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.Error("failed to claim job", "err", err)
+		}
+		return false
+	}
+
+	// A claimed job keeps running when the runner starts stopping, so its
+	// outcome is recorded instead of waiting for the lock to go stale.
+	r.process(r.jobCtx, job)
+	return true
+}
+
+func (r *Runner) process(ctx context.Context, job domain.Job) {
+	logger := r.logger.With("job_id", job.ID, "kind", job.Kind, "attempt", job.Attempts)
+
+	handlerCtx, cancel := context.WithCancel(ctx)
+	release := r.holdLock(handlerCtx, job, cancel, logger)
+	err := r.handle(handlerCtx, job)
+	lost := release()
+	cancel()
+
+	if lost {
+		logger.Warn("job lost its lock to another worker, dropping its outcome", "err", err)
+		return
+	}
+
+	// Outcomes are recorded even when Stop cancelled the job.
+	aborted := ctx.Err() != nil
+	ctx = context.WithoutCancel(ctx)
+
+	switch {
+	case err != nil && aborted:
+		logger.Warn("job interrupted by shutdown, requeueing", "err", err)
+		if err := r.repository.RetryJob(ctx, job.ID, job.Attempts, time.Now().UTC(), err.Error()); err != nil {
+			logger.Error("failed to requeue job", "err", err)
+		}
+	case err == nil:
+		if err := r.repository.CompleteJob(ctx, job.ID, job.Attempts); err != nil {
+			logger.Error("failed to complete job", "err", err)
+		}
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		logger.Error("job is dead", "err", err)
+		r.failures.WithLabelValues(job.Kind, "dead").Inc()
+		// When another worker owns the job by now, its attempt decides.
+		if killErr := r.repository.KillJob(ctx, job.ID, job.Attempts, err.Error()); errors.Is(killErr, domain.ErrJobLost) {
+			logger.Warn("job claimed by another worker before it could be killed")
+			return
+		} else if killErr != nil {
+			logger.Error("failed to kill job", "err", killErr)
+		}
+		if deadErr := r.deadLetter(ctx, job, err); deadErr != nil {
+			logger.Error("dead-letter hook failed", "err", deadErr)
+		}
+	default:
+		runAt := time.Now().UTC().Add(r.backoff(job.Attempts))
+		logger.Warn("job failed, retrying", "err", err, "run_at", runAt)
+		r.failures.WithLabelValues(job.Kind, "retried").Inc()
+		if err := r.repository.RetryJob(ctx, job.ID, job.Attempts, runAt, err.Error()); err != nil {
+			logger.Error("failed to reschedule job", "err", err)
+		}
+	}
+}
+
+// holdLock extends the job's lock every third of LockTimeout while its
+// handler runs, so a long job isn't claimed again by another worker. When the
+// lock was lost anyway, cancel stops the handler. The returned release stops
+// extending and reports whether the lock was lost.
+func (r *Runner) holdLock(ctx context.Context, job domain.Job, cancel context.CancelFunc, logger *slog.Logger) (release func() bool) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	var lost bool
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(r.options.LockTimeout / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			err := r.repository.ExtendJobLock(context.WithoutCancel(ctx), job.ID, job.Attempts)
+			if errors.Is(err, domain.ErrJobLost) {
+				lost = true
+				cancel()
+				return
+			}
+			if err != nil {
+				logger.Error("failed to extend job lock", "err", err)
+			}
+		}
+	}()
+
+	return func() bool {
+		close(done)
+		<-stopped
+		return lost
+	}
+}
+
+func (r *Runner) handle(ctx context.Context, job domain.Job) (err error) {
+	r.mu.RLock()
+	h, ok := r.handlers[job.Kind]
+	r.mu.RUnlock()
+
+	if !ok || h.run == nil {
+		return Permanent(fmt.Errorf("no handler registered for kind %q", job.Kind))
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+	}()
+
+	return h.run(ctx, job.Payload)
+}
+
+func (r *Runner) deadLetter(ctx context.Context, job domain.Job, cause error) (err error) {
+	r.mu.RLock()
+	h := r.handlers[job.Kind]
+	r.mu.RUnlock()
+
+	if h.dead == nil {
+		return nil
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("dead-letter hook panicked: %v", recovered)
+		}
+	}()
+
+	return h.dead(ctx, job.Payload, cause)
+}
+
+// backoff doubles the delay after every failed attempt, capped at MaxBackoff.
+func (r *Runner) backoff(attempts int) time.Duration {
+	delay := r.options.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= r.options.MaxBackoff {
+			return r.options.MaxBackoff
+		}
+	}
+
+	return min(delay, r.options.MaxBackoff)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks err as not worth retrying.
+func Permanent(err error) error {
+	return permanentError{err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
+
+// Describe and Collect make the runner a Prometheus collector of its failure
+// counts and the queue depth, which is counted on each scrape.
+func (r *Runner) Describe(ch chan<- *prometheus.Desc) {
+	r.failures.Describe(ch)
+	ch <- r.queueDepth
+}
+
+func (r *Runner) Collect(ch chan<- prometheus.Metric) {
+	r.failures.Collect(ch)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	counts, err := r.repository.CountJobs(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(r.queueDepth, err)
+		return
+	}
+
+	for _, status := range []domain.JobStatus{domain.JobQueued, domain.JobRunning, domain.JobSucceeded, domain.JobDead} {
+		ch <- prometheus.MustNewConstMetric(r.queueDepth, prometheus.GaugeValue, float64(counts[status]), string(status))
+	}
+}
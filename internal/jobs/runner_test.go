@@ -0,0 +1,491 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository is a minimal in-process queue so the runner can be
+// exercised end to end without Postgres.
+type memoryRepository struct {
+	mu     sync.Mutex
+	nextID int64
+	jobs   map[int64]*domain.Job
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{jobs: make(map[int64]*domain.Job)}
+}
+
+func (m *memoryRepository) EnqueueJob(_ context.Context, job domain.Job) (domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	job.ID = m.nextID
+	job.Status = domain.JobQueued
+	m.jobs[job.ID] = &job
+	return job, nil
+}
+
+func (m *memoryRepository) GetJob(_ context.Context, id int64) (domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return domain.Job{}, domain.ErrJobNotFound
+	}
+	return *job, nil
+}
+
+func (m *memoryRepository) ClaimJob(_ context.Context, _ time.Time) (domain.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id := int64(1); id <= m.nextID; id++ {
+		job := m.jobs[id]
+		if job.Status == domain.JobQueued && !job.RunAt.After(time.Now()) {
+			job.Status = domain.JobRunning
+			job.Attempts++
+			return *job, nil
+		}
+	}
+	return domain.Job{}, domain.ErrNoJobAvailable
+}
+
+// held returns the job when the claim that made attempt still holds it.
+func (m *memoryRepository) held(id int64, attempt int) (*domain.Job, error) {
+	job := m.jobs[id]
+	if job.Status != domain.JobRunning || job.Attempts != attempt {
+		return nil, domain.ErrJobLost
+	}
+	return job, nil
+}
+
+func (m *memoryRepository) ExtendJobLock(_ context.Context, id int64, attempt int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, err := m.held(id, attempt)
+	if err != nil {
+		return err
+	}
+	job.LockedAt = time.Now()
+	return nil
+}
+
+func (m *memoryRepository) CompleteJob(_ context.Context, id int64, attempt int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, err := m.held(id, attempt)
+	if err != nil {
+		return err
+	}
+	job.Status = domain.JobSucceeded
+	return nil
+}
+
+func (m *memoryRepository) RetryJob(_ context.Context, id int64, attempt int, runAt time.Time, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, err := m.held(id, attempt)
+	if err != nil {
+		return err
+	}
+	job.Status = domain.JobQueued
+	job.RunAt = runAt
+	job.LastError = lastError
+	return nil
+}
+
+func (m *memoryRepository) CountJobs(_ context.Context) (map[domain.JobStatus]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counts := make(map[domain.JobStatus]int)
+	for _, job := range m.jobs {
+		counts[job.Status]++
+	}
+	return counts, nil
+}
+
+func (m *memoryRepository) KillJob(_ context.Context, id int64, attempt int, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, err := m.held(id, attempt)
+	if err != nil {
+		return err
+	}
+	job.Status = domain.JobDead
+	job.LastError = lastError
+	return nil
+}
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+func newTestRunner(repo domain.JobRepository) *Runner {
+	return NewRunner(repo, slog.New(slog.DiscardHandler), Options{
+		Workers:      2,
+		PollInterval: time.Millisecond,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Millisecond,
+		MaxBackoff:   5 * time.Millisecond,
+	})
+}
+
+func stop(t *testing.T, runner *Runner) {
+	t.Helper()
+	require.NoError(t, runner.Stop(context.Background()))
+}
+
+func waitForStatus(t *testing.T, repo *memoryRepository, id int64, status domain.JobStatus) domain.Job {
+	t.Helper()
+
+	var job domain.Job
+	require.Eventually(t, func() bool {
+		job, _ = repo.GetJob(context.Background(), id)
+		return job.Status == status
+	}, 2*time.Second, time.Millisecond)
+
+	return job
+}
+
+func TestRunner_RunsRegisteredHandler(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	greeted := make(chan string, 1)
+	Register(runner, "greet", func(_ context.Context, payload greeting) error {
+		greeted <- payload.Name
+		return nil
+	})
+
+	runner.Start(context.Background())
+	defer stop(t, runner)
+
+	job, err := runner.Enqueue(context.Background(), "greet", greeting{Name: "Geralt"})
+	require.NoError(t, err)
+
+	require.Equal(t, "Geralt", <-greeted)
+	done := waitForStatus(t, repo, job.ID, domain.JobSucceeded)
+	require.Equal(t, 1, done.Attempts)
+}
+
+func TestRunner_RetriesThenSucceeds(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	var mu sync.Mutex
+	calls := 0
+	Register(runner, "flaky", func(context.Context, greeting) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		calls++
+		if calls < 3 {
+			return errors.New("try again")
+		}
+		return nil
+	})
+
+	job, err := runner.Enqueue(context.Background(), "flaky", greeting{})
+	require.NoError(t, err)
+
+	runner.Start(context.Background())
+	defer stop(t, runner)
+
+	done := waitForStatus(t, repo, job.ID, domain.JobSucceeded)
+	require.Equal(t, 3, done.Attempts)
+}
+
+func TestRunner_DeadAfterMaxAttempts(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	Register(runner, "broken", func(context.Context, greeting) error {
+		return errors.New("still broken")
+	})
+
+	job, err := runner.Enqueue(context.Background(), "broken", greeting{})
+	require.NoError(t, err)
+
+	runner.Start(context.Background())
+	defer stop(t, runner)
+
+	dead := waitForStatus(t, repo, job.ID, domain.JobDead)
+	require.Equal(t, 3, dead.Attempts)
+	require.Equal(t, "still broken", dead.LastError)
+}
+
+func TestRunner_DeadLetterHook(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	var mu sync.Mutex
+	attempts := 0
+	Register(runner, "broken", func(context.Context, greeting) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		return errors.New("still broken")
+	})
+
+	type dead struct {
+		payload greeting
+		cause   error
+	}
+	buried := make(chan dead, 1)
+	OnDead(runner, "broken", func(_ context.Context, payload greeting, cause error) error {
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, 3, attempts, "hook ran before the last attempt")
+		buried <- dead{payload, cause}
+		return nil
+	})
+
+	job, err := runner.Enqueue(context.Background(), "broken", greeting{Name: "Ciri"})
+	require.NoError(t, err)
+
+	runner.Start(context.Background())
+	defer stop(t, runner)
+
+	got := <-buried
+	require.Equal(t, "Ciri", got.payload.Name)
+	require.EqualError(t, got.cause, "still broken")
+	waitForStatus(t, repo, job.ID, domain.JobDead)
+}
+
+func TestRunner_PermanentFailures(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	Register(runner, "greet", func(context.Context, greeting) error { return nil })
+	Register(runner, "refuse", func(context.Context, greeting) error {
+		return Permanent(errors.New("nope"))
+	})
+	Register(runner, "panic", func(context.Context, greeting) error {
+		panic("boom")
+	})
+
+	ctx := context.Background()
+	refused, err := runner.Enqueue(ctx, "refuse", greeting{})
+	require.NoError(t, err)
+	unknown, err := runner.Enqueue(ctx, "unknown", greeting{})
+	require.NoError(t, err)
+	malformed, err := repo.EnqueueJob(ctx, domain.Job{Kind: "greet", Payload: []byte(`"not an object"`), MaxAttempts: 3})
+	require.NoError(t, err)
+	panicked, err := runner.Enqueue(ctx, "panic", greeting{})
+	require.NoError(t, err)
+
+	runner.Start(ctx)
+	defer stop(t, runner)
+
+	require.Equal(t, 1, waitForStatus(t, repo, refused.ID, domain.JobDead).Attempts)
+	require.Contains(t, waitForStatus(t, repo, unknown.ID, domain.JobDead).LastError, "no handler registered")
+	require.Equal(t, 1, waitForStatus(t, repo, malformed.ID, domain.JobDead).Attempts)
+	require.Contains(t, waitForStatus(t, repo, panicked.ID, domain.JobDead).LastError, "boom")
+}
+
+func TestRunner_StopWaitsForInFlightJobs(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	Register(runner, "slow", func(ctx context.Context, _ greeting) error {
+		close(started)
+		<-release
+		return ctx.Err()
+	})
+
+	job, err := runner.Enqueue(context.Background(), "slow", greeting{})
+	require.NoError(t, err)
+
+	runner.Start(context.Background())
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		stop(t, runner)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("runner stopped before the job finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+
+	got, err := repo.GetJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.JobSucceeded, got.Status)
+}
+
+func TestRunner_StopCancelsJobsPastDeadline(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	started := make(chan struct{})
+	Register(runner, "stuck", func(ctx context.Context, _ greeting) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	job, err := runner.Enqueue(context.Background(), "stuck", greeting{})
+	require.NoError(t, err)
+
+	runner.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.NoError(t, runner.Stop(ctx))
+
+	// The interrupted job goes back in the queue for the next process.
+	got, err := repo.GetJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.JobQueued, got.Status)
+	require.Equal(t, context.Canceled.Error(), got.LastError)
+}
+
+func TestRunner_HoldsLockUntilLost(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := NewRunner(repo, slog.New(slog.DiscardHandler), Options{
+		Workers:      1,
+		PollInterval: time.Millisecond,
+		LockTimeout:  15 * time.Millisecond,
+	})
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	Register(runner, "slow", func(ctx context.Context, _ greeting) error {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+
+	job, err := runner.Enqueue(context.Background(), "slow", greeting{})
+	require.NoError(t, err)
+
+	runner.Start(context.Background())
+	defer stop(t, runner)
+	<-started
+
+	// The running job keeps its lock fresh.
+	require.Eventually(t, func() bool {
+		got, _ := repo.GetJob(context.Background(), job.ID)
+		return !got.LockedAt.IsZero()
+	}, time.Second, time.Millisecond)
+
+	// Another worker claims it: the first one stops and leaves the outcome to
+	// the new claim.
+	repo.mu.Lock()
+	repo.jobs[job.ID].Attempts++
+	repo.mu.Unlock()
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("handler kept running after losing its lock")
+	}
+
+	require.Never(t, func() bool {
+		got, _ := repo.GetJob(context.Background(), job.ID)
+		return got.Status != domain.JobRunning || got.LastError != ""
+	}, 50*time.Millisecond, time.Millisecond)
+}
+
+func TestRunner_Backoff(t *testing.T) {
+	runner := NewRunner(newMemoryRepository(), slog.New(slog.DiscardHandler), Options{
+		BaseBackoff: time.Second,
+		MaxBackoff:  10 * time.Second,
+	})
+
+	require.Equal(t, time.Second, runner.backoff(1))
+	require.Equal(t, 2*time.Second, runner.backoff(2))
+	require.Equal(t, 8*time.Second, runner.backoff(4))
+	require.Equal(t, 10*time.Second, runner.backoff(5))
+	require.Equal(t, 10*time.Second, runner.backoff(40))
+}
+
+func TestRunner_Heartbeats(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := NewRunner(repo, slog.New(slog.DiscardHandler), Options{
+		Workers:      2,
+		PollInterval: time.Millisecond,
+		LockTimeout:  20 * time.Millisecond,
+	})
+
+	require.Nil(t, runner.Heartbeats())
+	require.Error(t, runner.CheckHeartbeats(context.Background()))
+
+	runner.Start(context.Background())
+	started := time.Now()
+
+	require.Eventually(t, func() bool {
+		heartbeats := runner.Heartbeats()
+		return len(heartbeats) == 2 && heartbeats[0].After(started) && heartbeats[1].After(started)
+	}, time.Second, time.Millisecond)
+	require.NoError(t, runner.CheckHeartbeats(context.Background()))
+
+	// Stopped workers fall silent and the check notices once the lock
+	// timeout passes.
+	stop(t, runner)
+	require.Eventually(t, func() bool {
+		return runner.CheckHeartbeats(context.Background()) != nil
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestRunner_Metrics(t *testing.T) {
+	repo := newMemoryRepository()
+	runner := newTestRunner(repo)
+
+	Register(runner, "broken", func(context.Context, greeting) error {
+		return errors.New("still broken")
+	})
+
+	ctx := context.Background()
+	job, err := runner.Enqueue(ctx, "broken", greeting{})
+	require.NoError(t, err)
+	_, err = runner.Enqueue(ctx, "later", greeting{})
+	require.NoError(t, err)
+	repo.jobs[2].RunAt = time.Now().Add(time.Hour)
+
+	runner.Start(ctx)
+	waitForStatus(t, repo, job.ID, domain.JobDead)
+	stop(t, runner)
+
+	expected := `
+# HELP nerd_backlog_job_failures_total Failed job attempts, by kind and whether the job was retried or is dead.
+# TYPE nerd_backlog_job_failures_total counter
+nerd_backlog_job_failures_total{kind="broken",outcome="dead"} 1
+nerd_backlog_job_failures_total{kind="broken",outcome="retried"} 2
+# HELP nerd_backlog_jobs Jobs in the queue, by status.
+# TYPE nerd_backlog_jobs gauge
+nerd_backlog_jobs{status="dead"} 1
+nerd_backlog_jobs{status="queued"} 1
+nerd_backlog_jobs{status="running"} 0
+nerd_backlog_jobs{status="succeeded"} 0
+`
+	require.NoError(t, testutil.CollectAndCompare(runner, strings.NewReader(expected)))
+}
@@ -0,0 +1,149 @@
+package library
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/validator"
+)
+
+type LibraryEntryResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	GameID          uuid.UUID  `json:"game_id"`
+	GameTitle       string     `json:"game_title"`
+	Status          string     `json:"status"`
+	StartedAt       *time.Time `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at"`
+	Rating          *int       `json:"rating"`
+	Notes           string     `json:"notes"`
+	PlaytimeMinutes int        `json:"playtime_minutes"`
+	LastPlayedAt    *time.Time `json:"last_played_at"`
+	InsertedAt      time.Time  `json:"inserted_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+func MountLibraryEntryResponse(entry domain.LibraryEntry) LibraryEntryResponse {
+	return LibraryEntryResponse{
+		ID:              entry.ID,
+		GameID:          entry.GameID,
+		GameTitle:       entry.GameTitle,
+		Status:          string(entry.Status),
+		StartedAt:       optionalTime(entry.StartedAt),
+		FinishedAt:      optionalTime(entry.FinishedAt),
+		Rating:          optionalRating(entry.Rating),
+		Notes:           entry.Notes,
+		PlaytimeMinutes: entry.PlaytimeMinutes,
+		LastPlayedAt:    optionalTime(entry.LastPlayedAt),
+		InsertedAt:      entry.InsertedAt,
+		UpdatedAt:       entry.UpdatedAt,
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func optionalRating(rating int) *int {
+	if rating == 0 {
+		return nil
+	}
+	return &rating
+}
+
+func MountLibraryEntriesResponse(entries []domain.LibraryEntry) []LibraryEntryResponse {
+	response := make([]LibraryEntryResponse, len(entries))
+	for i, entry := range entries {
+		response[i] = MountLibraryEntryResponse(entry)
+	}
+	return response
+}
+
+type CreateLibraryEntryPayload struct {
+	GameID uuid.UUID `json:"game_id"`
+	Status string    `json:"status"`
+}
+
+func (p *CreateLibraryEntryPayload) Valid(ctx context.Context) validator.Problems {
+	problems := make(validator.Problems)
+
+	if p.GameID == uuid.Nil {
+		problems.Add("game_id", "game_id is required")
+	}
+
+	if p.Status == "" {
+		p.Status = string(domain.LibraryStatusBacklog)
+	}
+
+	if _, err := domain.ParseLibraryStatus(p.Status); err != nil {
+		problems.Add("status", err.Error())
+	}
+
+	return problems
+}
+
+type UpdateLibraryEntryPayload struct {
+	Status *string `json:"status"`
+	Rating *int    `json:"rating"`
+	Notes  *string `json:"notes"`
+}
+
+func (p *UpdateLibraryEntryPayload) Valid(ctx context.Context) validator.Problems {
+	problems := make(validator.Problems)
+
+	if p.Status != nil {
+		if _, err := domain.ParseLibraryStatus(*p.Status); err != nil {
+			problems.Add("status", err.Error())
+		}
+	}
+
+	if p.Rating != nil && (*p.Rating < 0 || *p.Rating > 10) {
+		problems.Add("rating", "rating must be between 1 and 10, or 0 to clear it")
+	}
+
+	if p.Notes != nil && len(*p.Notes) > 10000 {
+		problems.Add("notes", "notes must be at most 10000 characters long")
+	}
+
+	return problems
+}
+
+func (p *UpdateLibraryEntryPayload) Update() domain.LibraryEntryUpdate {
+	var update domain.LibraryEntryUpdate
+
+	if p.Status != nil {
+		status := domain.LibraryStatus(*p.Status)
+		update.Status = &status
+	}
+
+	update.Rating = p.Rating
+	update.Notes = p.Notes
+
+	return update
+}
+
+type LibraryEntryEventResponse struct {
+	ID         int64     `json:"id"`
+	Kind       string    `json:"kind"`
+	From       string    `json:"from,omitempty"`
+	To         string    `json:"to,omitempty"`
+	InsertedAt time.Time `json:"inserted_at"`
+}
+
+func MountLibraryEntryEventsResponse(events []domain.LibraryEntryEvent) []LibraryEntryEventResponse {
+	response := make([]LibraryEntryEventResponse, len(events))
+	for i, event := range events {
+		response[i] = LibraryEntryEventResponse{
+			ID:         event.ID,
+			Kind:       string(event.Kind),
+			From:       event.From,
+			To:         event.To,
+			InsertedAt: event.InsertedAt,
+		}
+	}
+	return response
+}
@@ -0,0 +1,134 @@
+package library
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
+)
+
+type HTTPAdapter struct {
+	service domain.LibraryService
+	logger  *slog.Logger
+}
+
+func NewHTTPAdapter(s domain.LibraryService, logger *slog.Logger) *HTTPAdapter {
+	return &HTTPAdapter{s, logger}
+}
+
+func (h *HTTPAdapter) error(w http.ResponseWriter, r *http.Request, code int, title string, err error) {
+	httpjson.NotifyHTTPError(w, r, h.logger, code, title, err)
+}
+
+func (h *HTTPAdapter) serviceError(w http.ResponseWriter, r *http.Request, action string, err error) {
+	httpjson.NotifyProblem(w, r, h.logger, action, err)
+}
+
+func (h *HTTPAdapter) AddEntry(w http.ResponseWriter, r *http.Request) {
+	payload, err := httpjson.DecodeValid[*CreateLibraryEntryPayload](r)
+	if err != nil {
+		h.serviceError(w, r, "invalid payload", err)
+		return
+	}
+
+	entry, err := h.service.AddEntry(r.Context(), payload.GameID, domain.LibraryStatus(payload.Status))
+	if err != nil {
+		h.serviceError(w, r, "failed to add library entry", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusCreated, MountLibraryEntryResponse(entry)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode library entry", err)
+	}
+}
+
+func (h *HTTPAdapter) GetEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	entry, err := h.service.GetEntry(r.Context(), id)
+	if err != nil {
+		h.serviceError(w, r, "failed to retrieve library entry", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountLibraryEntryResponse(entry)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode library entry", err)
+	}
+}
+
+func (h *HTTPAdapter) ListEntries(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.service.ListEntries(r.Context())
+	if err != nil {
+		h.serviceError(w, r, "failed to list library entries", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountLibraryEntriesResponse(entries)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode library entries", err)
+	}
+}
+
+func (h *HTTPAdapter) UpdateEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	payload, err := httpjson.DecodeValid[*UpdateLibraryEntryPayload](r)
+	if err != nil {
+		h.serviceError(w, r, "invalid payload", err)
+		return
+	}
+
+	entry, err := h.service.UpdateEntry(r.Context(), id, payload.Update())
+	if err != nil {
+		h.serviceError(w, r, "failed to update library entry", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountLibraryEntryResponse(entry)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode library entry", err)
+	}
+}
+
+func (h *HTTPAdapter) DeleteEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	if err := h.service.DeleteEntry(r.Context(), id); err != nil {
+		h.serviceError(w, r, "failed to delete library entry", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HTTPAdapter) GetEntryHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.error(w, r, http.StatusUnprocessableEntity, "failed to parse id", err)
+		return
+	}
+
+	events, err := h.service.GetEntryHistory(r.Context(), id)
+	if err != nil {
+		h.serviceError(w, r, "failed to retrieve library entry history", err)
+		return
+	}
+
+	if err := httpjson.Encode(w, r, http.StatusOK, MountLibraryEntryEventsResponse(events)); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to encode library entry history", err)
+	}
+}
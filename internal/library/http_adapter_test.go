@@ -0,0 +1,273 @@
+package library
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/httpserver/problems"
+	"github.com/kalogs-c/nerd-backlog/pkg/httpjson"
+)
+
+// Adapters answer with the problems the router registers.
+func init() {
+	problems.Register()
+}
+
+func withRouteParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHTTPAdapter_AddEntry(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	gameID := uuid.New()
+	want := domain.LibraryEntry{ID: uuid.New(), GameID: gameID, GameTitle: "Hades", Status: domain.LibraryStatusWishlist}
+	mockSvc.On("AddEntry", mock.Anything, gameID, domain.LibraryStatusWishlist).Return(want, nil)
+
+	body := bytes.NewBufferString(`{"game_id":"` + gameID.String() + `","status":"wishlist"}`)
+	req := httptest.NewRequest(http.MethodPost, "/library", body)
+	w := httptest.NewRecorder()
+
+	handler.AddEntry(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var got LibraryEntryResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Equal(t, want.ID, got.ID)
+	require.Equal(t, "wishlist", got.Status)
+	require.Equal(t, "Hades", got.GameTitle)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_AddEntry_DefaultsToBacklog(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	gameID := uuid.New()
+	want := domain.LibraryEntry{ID: uuid.New(), GameID: gameID, Status: domain.LibraryStatusBacklog}
+	mockSvc.On("AddEntry", mock.Anything, gameID, domain.LibraryStatusBacklog).Return(want, nil)
+
+	body := bytes.NewBufferString(`{"game_id":"` + gameID.String() + `"}`)
+	req := httptest.NewRequest(http.MethodPost, "/library", body)
+	w := httptest.NewRecorder()
+
+	handler.AddEntry(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_AddEntry_InvalidStatus(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	body := bytes.NewBufferString(`{"game_id":"` + uuid.NewString() + `","status":"finished"}`)
+	req := httptest.NewRequest(http.MethodPost, "/library", body)
+	w := httptest.NewRecorder()
+
+	handler.AddEntry(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	mockSvc.AssertNotCalled(t, "AddEntry")
+}
+
+func TestHTTPAdapter_AddEntry_GameNotFound(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	gameID := uuid.New()
+	mockSvc.On("AddEntry", mock.Anything, gameID, domain.LibraryStatusBacklog).Return(domain.LibraryEntry{}, domain.ErrGameNotFound)
+
+	body := bytes.NewBufferString(`{"game_id":"` + gameID.String() + `"}`)
+	req := httptest.NewRequest(http.MethodPost, "/library", body)
+	w := httptest.NewRecorder()
+
+	handler.AddEntry(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_ListEntries(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	want := []domain.LibraryEntry{
+		{ID: uuid.New(), GameTitle: "Hades", Status: domain.LibraryStatusPlaying},
+		{ID: uuid.New(), GameTitle: "Celeste", Status: domain.LibraryStatusOnHold},
+	}
+	mockSvc.On("ListEntries", mock.Anything).Return(want, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/library", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListEntries(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got []LibraryEntryResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Len(t, got, 2)
+	require.Equal(t, "on_hold", got[1].Status)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_GetEntry_NotFound(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	mockSvc.On("GetEntry", mock.Anything, id).Return(domain.LibraryEntry{}, domain.ErrLibraryEntryNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/library/"+id.String(), nil)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.GetEntry(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_UpdateEntry(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	status := domain.LibraryStatusCompleted
+	want := domain.LibraryEntry{ID: id, Status: status}
+	mockSvc.On("UpdateEntry", mock.Anything, id, domain.LibraryEntryUpdate{Status: &status}).Return(want, nil)
+
+	body := bytes.NewBufferString(`{"status":"completed"}`)
+	req := httptest.NewRequest(http.MethodPatch, "/library/"+id.String(), body)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.UpdateEntry(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_UpdateEntry_InvalidTransition(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	status := domain.LibraryStatusCompleted
+	transitionErr := &domain.StatusTransitionError{
+		From:    domain.LibraryStatusDropped,
+		To:      domain.LibraryStatusCompleted,
+		Allowed: []domain.LibraryStatus{domain.LibraryStatusPlaying, domain.LibraryStatusBacklog},
+	}
+	mockSvc.On("UpdateEntry", mock.Anything, id, domain.LibraryEntryUpdate{Status: &status}).Return(domain.LibraryEntry{}, transitionErr)
+
+	body := bytes.NewBufferString(`{"status":"completed"}`)
+	req := httptest.NewRequest(http.MethodPatch, "/library/"+id.String(), body)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.UpdateEntry(w, req)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var got httpjson.ErrorResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Equal(t, http.StatusConflict, got.Status)
+	require.Equal(t, []string{"playing", "backlog"}, got.AllowedStates)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_UpdateEntry_InvalidRating(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	body := bytes.NewBufferString(`{"rating":11}`)
+	req := httptest.NewRequest(http.MethodPatch, "/library/"+id.String(), body)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.UpdateEntry(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	mockSvc.AssertNotCalled(t, "UpdateEntry")
+}
+
+func TestHTTPAdapter_GetEntryHistory(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	events := []domain.LibraryEntryEvent{
+		{ID: 1, EntryID: id, Kind: domain.LibraryEntryEventStatusChanged, To: "backlog"},
+		{ID: 2, EntryID: id, Kind: domain.LibraryEntryEventStatusChanged, From: "backlog", To: "dropped"},
+	}
+	mockSvc.On("GetEntryHistory", mock.Anything, id).Return(events, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/library/"+id.String()+"/history", nil)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.GetEntryHistory(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got []LibraryEntryEventResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Len(t, got, 2)
+	require.Equal(t, "status_changed", got[1].Kind)
+	require.Equal(t, "dropped", got[1].To)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_DeleteEntry(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	mockSvc.On("DeleteEntry", mock.Anything, id).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/library/"+id.String(), nil)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.DeleteEntry(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestHTTPAdapter_DeleteEntry_ServiceError(t *testing.T) {
+	mockSvc := new(MockLibraryService)
+	handler := NewHTTPAdapter(mockSvc, slog.Default())
+
+	id := uuid.New()
+	mockSvc.On("DeleteEntry", mock.Anything, id).Return(errors.New("delete failed"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/library/"+id.String(), nil)
+	req = withRouteParam(req, "id", id.String())
+	w := httptest.NewRecorder()
+
+	handler.DeleteEntry(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	mockSvc.AssertExpectations(t)
+}
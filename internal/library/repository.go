@@ -0,0 +1,217 @@
+package library
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+type repository struct {
+	db   *sqlc.Queries
+	pool postgres.TxBeginner
+}
+
+func NewRepository(q *sqlc.Queries, pool postgres.TxBeginner) domain.LibraryRepository {
+	return &repository{q, pool}
+}
+
+func (r *repository) CreateLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, error) {
+	var inserted sqlc.LibraryEntry
+	err := postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		var err error
+		inserted, err = q.CreateLibraryEntry(ctx, sqlc.CreateLibraryEntryParams{
+			AccountID:  entry.AccountID,
+			GameID:     entry.GameID,
+			Status:     string(entry.Status),
+			StartedAt:  timestamptz(entry.StartedAt),
+			FinishedAt: timestamptz(entry.FinishedAt),
+			Rating:     rating(entry.Rating),
+			Notes:      entry.Notes,
+		})
+		if err != nil {
+			return err
+		}
+
+		return appendEvents(ctx, q, inserted.ID, inserted.AccountID, events)
+	})
+	if err != nil {
+		return domain.LibraryEntry{}, err
+	}
+
+	return r.GetLibraryEntry(ctx, inserted.AccountID, inserted.ID)
+}
+
+func (r *repository) GetLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) (domain.LibraryEntry, error) {
+	entry, err := r.db.GetLibraryEntry(ctx, sqlc.GetLibraryEntryParams{
+		ID:        id,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return domain.LibraryEntry{}, postgres.TranslateError(err, domain.ErrLibraryEntryNotFound)
+	}
+
+	return toDomainEntry(entry), nil
+}
+
+func (r *repository) ListLibraryEntries(ctx context.Context, accountID uuid.UUID) ([]domain.LibraryEntry, error) {
+	entries, err := r.db.ListLibraryEntries(ctx, accountID)
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	entriesList := make([]domain.LibraryEntry, len(entries))
+	for i, entry := range entries {
+		entriesList[i] = toDomainEntry(sqlc.GetLibraryEntryRow(entry))
+	}
+
+	return entriesList, nil
+}
+
+func (r *repository) UpdateLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, error) {
+	err := postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		_, err := q.UpdateLibraryEntry(ctx, sqlc.UpdateLibraryEntryParams{
+			ID:         entry.ID,
+			AccountID:  entry.AccountID,
+			Status:     string(entry.Status),
+			StartedAt:  timestamptz(entry.StartedAt),
+			FinishedAt: timestamptz(entry.FinishedAt),
+			Rating:     rating(entry.Rating),
+			Notes:      entry.Notes,
+		})
+		if err != nil {
+			return postgres.TranslateError(err, domain.ErrLibraryEntryNotFound)
+		}
+
+		return appendEvents(ctx, q, entry.ID, entry.AccountID, events)
+	})
+	if err != nil {
+		return domain.LibraryEntry{}, err
+	}
+
+	return r.GetLibraryEntry(ctx, entry.AccountID, entry.ID)
+}
+
+func (r *repository) DeleteLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) error {
+	deleted, err := r.db.DeleteLibraryEntry(ctx, sqlc.DeleteLibraryEntryParams{
+		ID:        id,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return postgres.TranslateError(err, nil)
+	}
+
+	if deleted == 0 {
+		return domain.ErrLibraryEntryNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) ImportLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, bool, error) {
+	var imported sqlc.ImportLibraryEntryRow
+	err := postgres.WithTx(ctx, r.pool, r.db, func(q *sqlc.Queries) error {
+		var err error
+		imported, err = q.ImportLibraryEntry(ctx, sqlc.ImportLibraryEntryParams{
+			AccountID:       entry.AccountID,
+			GameID:          entry.GameID,
+			Status:          string(entry.Status),
+			StartedAt:       timestamptz(entry.StartedAt),
+			PlaytimeMinutes: int32(entry.PlaytimeMinutes),
+			LastPlayedAt:    timestamptz(entry.LastPlayedAt),
+		})
+		if err != nil {
+			return err
+		}
+
+		if !imported.Inserted {
+			return nil
+		}
+
+		return appendEvents(ctx, q, imported.ID, imported.AccountID, events)
+	})
+	if err != nil {
+		return domain.LibraryEntry{}, false, err
+	}
+
+	stored, err := r.GetLibraryEntry(ctx, imported.AccountID, imported.ID)
+	if err != nil {
+		return domain.LibraryEntry{}, false, err
+	}
+
+	return stored, imported.Inserted, nil
+}
+
+func (r *repository) ListLibraryEntryEvents(ctx context.Context, accountID uuid.UUID, entryID uuid.UUID) ([]domain.LibraryEntryEvent, error) {
+	events, err := r.db.ListLibraryEntryEvents(ctx, sqlc.ListLibraryEntryEventsParams{
+		EntryID:   entryID,
+		AccountID: accountID,
+	})
+	if err != nil {
+		return nil, postgres.TranslateError(err, nil)
+	}
+
+	eventsList := make([]domain.LibraryEntryEvent, len(events))
+	for i, event := range events {
+		eventsList[i] = domain.LibraryEntryEvent{
+			ID:         event.ID,
+			EntryID:    event.EntryID.Bytes,
+			AccountID:  event.AccountID,
+			Kind:       domain.LibraryEntryEventKind(event.Kind),
+			From:       event.FromValue.String,
+			To:         event.ToValue.String,
+			InsertedAt: event.InsertedAt.Time,
+		}
+	}
+
+	return eventsList, nil
+}
+
+func appendEvents(ctx context.Context, q *sqlc.Queries, entryID uuid.UUID, accountID uuid.UUID, events []domain.LibraryEntryEvent) error {
+	for _, event := range events {
+		err := q.CreateLibraryEntryEvent(ctx, sqlc.CreateLibraryEntryEventParams{
+			EntryID:   entryID,
+			AccountID: accountID,
+			Kind:      string(event.Kind),
+			FromValue: pgtype.Text{String: event.From, Valid: event.From != ""},
+			ToValue:   pgtype.Text{String: event.To, Valid: event.To != ""},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toDomainEntry(entry sqlc.GetLibraryEntryRow) domain.LibraryEntry {
+	return domain.LibraryEntry{
+		ID:              entry.ID,
+		AccountID:       entry.AccountID,
+		GameID:          entry.GameID,
+		GameTitle:       entry.GameTitle,
+		Status:          domain.LibraryStatus(entry.Status),
+		StartedAt:       entry.StartedAt.Time,
+		FinishedAt:      entry.FinishedAt.Time,
+		Rating:          int(entry.Rating.Int16),
+		Notes:           entry.Notes,
+		PlaytimeMinutes: int(entry.PlaytimeMinutes),
+		LastPlayedAt:    entry.LastPlayedAt.Time,
+		TimeStamps: domain.TimeStamps{
+			InsertedAt: entry.InsertedAt.Time,
+			UpdatedAt:  entry.UpdatedAt.Time,
+		},
+	}
+}
+
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
+
+func rating(value int) pgtype.Int2 {
+	return pgtype.Int2{Int16: int16(value), Valid: value != 0}
+}
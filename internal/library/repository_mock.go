@@ -0,0 +1,52 @@
+package library
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLibraryRepository struct {
+	mock.Mock
+}
+
+func NewMockLibraryRepository() domain.LibraryRepository {
+	return new(MockLibraryRepository)
+}
+
+func (m *MockLibraryRepository) CreateLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, error) {
+	args := m.Called(ctx, entry, events)
+	return args.Get(0).(domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryRepository) GetLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) (domain.LibraryEntry, error) {
+	args := m.Called(ctx, accountID, id)
+	return args.Get(0).(domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryRepository) ListLibraryEntries(ctx context.Context, accountID uuid.UUID) ([]domain.LibraryEntry, error) {
+	args := m.Called(ctx, accountID)
+	return args.Get(0).([]domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryRepository) UpdateLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, error) {
+	args := m.Called(ctx, entry, events)
+	return args.Get(0).(domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryRepository) DeleteLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) error {
+	args := m.Called(ctx, accountID, id)
+	return args.Error(0)
+}
+
+func (m *MockLibraryRepository) ImportLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, bool, error) {
+	args := m.Called(ctx, entry, events)
+	return args.Get(0).(domain.LibraryEntry), args.Bool(1), args.Error(2)
+}
+
+func (m *MockLibraryRepository) ListLibraryEntryEvents(ctx context.Context, accountID uuid.UUID, entryID uuid.UUID) ([]domain.LibraryEntryEvent, error) {
+	args := m.Called(ctx, accountID, entryID)
+	return args.Get(0).([]domain.LibraryEntryEvent), args.Error(1)
+}
@@ -0,0 +1,228 @@
+package library
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kalogs-c/nerd-backlog/internal/accounts"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/testutils"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+	"github.com/stretchr/testify/require"
+)
+
+var testQueries *sqlc.Queries
+var testDB *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dsn, terminate, err := testutils.StartPostgresContainer(ctx)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	testDB = postgres.MustConnect(ctx, dsn, nil)
+	gooseProvider := migrations.MustProvide(testDB)
+	testQueries = sqlc.New(testDB)
+
+	_, err = gooseProvider.Up(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	exitCode := m.Run()
+
+	if err := terminate(context.Background()); err != nil {
+		log.Println(err)
+	}
+
+	os.Exit(exitCode)
+}
+
+func createAccountAndGame(t *testing.T, ctx context.Context) (domain.Account, domain.Game) {
+	t.Helper()
+
+	account, err := accounts.NewRepository(testQueries, testDB).CreateAccount(ctx, domain.Account{
+		Nickname:       "library",
+		Email:          fmt.Sprintf("library_test%d@example.com", rand.Uint64()),
+		HashedPassword: "salt$hash",
+	})
+	require.NoError(t, err)
+
+	game, err := games.NewRepository(testQueries, testDB).CreateGame(ctx, domain.Game{Title: "Outer Wilds"})
+	require.NoError(t, err)
+
+	return account, game
+}
+
+func TestRepository_CreateAndGetLibraryEntry(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+	account, game := createAccountAndGame(t, ctx)
+
+	entry, err := repo.CreateLibraryEntry(ctx, domain.LibraryEntry{
+		AccountID: account.ID,
+		GameID:    game.ID,
+		Status:    domain.LibraryStatusWishlist,
+	}, nil)
+	require.NoError(t, err)
+	require.NotZero(t, entry.ID)
+	require.Equal(t, "Outer Wilds", entry.GameTitle)
+
+	got, err := repo.GetLibraryEntry(ctx, account.ID, entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.LibraryStatusWishlist, got.Status)
+
+	_, err = repo.GetLibraryEntry(ctx, uuid.New(), entry.ID)
+	require.ErrorIs(t, err, domain.ErrLibraryEntryNotFound)
+}
+
+func TestRepository_UpdateAndDeleteLibraryEntry(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+	account, game := createAccountAndGame(t, ctx)
+
+	entry, err := repo.CreateLibraryEntry(ctx, domain.LibraryEntry{
+		AccountID: account.ID,
+		GameID:    game.ID,
+		Status:    domain.LibraryStatusBacklog,
+	}, nil)
+	require.NoError(t, err)
+
+	startedAt := time.Now().UTC().Truncate(time.Microsecond)
+	entry.Status = domain.LibraryStatusPlaying
+	entry.StartedAt = startedAt
+	updated, err := repo.UpdateLibraryEntry(ctx, entry, nil)
+	require.NoError(t, err)
+	require.Equal(t, domain.LibraryStatusPlaying, updated.Status)
+	require.True(t, startedAt.Equal(updated.StartedAt))
+	require.True(t, updated.FinishedAt.IsZero())
+
+	entries, err := repo.ListLibraryEntries(ctx, account.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, repo.DeleteLibraryEntry(ctx, account.ID, entry.ID))
+	require.ErrorIs(t, repo.DeleteLibraryEntry(ctx, account.ID, entry.ID), domain.ErrLibraryEntryNotFound)
+}
+
+func TestRepository_LibraryEntryEvents(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+	account, game := createAccountAndGame(t, ctx)
+
+	entry, err := repo.CreateLibraryEntry(ctx, domain.LibraryEntry{
+		AccountID: account.ID,
+		GameID:    game.ID,
+		Status:    domain.LibraryStatusWishlist,
+	}, []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventStatusChanged, To: "wishlist"},
+	})
+	require.NoError(t, err)
+
+	entry.Status = domain.LibraryStatusPlaying
+	entry.Rating = 8
+	_, err = repo.UpdateLibraryEntry(ctx, entry, []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventStatusChanged, From: "wishlist", To: "playing"},
+		{Kind: domain.LibraryEntryEventRatingChanged, To: "8"},
+	})
+	require.NoError(t, err)
+
+	events, err := repo.ListLibraryEntryEvents(ctx, account.ID, entry.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, domain.LibraryEntryEventStatusChanged, events[0].Kind)
+	require.Equal(t, "", events[0].From)
+	require.Equal(t, "wishlist", events[1].From)
+	require.Equal(t, "playing", events[1].To)
+	require.Equal(t, domain.LibraryEntryEventRatingChanged, events[2].Kind)
+
+	_, err = testDB.Exec(ctx, "UPDATE library_entry_events SET to_value = 'tampered' WHERE entry_id = $1", entry.ID)
+	require.Error(t, err)
+
+	others, err := repo.ListLibraryEntryEvents(ctx, uuid.New(), entry.ID)
+	require.NoError(t, err)
+	require.Empty(t, others)
+}
+
+func TestRepository_LibraryEntryEventsOutliveEntry(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+	account, game := createAccountAndGame(t, ctx)
+
+	entry, err := repo.CreateLibraryEntry(ctx, domain.LibraryEntry{
+		AccountID: account.ID,
+		GameID:    game.ID,
+		Status:    domain.LibraryStatusWishlist,
+	}, []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventStatusChanged, To: "wishlist"},
+	})
+	require.NoError(t, err)
+
+	_, err = testDB.Exec(ctx, "DELETE FROM library_entry_events WHERE entry_id = $1", entry.ID)
+	require.Error(t, err)
+
+	require.NoError(t, repo.DeleteLibraryEntry(ctx, account.ID, entry.ID))
+
+	var count int
+	err = testDB.QueryRow(ctx,
+		"SELECT count(*) FROM library_entry_events WHERE account_id = $1 AND game_id = $2 AND entry_id IS NULL",
+		account.ID, game.ID,
+	).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	_, err = testDB.Exec(ctx, "DELETE FROM accounts WHERE id = $1", account.ID)
+	require.NoError(t, err)
+
+	err = testDB.QueryRow(ctx, "SELECT count(*) FROM library_entry_events WHERE account_id = $1", account.ID).Scan(&count)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestRepository_ImportLibraryEntry(t *testing.T) {
+	repo := NewRepository(testQueries, testDB)
+	ctx := context.Background()
+	account, game := createAccountAndGame(t, ctx)
+
+	events := []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventStatusChanged, To: "backlog"},
+	}
+
+	entry, inserted, err := repo.ImportLibraryEntry(ctx, domain.LibraryEntry{
+		AccountID:       account.ID,
+		GameID:          game.ID,
+		Status:          domain.LibraryStatusBacklog,
+		PlaytimeMinutes: 30,
+	}, events)
+	require.NoError(t, err)
+	require.True(t, inserted)
+	require.Equal(t, 30, entry.PlaytimeMinutes)
+
+	again, inserted, err := repo.ImportLibraryEntry(ctx, domain.LibraryEntry{
+		AccountID:       account.ID,
+		GameID:          game.ID,
+		Status:          domain.LibraryStatusBacklog,
+		PlaytimeMinutes: 90,
+	}, events)
+	require.NoError(t, err)
+	require.False(t, inserted)
+	require.Equal(t, entry.ID, again.ID)
+	require.Equal(t, 90, again.PlaytimeMinutes)
+
+	history, err := repo.ListLibraryEntryEvents(ctx, account.ID, entry.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+}
@@ -0,0 +1,147 @@
+package library
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+type service struct {
+	repository     domain.LibraryRepository
+	gameRepository domain.GameRepository
+	statusMachine  StatusMachine
+}
+
+func NewService(repository domain.LibraryRepository, gameRepository domain.GameRepository) domain.LibraryService {
+	return &service{repository, gameRepository, NewStatusMachine()}
+}
+
+func (s *service) AddEntry(ctx context.Context, gameID uuid.UUID, status domain.LibraryStatus) (domain.LibraryEntry, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return domain.LibraryEntry{}, auth.ErrInvalidToken
+	}
+
+	if _, err := s.gameRepository.GetGameByID(ctx, gameID); err != nil {
+		return domain.LibraryEntry{}, err
+	}
+
+	entry := s.statusMachine.Enter(domain.LibraryEntry{
+		AccountID: accountID,
+		GameID:    gameID,
+		Status:    status,
+	}, time.Now().UTC())
+
+	events := []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventStatusChanged, To: string(entry.Status)},
+	}
+
+	return s.repository.CreateLibraryEntry(ctx, entry, events)
+}
+
+func (s *service) GetEntry(ctx context.Context, id uuid.UUID) (domain.LibraryEntry, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return domain.LibraryEntry{}, auth.ErrInvalidToken
+	}
+
+	return s.repository.GetLibraryEntry(ctx, accountID, id)
+}
+
+func (s *service) ListEntries(ctx context.Context) ([]domain.LibraryEntry, error) {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return nil, auth.ErrInvalidToken
+	}
+
+	return s.repository.ListLibraryEntries(ctx, accountID)
+}
+
+func (s *service) UpdateEntry(ctx context.Context, id uuid.UUID, update domain.LibraryEntryUpdate) (domain.LibraryEntry, error) {
+	current, err := s.GetEntry(ctx, id)
+	if err != nil {
+		return domain.LibraryEntry{}, err
+	}
+
+	entry := current
+	if update.Status != nil {
+		entry, err = s.statusMachine.Transition(entry, *update.Status, time.Now().UTC())
+		if err != nil {
+			return domain.LibraryEntry{}, err
+		}
+	}
+
+	if update.Rating != nil {
+		entry.Rating = *update.Rating
+	}
+
+	if update.Notes != nil {
+		entry.Notes = *update.Notes
+	}
+
+	events := entryEvents(current, entry)
+	if len(events) == 0 {
+		return current, nil
+	}
+
+	return s.repository.UpdateLibraryEntry(ctx, entry, events)
+}
+
+func (s *service) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+	accountID, ok := auth.AccountIDFromContext(ctx)
+	if !ok {
+		return auth.ErrInvalidToken
+	}
+
+	return s.repository.DeleteLibraryEntry(ctx, accountID, id)
+}
+
+func (s *service) GetEntryHistory(ctx context.Context, id uuid.UUID) ([]domain.LibraryEntryEvent, error) {
+	entry, err := s.GetEntry(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListLibraryEntryEvents(ctx, entry.AccountID, entry.ID)
+}
+
+func entryEvents(before, after domain.LibraryEntry) []domain.LibraryEntryEvent {
+	var events []domain.LibraryEntryEvent
+
+	if before.Status != after.Status {
+		events = append(events, domain.LibraryEntryEvent{
+			Kind: domain.LibraryEntryEventStatusChanged,
+			From: string(before.Status),
+			To:   string(after.Status),
+		})
+	}
+
+	if before.Rating != after.Rating {
+		events = append(events, domain.LibraryEntryEvent{
+			Kind: domain.LibraryEntryEventRatingChanged,
+			From: ratingString(before.Rating),
+			To:   ratingString(after.Rating),
+		})
+	}
+
+	if before.Notes != after.Notes {
+		events = append(events, domain.LibraryEntryEvent{
+			Kind: domain.LibraryEntryEventNotesEdited,
+			From: before.Notes,
+			To:   after.Notes,
+		})
+	}
+
+	return events
+}
+
+func ratingString(rating int) string {
+	if rating == 0 {
+		return ""
+	}
+	return strconv.Itoa(rating)
+}
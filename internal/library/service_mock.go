@@ -0,0 +1,47 @@
+package library
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLibraryService struct {
+	mock.Mock
+}
+
+func NewMockLibraryService() domain.LibraryService {
+	return new(MockLibraryService)
+}
+
+func (m *MockLibraryService) AddEntry(ctx context.Context, gameID uuid.UUID, status domain.LibraryStatus) (domain.LibraryEntry, error) {
+	args := m.Called(ctx, gameID, status)
+	return args.Get(0).(domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryService) GetEntry(ctx context.Context, id uuid.UUID) (domain.LibraryEntry, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryService) ListEntries(ctx context.Context) ([]domain.LibraryEntry, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryService) UpdateEntry(ctx context.Context, id uuid.UUID, update domain.LibraryEntryUpdate) (domain.LibraryEntry, error) {
+	args := m.Called(ctx, id, update)
+	return args.Get(0).(domain.LibraryEntry), args.Error(1)
+}
+
+func (m *MockLibraryService) DeleteEntry(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockLibraryService) GetEntryHistory(ctx context.Context, id uuid.UUID) ([]domain.LibraryEntryEvent, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]domain.LibraryEntryEvent), args.Error(1)
+}
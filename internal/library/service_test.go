@@ -0,0 +1,239 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_AddEntry(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	mockGames := new(games.MockGameRepository)
+	svc := NewService(mockRepo, mockGames)
+
+	accountID := uuid.New()
+	gameID := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	entryIn := domain.LibraryEntry{AccountID: accountID, GameID: gameID, Status: domain.LibraryStatusBacklog}
+	entryOut := domain.LibraryEntry{ID: uuid.New(), AccountID: accountID, GameID: gameID, GameTitle: "Hades", Status: domain.LibraryStatusBacklog}
+
+	mockGames.On("GetGameByID", ctx, gameID).Return(domain.Game{ID: gameID, Title: "Hades"}, nil)
+	events := []domain.LibraryEntryEvent{{Kind: domain.LibraryEntryEventStatusChanged, To: "backlog"}}
+	mockRepo.On("CreateLibraryEntry", ctx, entryIn, events).Return(entryOut, nil)
+
+	got, err := svc.AddEntry(ctx, gameID, domain.LibraryStatusBacklog)
+	require.NoError(t, err)
+	require.Equal(t, entryOut, got)
+
+	mockGames.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_AddEntry_GameNotFound(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	mockGames := new(games.MockGameRepository)
+	svc := NewService(mockRepo, mockGames)
+
+	gameID := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), uuid.New())
+
+	mockGames.On("GetGameByID", ctx, gameID).Return(domain.Game{}, domain.ErrGameNotFound)
+
+	_, err := svc.AddEntry(ctx, gameID, domain.LibraryStatusBacklog)
+	require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+	mockRepo.AssertNotCalled(t, "CreateLibraryEntry")
+	mockGames.AssertExpectations(t)
+}
+
+func TestService_AddEntry_MissingAccount(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	mockGames := new(games.MockGameRepository)
+	svc := NewService(mockRepo, mockGames)
+
+	_, err := svc.AddEntry(context.Background(), uuid.New(), domain.LibraryStatusBacklog)
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestService_ListEntries(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	want := []domain.LibraryEntry{
+		{ID: uuid.New(), AccountID: accountID, GameTitle: "Hades", Status: domain.LibraryStatusPlaying},
+		{ID: uuid.New(), AccountID: accountID, GameTitle: "Celeste", Status: domain.LibraryStatusWishlist},
+	}
+	mockRepo.On("ListLibraryEntries", ctx, accountID).Return(want, nil)
+
+	got, err := svc.ListEntries(ctx)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_UpdateEntry(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	current := domain.LibraryEntry{ID: id, AccountID: accountID, Status: domain.LibraryStatusWishlist}
+
+	mockRepo.On("GetLibraryEntry", ctx, accountID, id).Return(current, nil)
+	mockRepo.On("UpdateLibraryEntry", ctx, mock.MatchedBy(func(entry domain.LibraryEntry) bool {
+		return entry.Status == domain.LibraryStatusPlaying && !entry.StartedAt.IsZero()
+	}), []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventStatusChanged, From: "wishlist", To: "playing"},
+	}).Return(domain.LibraryEntry{ID: id, Status: domain.LibraryStatusPlaying}, nil)
+
+	status := domain.LibraryStatusPlaying
+	got, err := svc.UpdateEntry(ctx, id, domain.LibraryEntryUpdate{Status: &status})
+	require.NoError(t, err)
+	require.Equal(t, domain.LibraryStatusPlaying, got.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_UpdateEntry_RecordsRatingAndNotes(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	current := domain.LibraryEntry{ID: id, AccountID: accountID, Status: domain.LibraryStatusPlaying, Rating: 6}
+	updated := current
+	updated.Rating = 9
+	updated.Notes = "Great soundtrack"
+
+	mockRepo.On("GetLibraryEntry", ctx, accountID, id).Return(current, nil)
+	mockRepo.On("UpdateLibraryEntry", ctx, updated, []domain.LibraryEntryEvent{
+		{Kind: domain.LibraryEntryEventRatingChanged, From: "6", To: "9"},
+		{Kind: domain.LibraryEntryEventNotesEdited, To: "Great soundtrack"},
+	}).Return(updated, nil)
+
+	rating := 9
+	notes := "Great soundtrack"
+	got, err := svc.UpdateEntry(ctx, id, domain.LibraryEntryUpdate{Rating: &rating, Notes: &notes})
+	require.NoError(t, err)
+	require.Equal(t, 9, got.Rating)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_UpdateEntry_NoChanges(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	current := domain.LibraryEntry{ID: id, AccountID: accountID, Status: domain.LibraryStatusPlaying}
+	mockRepo.On("GetLibraryEntry", ctx, accountID, id).Return(current, nil)
+
+	status := domain.LibraryStatusPlaying
+	got, err := svc.UpdateEntry(ctx, id, domain.LibraryEntryUpdate{Status: &status})
+	require.NoError(t, err)
+	require.Equal(t, current, got)
+	mockRepo.AssertNotCalled(t, "UpdateLibraryEntry")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_UpdateEntry_InvalidTransition(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	current := domain.LibraryEntry{ID: id, AccountID: accountID, Status: domain.LibraryStatusDropped}
+	mockRepo.On("GetLibraryEntry", ctx, accountID, id).Return(current, nil)
+
+	status := domain.LibraryStatusCompleted
+	_, err := svc.UpdateEntry(ctx, id, domain.LibraryEntryUpdate{Status: &status})
+	require.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	mockRepo.AssertNotCalled(t, "UpdateLibraryEntry")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_UpdateEntry_NotFound(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	mockRepo.On("GetLibraryEntry", ctx, accountID, id).Return(domain.LibraryEntry{}, domain.ErrLibraryEntryNotFound)
+
+	status := domain.LibraryStatusPlaying
+	_, err := svc.UpdateEntry(ctx, id, domain.LibraryEntryUpdate{Status: &status})
+	require.ErrorIs(t, err, domain.ErrLibraryEntryNotFound)
+	mockRepo.AssertNotCalled(t, "UpdateLibraryEntry")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_DeleteEntry(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	mockRepo.On("DeleteLibraryEntry", ctx, accountID, id).Return(errors.New("delete error"))
+
+	err := svc.DeleteEntry(ctx, id)
+	require.EqualError(t, err, "delete error")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_GetEntryHistory(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	want := []domain.LibraryEntryEvent{
+		{ID: 1, EntryID: id, Kind: domain.LibraryEntryEventStatusChanged, To: "wishlist"},
+		{ID: 2, EntryID: id, Kind: domain.LibraryEntryEventStatusChanged, From: "wishlist", To: "playing"},
+	}
+	mockRepo.On("GetLibraryEntry", ctx, accountID, id).Return(domain.LibraryEntry{ID: id, AccountID: accountID}, nil)
+	mockRepo.On("ListLibraryEntryEvents", ctx, accountID, id).Return(want, nil)
+
+	got, err := svc.GetEntryHistory(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_GetEntryHistory_NotFound(t *testing.T) {
+	mockRepo := new(MockLibraryRepository)
+	svc := NewService(mockRepo, new(games.MockGameRepository))
+
+	accountID := uuid.New()
+	id := uuid.New()
+	ctx := auth.WithAccountID(context.Background(), accountID)
+
+	mockRepo.On("GetLibraryEntry", ctx, accountID, id).Return(domain.LibraryEntry{}, domain.ErrLibraryEntryNotFound)
+
+	_, err := svc.GetEntryHistory(ctx, id)
+	require.ErrorIs(t, err, domain.ErrLibraryEntryNotFound)
+	mockRepo.AssertNotCalled(t, "ListLibraryEntryEvents")
+}
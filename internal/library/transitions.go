@@ -0,0 +1,99 @@
+package library
+
+import (
+	"slices"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+// StatusMachine owns the rules for moving a library entry between statuses
+// and the dates that change along with them.
+type StatusMachine struct {
+	transitions map[domain.LibraryStatus][]domain.LibraryStatus
+}
+
+func NewStatusMachine() StatusMachine {
+	return StatusMachine{
+		transitions: map[domain.LibraryStatus][]domain.LibraryStatus{
+			domain.LibraryStatusWishlist: {
+				domain.LibraryStatusBacklog,
+				domain.LibraryStatusPlaying,
+				domain.LibraryStatusDropped,
+			},
+			domain.LibraryStatusBacklog: {
+				domain.LibraryStatusPlaying,
+				domain.LibraryStatusCompleted,
+				domain.LibraryStatusDropped,
+				domain.LibraryStatusWishlist,
+			},
+			domain.LibraryStatusPlaying: {
+				domain.LibraryStatusCompleted,
+				domain.LibraryStatusDropped,
+				domain.LibraryStatusOnHold,
+				domain.LibraryStatusBacklog,
+			},
+			domain.LibraryStatusOnHold: {
+				domain.LibraryStatusPlaying,
+				domain.LibraryStatusDropped,
+				domain.LibraryStatusBacklog,
+			},
+			domain.LibraryStatusCompleted: {
+				domain.LibraryStatusPlaying,
+			},
+			domain.LibraryStatusDropped: {
+				domain.LibraryStatusPlaying,
+				domain.LibraryStatusBacklog,
+			},
+		},
+	}
+}
+
+func (m StatusMachine) Allowed(from domain.LibraryStatus) []domain.LibraryStatus {
+	return slices.Clone(m.transitions[from])
+}
+
+// Enter applies the side effects of a brand new entry starting in its status.
+func (m StatusMachine) Enter(entry domain.LibraryEntry, now time.Time) domain.LibraryEntry {
+	switch entry.Status {
+	case domain.LibraryStatusPlaying:
+		entry.StartedAt = now
+	case domain.LibraryStatusCompleted:
+		entry.FinishedAt = now
+	}
+
+	return entry
+}
+
+// Transition moves entry to the given status, returning a
+// *domain.StatusTransitionError when the move is not allowed.
+func (m StatusMachine) Transition(entry domain.LibraryEntry, to domain.LibraryStatus, now time.Time) (domain.LibraryEntry, error) {
+	from := entry.Status
+	if from == to {
+		return entry, nil
+	}
+
+	if !slices.Contains(m.transitions[from], to) {
+		return domain.LibraryEntry{}, &domain.StatusTransitionError{
+			From:    from,
+			To:      to,
+			Allowed: m.Allowed(from),
+		}
+	}
+
+	entry.Status = to
+
+	switch to {
+	case domain.LibraryStatusPlaying:
+		if from == domain.LibraryStatusCompleted {
+			entry.StartedAt = now
+			entry.FinishedAt = time.Time{}
+		} else if entry.StartedAt.IsZero() {
+			entry.StartedAt = now
+		}
+	case domain.LibraryStatusCompleted:
+		entry.FinishedAt = now
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,112 @@
+package library
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusMachine_WishlistToPlayingSetsStartedAt(t *testing.T) {
+	machine := NewStatusMachine()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	entry := domain.LibraryEntry{ID: uuid.New(), Status: domain.LibraryStatusWishlist}
+
+	got, err := machine.Transition(entry, domain.LibraryStatusPlaying, now)
+	require.NoError(t, err)
+	require.Equal(t, domain.LibraryStatusPlaying, got.Status)
+	require.Equal(t, now, got.StartedAt)
+	require.True(t, got.FinishedAt.IsZero())
+}
+
+func TestStatusMachine_PlayingKeepsOriginalStartedAt(t *testing.T) {
+	machine := NewStatusMachine()
+	started := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	entry := domain.LibraryEntry{Status: domain.LibraryStatusOnHold, StartedAt: started}
+
+	got, err := machine.Transition(entry, domain.LibraryStatusPlaying, now)
+	require.NoError(t, err)
+	require.Equal(t, started, got.StartedAt)
+}
+
+func TestStatusMachine_CompletedSetsFinishedAt(t *testing.T) {
+	machine := NewStatusMachine()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	entry := domain.LibraryEntry{Status: domain.LibraryStatusPlaying, StartedAt: now.Add(-time.Hour)}
+
+	got, err := machine.Transition(entry, domain.LibraryStatusCompleted, now)
+	require.NoError(t, err)
+	require.Equal(t, now, got.FinishedAt)
+	require.Equal(t, now.Add(-time.Hour), got.StartedAt)
+}
+
+func TestStatusMachine_ReplayResetsDates(t *testing.T) {
+	machine := NewStatusMachine()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	entry := domain.LibraryEntry{
+		Status:     domain.LibraryStatusCompleted,
+		StartedAt:  now.Add(-48 * time.Hour),
+		FinishedAt: now.Add(-24 * time.Hour),
+	}
+
+	got, err := machine.Transition(entry, domain.LibraryStatusPlaying, now)
+	require.NoError(t, err)
+	require.Equal(t, now, got.StartedAt)
+	require.True(t, got.FinishedAt.IsZero())
+}
+
+func TestStatusMachine_DroppedToCompletedRejected(t *testing.T) {
+	machine := NewStatusMachine()
+
+	entry := domain.LibraryEntry{Status: domain.LibraryStatusDropped}
+
+	_, err := machine.Transition(entry, domain.LibraryStatusCompleted, time.Now())
+	require.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+
+	var transitionErr *domain.StatusTransitionError
+	require.True(t, errors.As(err, &transitionErr))
+	require.Equal(t, domain.LibraryStatusDropped, transitionErr.From)
+	require.Equal(t, domain.LibraryStatusCompleted, transitionErr.To)
+	require.Equal(t, []string{"playing", "backlog"}, transitionErr.AllowedStates())
+}
+
+func TestStatusMachine_SameStatusIsNoop(t *testing.T) {
+	machine := NewStatusMachine()
+
+	entry := domain.LibraryEntry{Status: domain.LibraryStatusBacklog}
+
+	got, err := machine.Transition(entry, domain.LibraryStatusBacklog, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, entry, got)
+}
+
+func TestStatusMachine_EveryStatusHasTransitions(t *testing.T) {
+	machine := NewStatusMachine()
+
+	for _, status := range domain.LibraryStatuses {
+		require.NotEmpty(t, machine.Allowed(status), status)
+	}
+}
+
+func TestStatusMachine_Enter(t *testing.T) {
+	machine := NewStatusMachine()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	playing := machine.Enter(domain.LibraryEntry{Status: domain.LibraryStatusPlaying}, now)
+	require.Equal(t, now, playing.StartedAt)
+
+	completed := machine.Enter(domain.LibraryEntry{Status: domain.LibraryStatusCompleted}, now)
+	require.Equal(t, now, completed.FinishedAt)
+
+	wishlist := machine.Enter(domain.LibraryEntry{Status: domain.LibraryStatusWishlist}, now)
+	require.True(t, wishlist.StartedAt.IsZero())
+	require.True(t, wishlist.FinishedAt.IsZero())
+}
@@ -0,0 +1,220 @@
+package metadata
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+// refreshTimeout bounds a background refresh, which outlives the request that
+// noticed the entry was stale.
+const refreshTimeout = 30 * time.Second
+
+// CachedProvider serves metadata from the metadata_cache table and only asks
+// the wrapped provider on a miss. Entries older than the TTL are still served,
+// while a single background refresh per entry fetches a newer copy.
+type CachedProvider struct {
+	provider   domain.MetadataProvider
+	source     domain.ExternalSource
+	repository domain.MetadataCacheRepository
+	ttl        time.Duration
+	logger     *slog.Logger
+	now        func() time.Time
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+	wg         sync.WaitGroup
+}
+
+func NewCachedProvider(
+	provider domain.MetadataProvider,
+	repository domain.MetadataCacheRepository,
+	ttl time.Duration,
+	logger *slog.Logger,
+) *CachedProvider {
+	return &CachedProvider{
+		provider:   provider,
+		source:     provider.Source(),
+		repository: repository,
+		ttl:        ttl,
+		logger:     logger,
+		now:        time.Now,
+		refreshing: make(map[string]bool),
+	}
+}
+
+func (c *CachedProvider) Source() domain.ExternalSource {
+	return c.source
+}
+
+// SearchGames always asks the provider, since searches are not keyed by an
+// external ID, but caches every game it returns.
+func (c *CachedProvider) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameMetadata, error) {
+	results, err := c.provider.SearchGames(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		c.store(ctx, result)
+	}
+
+	return results, nil
+}
+
+func (c *CachedProvider) GetGame(ctx context.Context, id string) (domain.GameMetadata, error) {
+	entry, err := c.repository.GetMetadataCacheEntry(ctx, c.source, id)
+	if errors.Is(err, domain.ErrMetadataNotFound) {
+		return c.fetch(ctx, id)
+	}
+	if err != nil {
+		return domain.GameMetadata{}, err
+	}
+
+	var cached payload
+	if err := json.Unmarshal(entry.Payload, &cached); err != nil {
+		c.logger.Warn("discarding unreadable metadata cache entry", "provider", c.source, "external_id", id, "err", err)
+		return c.fetch(ctx, id)
+	}
+
+	if c.now().Sub(entry.FetchedAt) > c.ttl {
+		c.refresh(ctx, id)
+	}
+
+	return cached.metadata(), nil
+}
+
+// Wait blocks until every background refresh has finished.
+func (c *CachedProvider) Wait() {
+	c.wg.Wait()
+}
+
+func (c *CachedProvider) fetch(ctx context.Context, id string) (domain.GameMetadata, error) {
+	metadata, err := c.provider.GetGame(ctx, id)
+	if err != nil {
+		return domain.GameMetadata{}, err
+	}
+
+	c.store(ctx, metadata)
+	return metadata, nil
+}
+
+func (c *CachedProvider) refresh(ctx context.Context, id string) {
+	c.mu.Lock()
+	if c.refreshing[id] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[id] = true
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, id)
+			c.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refreshTimeout)
+		defer cancel()
+
+		if _, err := c.fetch(ctx, id); err != nil {
+			c.logger.Warn("failed to refresh metadata", "provider", c.source, "external_id", id, "err", err)
+		}
+	}()
+}
+
+// store caches metadata. Failing to cache is logged rather than returned, the
+// caller already has what it asked for.
+func (c *CachedProvider) store(ctx context.Context, metadata domain.GameMetadata) {
+	raw, err := json.Marshal(newPayload(metadata))
+	if err == nil {
+		sum := sha256.Sum256(raw)
+		err = c.repository.UpsertMetadataCacheEntry(ctx, domain.MetadataCacheEntry{
+			Provider:   c.source,
+			ExternalID: metadata.SourceID,
+			Payload:    raw,
+			ETag:       hex.EncodeToString(sum[:16]),
+			FetchedAt:  c.now().UTC(),
+		})
+	}
+
+	if err != nil {
+		c.logger.Warn("failed to cache metadata", "provider", c.source, "external_id", metadata.SourceID, "err", err)
+	}
+}
+
+// payload is the stored form of domain.GameMetadata, kept separate so renaming
+// a domain field doesn't silently invalidate every cached entry.
+type payload struct {
+	Source      string              `json:"source"`
+	SourceID    string              `json:"source_id"`
+	Title       string              `json:"title"`
+	ReleaseDate time.Time           `json:"release_date"`
+	Summary     string              `json:"summary"`
+	Developer   string              `json:"developer"`
+	Publisher   string              `json:"publisher"`
+	CoverURL    string              `json:"cover_url"`
+	Platforms   []string            `json:"platforms"`
+	Genres      []string            `json:"genres"`
+	ExternalIDs []externalIDPayload `json:"external_ids"`
+}
+
+type externalIDPayload struct {
+	Source string `json:"source"`
+	ID     string `json:"id"`
+}
+
+func newPayload(metadata domain.GameMetadata) payload {
+	externalIDs := make([]externalIDPayload, len(metadata.ExternalIDs))
+	for i, externalID := range metadata.ExternalIDs {
+		externalIDs[i] = externalIDPayload{string(externalID.Source), externalID.ID}
+	}
+
+	return payload{
+		Source:      string(metadata.Source),
+		SourceID:    metadata.SourceID,
+		Title:       metadata.Title,
+		ReleaseDate: metadata.ReleaseDate,
+		Summary:     metadata.Summary,
+		Developer:   metadata.Developer,
+		Publisher:   metadata.Publisher,
+		CoverURL:    metadata.CoverURL,
+		Platforms:   metadata.Platforms,
+		Genres:      metadata.Genres,
+		ExternalIDs: externalIDs,
+	}
+}
+
+func (p payload) metadata() domain.GameMetadata {
+	var externalIDs []domain.ExternalID
+	for _, externalID := range p.ExternalIDs {
+		externalIDs = append(externalIDs, domain.ExternalID{
+			Source: domain.ExternalSource(externalID.Source),
+			ID:     externalID.ID,
+		})
+	}
+
+	return domain.GameMetadata{
+		Source:      domain.ExternalSource(p.Source),
+		SourceID:    p.SourceID,
+		Title:       p.Title,
+		ReleaseDate: p.ReleaseDate,
+		Summary:     p.Summary,
+		Developer:   p.Developer,
+		Publisher:   p.Publisher,
+		CoverURL:    p.CoverURL,
+		Platforms:   p.Platforms,
+		Genres:      p.Genres,
+		ExternalIDs: externalIDs,
+	}
+}
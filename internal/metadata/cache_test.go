@@ -0,0 +1,142 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var hades = domain.GameMetadata{
+	Source:      domain.ExternalSourceIGDB,
+	SourceID:    "113112",
+	Title:       "Hades",
+	ReleaseDate: time.Date(2020, time.September, 17, 0, 0, 0, 0, time.UTC),
+	Developer:   "Supergiant Games",
+	Platforms:   []string{"pc", "switch"},
+	Genres:      []string{"role-playing-rpg"},
+	ExternalIDs: []domain.ExternalID{
+		{Source: domain.ExternalSourceIGDB, ID: "113112"},
+		{Source: domain.ExternalSourceSteam, ID: "1145360"},
+	},
+}
+
+func newTestCache(provider *MockMetadataProvider, repo domain.MetadataCacheRepository, now time.Time) *CachedProvider {
+	provider.On("Source").Return(domain.ExternalSourceIGDB)
+	cache := NewCachedProvider(provider, repo, time.Hour, slog.New(slog.DiscardHandler))
+	cache.now = func() time.Time { return now }
+	return cache
+}
+
+func cachedEntry(t *testing.T, metadata domain.GameMetadata, fetchedAt time.Time) domain.MetadataCacheEntry {
+	t.Helper()
+
+	raw, err := json.Marshal(newPayload(metadata))
+	require.NoError(t, err)
+
+	return domain.MetadataCacheEntry{
+		Provider:   domain.ExternalSourceIGDB,
+		ExternalID: metadata.SourceID,
+		Payload:    raw,
+		FetchedAt:  fetchedAt,
+	}
+}
+
+func TestCachedProvider_MissFetchesAndStores(t *testing.T) {
+	provider := new(MockMetadataProvider)
+	repo := new(MockMetadataCacheRepository)
+	now := time.Date(2026, time.March, 9, 12, 0, 0, 0, time.UTC)
+	cache := newTestCache(provider, repo, now)
+	ctx := context.Background()
+
+	repo.On("GetMetadataCacheEntry", ctx, domain.ExternalSourceIGDB, "113112").
+		Return(domain.MetadataCacheEntry{}, domain.ErrMetadataNotFound)
+	provider.On("GetGame", ctx, "113112").Return(hades, nil)
+	repo.On("UpsertMetadataCacheEntry", ctx, mock.MatchedBy(func(entry domain.MetadataCacheEntry) bool {
+		return entry.ExternalID == "113112" && entry.FetchedAt.Equal(now) && entry.ETag != ""
+	})).Return(nil)
+
+	got, err := cache.GetGame(ctx, "113112")
+	require.NoError(t, err)
+	require.Equal(t, hades, got)
+
+	provider.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestCachedProvider_FreshEntrySkipsProvider(t *testing.T) {
+	provider := new(MockMetadataProvider)
+	repo := new(MockMetadataCacheRepository)
+	now := time.Date(2026, time.March, 9, 12, 0, 0, 0, time.UTC)
+	cache := newTestCache(provider, repo, now)
+	ctx := context.Background()
+
+	repo.On("GetMetadataCacheEntry", ctx, domain.ExternalSourceIGDB, "113112").
+		Return(cachedEntry(t, hades, now.Add(-time.Minute)), nil)
+
+	got, err := cache.GetGame(ctx, "113112")
+	require.NoError(t, err)
+	require.Equal(t, hades, got)
+
+	cache.Wait()
+	provider.AssertNotCalled(t, "GetGame", mock.Anything, mock.Anything)
+}
+
+func TestCachedProvider_StaleEntryServedWhileRefreshing(t *testing.T) {
+	provider := new(MockMetadataProvider)
+	repo := new(MockMetadataCacheRepository)
+	now := time.Date(2026, time.March, 9, 12, 0, 0, 0, time.UTC)
+	cache := newTestCache(provider, repo, now)
+	ctx := context.Background()
+
+	old := hades
+	old.Summary = "old summary"
+	refreshed := hades
+	refreshed.Summary = "new summary"
+
+	release := make(chan time.Time)
+	repo.On("GetMetadataCacheEntry", ctx, domain.ExternalSourceIGDB, "113112").
+		Return(cachedEntry(t, old, now.Add(-2*time.Hour)), nil)
+	provider.On("GetGame", mock.Anything, "113112").
+		WaitUntil(release).
+		Return(refreshed, nil).
+		Once()
+	repo.On("UpsertMetadataCacheEntry", mock.Anything, mock.MatchedBy(func(entry domain.MetadataCacheEntry) bool {
+		return entry.FetchedAt.Equal(now)
+	})).Return(nil).Once()
+
+	for range 3 {
+		got, err := cache.GetGame(ctx, "113112")
+		require.NoError(t, err)
+		require.Equal(t, "old summary", got.Summary)
+	}
+
+	close(release)
+	cache.Wait()
+
+	provider.AssertNumberOfCalls(t, "GetGame", 1)
+	repo.AssertExpectations(t)
+}
+
+func TestCachedProvider_SearchCachesResults(t *testing.T) {
+	provider := new(MockMetadataProvider)
+	repo := new(MockMetadataCacheRepository)
+	cache := newTestCache(provider, repo, time.Now())
+	ctx := context.Background()
+
+	provider.On("SearchGames", ctx, "hades", 5).Return([]domain.GameMetadata{hades}, nil)
+	repo.On("UpsertMetadataCacheEntry", ctx, mock.MatchedBy(func(entry domain.MetadataCacheEntry) bool {
+		var stored payload
+		return json.Unmarshal(entry.Payload, &stored) == nil && stored.metadata().Title == "Hades"
+	})).Return(nil)
+
+	got, err := cache.SearchGames(ctx, "hades", 5)
+	require.NoError(t, err)
+	require.Equal(t, []domain.GameMetadata{hades}, got)
+	repo.AssertExpectations(t)
+}
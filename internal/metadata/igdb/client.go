@@ -0,0 +1,310 @@
+package igdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+const (
+	// IGDB rejects clients that go over four requests per second.
+	requestsPerSecond = 4
+	// Tokens are refreshed this long before Twitch says they expire.
+	tokenExpiryMargin = time.Minute
+	coverURLFormat    = "https://images.igdb.com/igdb/image/upload/t_cover_big/%s.jpg"
+	steamGameSource   = 1
+)
+
+const gameFields = "fields name,summary,first_release_date,cover.image_id," +
+	"involved_companies.company.name,involved_companies.developer,involved_companies.publisher," +
+	"platforms.slug,genres.slug,external_games.external_game_source,external_games.uid;"
+
+// platformAliases maps IGDB platform slugs onto the names used in the catalog.
+var platformAliases = map[string]string{
+	"win": "pc",
+}
+
+type Config struct {
+	BaseURL      string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+}
+
+// Client queries the IGDB v4 API, authenticating through Twitch's
+// client-credentials flow. The access token is cached until shortly before it
+// expires and shared by every request.
+type Client struct {
+	config  Config
+	client  *http.Client
+	limiter *limiter
+	now     func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func NewClient(config Config, client *http.Client) *Client {
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+
+	return &Client{
+		config:  config,
+		client:  client,
+		limiter: newLimiter(requestsPerSecond),
+		now:     time.Now,
+	}
+}
+
+type game struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	Summary          string `json:"summary"`
+	FirstReleaseDate int64  `json:"first_release_date"`
+	Cover            struct {
+		ImageID string `json:"image_id"`
+	} `json:"cover"`
+	InvolvedCompanies []struct {
+		Company struct {
+			Name string `json:"name"`
+		} `json:"company"`
+		Developer bool `json:"developer"`
+		Publisher bool `json:"publisher"`
+	} `json:"involved_companies"`
+	Platforms []struct {
+		Slug string `json:"slug"`
+	} `json:"platforms"`
+	Genres []struct {
+		Slug string `json:"slug"`
+	} `json:"genres"`
+	ExternalGames []struct {
+		ExternalGameSource int    `json:"external_game_source"`
+		UID                string `json:"uid"`
+	} `json:"external_games"`
+}
+
+func (c *Client) Source() domain.ExternalSource {
+	return domain.ExternalSourceIGDB
+}
+
+func (c *Client) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameMetadata, error) {
+	var games []game
+	body := fmt.Sprintf("search %s; %s limit %d;", quote(query), gameFields, limit)
+	if err := c.query(ctx, "/games", body, &games); err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.GameMetadata, len(games))
+	for i, g := range games {
+		results[i] = toMetadata(g)
+	}
+
+	return results, nil
+}
+
+func (c *Client) GetGame(ctx context.Context, id string) (domain.GameMetadata, error) {
+	igdbID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return domain.GameMetadata{}, fmt.Errorf("igdb id %q: %w", id, domain.ErrMetadataNotFound)
+	}
+
+	var games []game
+	body := fmt.Sprintf("%s where id = %d; limit 1;", gameFields, igdbID)
+	if err := c.query(ctx, "/games", body, &games); err != nil {
+		return domain.GameMetadata{}, err
+	}
+
+	if len(games) == 0 {
+		return domain.GameMetadata{}, domain.ErrMetadataNotFound
+	}
+
+	return toMetadata(games[0]), nil
+}
+
+func (c *Client) query(ctx context.Context, endpoint string, body string, v any) error {
+	for retried := false; ; retried = true {
+		token, err := c.accessToken(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+endpoint, strings.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("build igdb request: %w", err)
+		}
+		req.Header.Set("Client-ID", c.config.ClientID)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "text/plain")
+
+		res, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("call igdb %s: %w", endpoint, err)
+		}
+
+		// A revoked token is only noticed when IGDB rejects it; fetch a new
+		// one and try again once.
+		if res.StatusCode == http.StatusUnauthorized && !retried {
+			_ = res.Body.Close()
+			c.invalidateToken(token)
+			continue
+		}
+
+		err = decode(res, endpoint, v)
+		_ = res.Body.Close()
+		return err
+	}
+}
+
+func decode(res *http.Response, endpoint string, v any) error {
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("call igdb %s: unexpected status %d", endpoint, res.StatusCode)
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode igdb %s: %w", endpoint, err)
+	}
+
+	return nil
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && c.now().Before(c.expiresAt) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"client_id":     {c.config.ClientID},
+		"client_secret": {c.config.ClientSecret},
+		"grant_type":    {"client_credentials"},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("build twitch token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("call twitch token: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("call twitch token: unexpected status %d", res.StatusCode)
+	}
+
+	var body tokenResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("decode twitch token: %w", err)
+	}
+
+	c.token = body.AccessToken
+	c.expiresAt = c.now().Add(time.Duration(body.ExpiresIn)*time.Second - tokenExpiryMargin)
+
+	return c.token, nil
+}
+
+func (c *Client) invalidateToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == token {
+		c.token = ""
+	}
+}
+
+// quote renders s as an Apicalypse string literal.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func toMetadata(g game) domain.GameMetadata {
+	id := strconv.FormatInt(g.ID, 10)
+	metadata := domain.GameMetadata{
+		Source:      domain.ExternalSourceIGDB,
+		SourceID:    id,
+		Title:       g.Name,
+		Summary:     g.Summary,
+		ExternalIDs: []domain.ExternalID{{Source: domain.ExternalSourceIGDB, ID: id}},
+	}
+
+	if g.FirstReleaseDate != 0 {
+		metadata.ReleaseDate = time.Unix(g.FirstReleaseDate, 0).UTC().Truncate(24 * time.Hour)
+	}
+
+	if g.Cover.ImageID != "" {
+		metadata.CoverURL = fmt.Sprintf(coverURLFormat, g.Cover.ImageID)
+	}
+
+	for _, involved := range g.InvolvedCompanies {
+		if involved.Developer && metadata.Developer == "" {
+			metadata.Developer = involved.Company.Name
+		}
+		if involved.Publisher && metadata.Publisher == "" {
+			metadata.Publisher = involved.Company.Name
+		}
+	}
+
+	for _, platform := range g.Platforms {
+		slug := platform.Slug
+		if alias, ok := platformAliases[slug]; ok {
+			slug = alias
+		}
+		metadata.Platforms = appendUnique(metadata.Platforms, slug)
+	}
+
+	for _, genre := range g.Genres {
+		metadata.Genres = appendUnique(metadata.Genres, genre.Slug)
+	}
+
+	for _, external := range g.ExternalGames {
+		if external.ExternalGameSource == steamGameSource && external.UID != "" {
+			metadata.ExternalIDs = append(metadata.ExternalIDs, domain.ExternalID{
+				Source: domain.ExternalSourceSteam,
+				ID:     external.UID,
+			})
+		}
+	}
+
+	return metadata
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
@@ -0,0 +1,194 @@
+package igdb
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+const witcherJSON = `[{
+	"id": 1942,
+	"name": "The Witcher 3: Wild Hunt",
+	"summary": "Geralt hunts monsters.",
+	"first_release_date": 1431993600,
+	"cover": {"id": 1, "image_id": "co1wyy"},
+	"involved_companies": [
+		{"company": {"name": "Bandai Namco"}, "developer": false, "publisher": true},
+		{"company": {"name": "CD Projekt RED"}, "developer": true, "publisher": false}
+	],
+	"platforms": [{"slug": "win"}, {"slug": "ps4--1"}, {"slug": "win"}],
+	"genres": [{"slug": "role-playing-rpg"}],
+	"external_games": [
+		{"external_game_source": 1, "uid": "292030"},
+		{"external_game_source": 5, "uid": "1207664643"}
+	]
+}]`
+
+type fakeIGDB struct {
+	server      *httptest.Server
+	tokenCalls  atomic.Int32
+	gamesCalls  atomic.Int32
+	rejectToken atomic.Value
+	lastBody    atomic.Value
+}
+
+func newFakeIGDB(t *testing.T) *fakeIGDB {
+	t.Helper()
+
+	fake := &fakeIGDB{}
+	fake.rejectToken.Store("")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client", r.Form.Get("client_id"))
+		require.Equal(t, "secret", r.Form.Get("client_secret"))
+		require.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+
+		n := fake.tokenCalls.Add(1)
+		_, _ = w.Write([]byte(`{"access_token":"token-` + string(rune('0'+n)) + `","expires_in":3600,"token_type":"bearer"}`))
+	})
+	mux.HandleFunc("POST /v4/games", func(w http.ResponseWriter, r *http.Request) {
+		fake.gamesCalls.Add(1)
+		require.Equal(t, "client", r.Header.Get("Client-ID"))
+
+		rejected := fake.rejectToken.Load().(string)
+		if rejected == "*" || r.Header.Get("Authorization") == "Bearer "+rejected {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		fake.lastBody.Store(string(body))
+
+		if string(body) == gameFields+" where id = 404; limit 1;" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+		_, _ = w.Write([]byte(witcherJSON))
+	})
+
+	fake.server = httptest.NewServer(mux)
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+func (f *fakeIGDB) client() *Client {
+	return NewClient(Config{
+		BaseURL:      f.server.URL + "/v4/",
+		TokenURL:     f.server.URL + "/oauth2/token",
+		ClientID:     "client",
+		ClientSecret: "secret",
+	}, f.server.Client())
+}
+
+func TestClient_SearchGames(t *testing.T) {
+	fake := newFakeIGDB(t)
+	client := fake.client()
+
+	games, err := client.SearchGames(context.Background(), `witcher "3"`, 5)
+	require.NoError(t, err)
+	require.Equal(t, `search "witcher \"3\""; `+gameFields+` limit 5;`, fake.lastBody.Load())
+
+	require.Equal(t, []domain.GameMetadata{{
+		Source:      domain.ExternalSourceIGDB,
+		SourceID:    "1942",
+		Title:       "The Witcher 3: Wild Hunt",
+		ReleaseDate: time.Date(2015, time.May, 19, 0, 0, 0, 0, time.UTC),
+		Summary:     "Geralt hunts monsters.",
+		Developer:   "CD Projekt RED",
+		Publisher:   "Bandai Namco",
+		CoverURL:    "https://images.igdb.com/igdb/image/upload/t_cover_big/co1wyy.jpg",
+		Platforms:   []string{"pc", "ps4--1"},
+		Genres:      []string{"role-playing-rpg"},
+		ExternalIDs: []domain.ExternalID{
+			{Source: domain.ExternalSourceIGDB, ID: "1942"},
+			{Source: domain.ExternalSourceSteam, ID: "292030"},
+		},
+	}}, games)
+}
+
+func TestClient_GetGame(t *testing.T) {
+	fake := newFakeIGDB(t)
+	client := fake.client()
+
+	game, err := client.GetGame(context.Background(), "1942")
+	require.NoError(t, err)
+	require.Equal(t, "1942", game.SourceID)
+	require.Equal(t, gameFields+" where id = 1942; limit 1;", fake.lastBody.Load())
+
+	_, err = client.GetGame(context.Background(), "404")
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+
+	_, err = client.GetGame(context.Background(), "not-a-number")
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+}
+
+func TestClient_CachesAndRefreshesToken(t *testing.T) {
+	fake := newFakeIGDB(t)
+	client := fake.client()
+
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	for range 3 {
+		_, err := client.GetGame(context.Background(), "1942")
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), fake.tokenCalls.Load())
+
+	now = now.Add(time.Hour - tokenExpiryMargin/2)
+	_, err := client.GetGame(context.Background(), "1942")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), fake.tokenCalls.Load())
+}
+
+func TestClient_RetriesOnceWithFreshToken(t *testing.T) {
+	fake := newFakeIGDB(t)
+	client := fake.client()
+
+	_, err := client.GetGame(context.Background(), "1942")
+	require.NoError(t, err)
+
+	fake.rejectToken.Store("token-1")
+	_, err = client.GetGame(context.Background(), "1942")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), fake.tokenCalls.Load())
+	require.Equal(t, int32(3), fake.gamesCalls.Load())
+
+	fake.rejectToken.Store("*")
+	_, err = client.GetGame(context.Background(), "1942")
+	require.ErrorContains(t, err, "unexpected status 401")
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	fake := newFakeIGDB(t)
+	client := fake.client()
+
+	start := time.Now()
+	for range 5 {
+		_, err := client.GetGame(context.Background(), "1942")
+		require.NoError(t, err)
+	}
+
+	// Five requests at four per second need at least a full second.
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestLimiter_HonoursContext(t *testing.T) {
+	l := newLimiter(1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
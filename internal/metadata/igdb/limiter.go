@@ -0,0 +1,45 @@
+package igdb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter spaces requests evenly so no more than rate of them start per
+// second. Callers reserve the next free slot and sleep until it arrives.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newLimiter(rate int) *limiter {
+	return &limiter{interval: time.Second / time.Duration(rate)}
+}
+
+func (l *limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
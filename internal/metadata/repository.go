@@ -0,0 +1,51 @@
+package metadata
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+type repository struct {
+	db *sqlc.Queries
+}
+
+func NewRepository(q *sqlc.Queries) domain.MetadataCacheRepository {
+	return &repository{q}
+}
+
+func (r *repository) GetMetadataCacheEntry(
+	ctx context.Context,
+	provider domain.ExternalSource,
+	externalID string,
+) (domain.MetadataCacheEntry, error) {
+	entry, err := r.db.GetMetadataCacheEntry(ctx, sqlc.GetMetadataCacheEntryParams{
+		Provider:   string(provider),
+		ExternalID: externalID,
+	})
+	if err != nil {
+		return domain.MetadataCacheEntry{}, postgres.TranslateError(err, domain.ErrMetadataNotFound)
+	}
+
+	return domain.MetadataCacheEntry{
+		Provider:   domain.ExternalSource(entry.Provider),
+		ExternalID: entry.ExternalID,
+		Payload:    entry.Payload,
+		ETag:       entry.Etag,
+		FetchedAt:  entry.FetchedAt.Time,
+	}, nil
+}
+
+func (r *repository) UpsertMetadataCacheEntry(ctx context.Context, entry domain.MetadataCacheEntry) error {
+	err := r.db.UpsertMetadataCacheEntry(ctx, sqlc.UpsertMetadataCacheEntryParams{
+		Provider:   string(entry.Provider),
+		ExternalID: entry.ExternalID,
+		Payload:    entry.Payload,
+		Etag:       entry.ETag,
+		FetchedAt:  pgtype.Timestamptz{Time: entry.FetchedAt, Valid: true},
+	})
+	return postgres.TranslateError(err, nil)
+}
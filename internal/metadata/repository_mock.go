@@ -0,0 +1,49 @@
+package metadata
+
+import (
+	"context"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMetadataCacheRepository struct {
+	mock.Mock
+}
+
+func NewMockMetadataCacheRepository() domain.MetadataCacheRepository {
+	return new(MockMetadataCacheRepository)
+}
+
+func (m *MockMetadataCacheRepository) GetMetadataCacheEntry(
+	ctx context.Context,
+	provider domain.ExternalSource,
+	externalID string,
+) (domain.MetadataCacheEntry, error) {
+	args := m.Called(ctx, provider, externalID)
+	return args.Get(0).(domain.MetadataCacheEntry), args.Error(1)
+}
+
+func (m *MockMetadataCacheRepository) UpsertMetadataCacheEntry(ctx context.Context, entry domain.MetadataCacheEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+type MockMetadataProvider struct {
+	mock.Mock
+}
+
+func (m *MockMetadataProvider) Source() domain.ExternalSource {
+	args := m.Called()
+	return args.Get(0).(domain.ExternalSource)
+}
+
+func (m *MockMetadataProvider) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameMetadata, error) {
+	args := m.Called(ctx, query, limit)
+	return args.Get(0).([]domain.GameMetadata), args.Error(1)
+}
+
+func (m *MockMetadataProvider) GetGame(ctx context.Context, id string) (domain.GameMetadata, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.GameMetadata), args.Error(1)
+}
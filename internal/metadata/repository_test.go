@@ -0,0 +1,78 @@
+package metadata
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/testutils"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+	"github.com/stretchr/testify/require"
+)
+
+var testQueries *sqlc.Queries
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dsn, terminate, err := testutils.StartPostgresContainer(ctx)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	testDB := postgres.MustConnect(ctx, dsn, nil)
+	gooseProvider := migrations.MustProvide(testDB)
+	testQueries = sqlc.New(testDB)
+
+	_, err = gooseProvider.Up(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	exitCode := m.Run()
+
+	if err := terminate(context.Background()); err != nil {
+		log.Println(err)
+	}
+
+	os.Exit(exitCode)
+}
+
+func TestRepository_MetadataCacheEntry(t *testing.T) {
+	repo := NewRepository(testQueries)
+	ctx := context.Background()
+
+	_, err := repo.GetMetadataCacheEntry(ctx, domain.ExternalSourceIGDB, "1942")
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+
+	fetchedAt := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(t, repo.UpsertMetadataCacheEntry(ctx, domain.MetadataCacheEntry{
+		Provider:   domain.ExternalSourceIGDB,
+		ExternalID: "1942",
+		Payload:    []byte(`{"title":"The Witcher 3"}`),
+		ETag:       "v1",
+		FetchedAt:  fetchedAt.Add(-time.Hour),
+	}))
+	require.NoError(t, repo.UpsertMetadataCacheEntry(ctx, domain.MetadataCacheEntry{
+		Provider:   domain.ExternalSourceIGDB,
+		ExternalID: "1942",
+		Payload:    []byte(`{"title":"The Witcher 3: Wild Hunt"}`),
+		ETag:       "v2",
+		FetchedAt:  fetchedAt,
+	}))
+
+	got, err := repo.GetMetadataCacheEntry(ctx, domain.ExternalSourceIGDB, "1942")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"title":"The Witcher 3: Wild Hunt"}`, string(got.Payload))
+	require.Equal(t, "v2", got.ETag)
+	require.True(t, fetchedAt.Equal(got.FetchedAt))
+
+	_, err = repo.GetMetadataCacheEntry(ctx, domain.ExternalSourceSteam, "1942")
+	require.ErrorIs(t, err, domain.ErrMetadataNotFound)
+}
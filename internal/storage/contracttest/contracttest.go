@@ -0,0 +1,739 @@
+// Package contracttest holds the behaviour every storage backend must share.
+// Backends run it from their own tests with a factory that hands out
+// repositories over storage the suite may write to.
+package contracttest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+type Repositories struct {
+	Games    domain.GameRepository
+	Accounts domain.AccountRepository
+	// Library is nil for backends without one, which skip its cases.
+	Library domain.LibraryRepository
+}
+
+// Factory is called once per test. Backends may share storage between calls,
+// so the suite never assumes it starts from an empty database.
+type Factory func(t *testing.T) Repositories
+
+func Run(t *testing.T, factory Factory) {
+	t.Run("games", func(t *testing.T) {
+		RunGameRepository(t, factory)
+	})
+	t.Run("accounts", func(t *testing.T) {
+		RunAccountRepository(t, factory)
+	})
+	t.Run("library", func(t *testing.T) {
+		RunLibraryRepository(t, factory)
+	})
+}
+
+func RunGameRepository(t *testing.T, factory Factory) {
+	t.Run("create and get", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+
+		created, err := repo.CreateGame(ctx, domain.Game{
+			Title:       "Hollow Knight",
+			ReleaseDate: time.Date(2017, time.February, 24, 0, 0, 0, 0, time.UTC),
+			Summary:     "A challenging 2D action-adventure.",
+			Developer:   "Team Cherry",
+			Publisher:   "Team Cherry",
+			CoverURL:    "https://example.com/hollow-knight.png",
+			Platforms:   []string{"switch", "pc"},
+			Genres:      []string{"metroidvania"},
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, created.ID)
+		require.False(t, created.InsertedAt.IsZero())
+
+		got, err := repo.GetGameByID(ctx, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Hollow Knight", got.Title)
+		require.True(t, created.ReleaseDate.Equal(got.ReleaseDate))
+		require.Equal(t, "Team Cherry", got.Developer)
+		require.Equal(t, "https://example.com/hollow-knight.png", got.CoverURL)
+		require.Equal(t, []string{"pc", "switch"}, got.Platforms)
+		require.Equal(t, []string{"metroidvania"}, got.Genres)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+
+		word := uniqueWord()
+
+		created, err := repo.CreateGame(ctx, domain.Game{Title: "Celeste", Platforms: []string{"pc"}})
+		require.NoError(t, err)
+
+		created.Title = "Celeste: " + word
+		created.Platforms = []string{"switch"}
+		created.Genres = []string{"platformer"}
+		updated, err := repo.UpdateGame(ctx, created)
+		require.NoError(t, err)
+		require.Equal(t, "Celeste: "+word, updated.Title)
+
+		got, err := repo.GetGameByID(ctx, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Celeste: "+word, got.Title)
+		require.Equal(t, []string{"switch"}, got.Platforms)
+		require.Equal(t, []string{"platformer"}, got.Genres)
+
+		byTitle, err := repo.GetGameByNormalizedTitle(ctx, "celeste "+word)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, byTitle.ID)
+	})
+
+	t.Run("creator survives updates", func(t *testing.T) {
+		repos := factory(t)
+		ctx := context.Background()
+
+		creator, err := repos.Accounts.CreateAccount(ctx, domain.Account{
+			Nickname:       "sheik",
+			Email:          uniqueWord() + "@example.com",
+			HashedPassword: "hashed",
+		})
+		require.NoError(t, err)
+
+		word := uniqueWord()
+		created, err := repos.Games.CreateGame(ctx, domain.Game{Title: "Tunic " + word, CreatedBy: creator.ID})
+		require.NoError(t, err)
+		require.Equal(t, creator.ID, created.CreatedBy)
+
+		created.CreatedBy = uuid.Nil
+		updated, err := repos.Games.UpdateGame(ctx, created)
+		require.NoError(t, err)
+		require.Equal(t, creator.ID, updated.CreatedBy)
+
+		page, err := repos.Games.ListGames(ctx, domain.ListGamesParams{
+			Sort:        domain.GameSortTitle,
+			TitlePrefix: "Tunic " + word,
+			Limit:       10,
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Games, 1)
+		require.Equal(t, creator.ID, page.Games[0].CreatedBy)
+
+		unowned, err := repos.Games.CreateGame(ctx, domain.Game{Title: "Fez"})
+		require.NoError(t, err)
+
+		got, err := repos.Games.GetGameByID(ctx, unowned.ID)
+		require.NoError(t, err)
+		require.Equal(t, uuid.Nil, got.CreatedBy)
+	})
+
+	t.Run("list pages through a filter", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+		prefix := uniqueWord()
+
+		for _, suffix := range []string{"c", "a", "b"} {
+			_, err := repo.CreateGame(ctx, domain.Game{Title: prefix + " " + suffix})
+			require.NoError(t, err)
+		}
+
+		params := domain.ListGamesParams{Sort: domain.GameSortTitle, TitlePrefix: prefix, Limit: 2}
+		first, err := repo.ListGames(ctx, params)
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + " a", prefix + " b"}, titles(first.Games))
+		require.NotNil(t, first.Next)
+
+		params.After = first.Next
+		second, err := repo.ListGames(ctx, params)
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + " c"}, titles(second.Games))
+		require.Nil(t, second.Next)
+
+		params.After = nil
+		params.Descending = true
+		params.Limit = 10
+		descending, err := repo.ListGames(ctx, params)
+		require.NoError(t, err)
+		require.Equal(t, []string{prefix + " c", prefix + " b", prefix + " a"}, titles(descending.Games))
+	})
+
+	t.Run("list sorts missing release dates last", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+		genre := uniqueWord()
+
+		for i, title := range []string{"Undated", "Older", "Newer"} {
+			game := domain.Game{Title: title, Genres: []string{genre}}
+			if i > 0 {
+				game.ReleaseDate = time.Date(2000+i, time.January, 1, 0, 0, 0, 0, time.UTC)
+			}
+			_, err := repo.CreateGame(ctx, game)
+			require.NoError(t, err)
+		}
+
+		for descending, want := range map[bool][]string{
+			false: {"Older", "Newer", "Undated"},
+			true:  {"Newer", "Older", "Undated"},
+		} {
+			params := domain.ListGamesParams{Sort: domain.GameSortReleaseDate, Descending: descending, Genre: genre, Limit: 1}
+			var got []string
+			for {
+				page, err := repo.ListGames(ctx, params)
+				require.NoError(t, err)
+				got = append(got, titles(page.Games)...)
+				if page.Next == nil {
+					break
+				}
+				params.After = page.Next
+			}
+			require.Equal(t, want, got)
+		}
+	})
+
+	t.Run("search highlights matches", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+		word := uniqueWord()
+
+		created, err := repo.CreateGame(ctx, domain.Game{Title: "The " + word + " Chronicles"})
+		require.NoError(t, err)
+
+		results, err := repo.SearchGames(ctx, word, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, created.ID, results[0].Game.ID)
+		require.Contains(t, results[0].TitleSnippet, "<mark>"+word+"</mark>")
+	})
+
+	t.Run("external ids", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+		appID := uniqueWord()
+
+		created, err := repo.CreateGame(ctx, domain.Game{Title: "Disco Elysium"})
+		require.NoError(t, err)
+
+		steamID := domain.ExternalID{Source: domain.ExternalSourceSteam, ID: appID}
+		require.NoError(t, repo.AddExternalIDs(ctx, created.ID, []domain.ExternalID{steamID}))
+		require.NoError(t, repo.AddExternalIDs(ctx, created.ID, []domain.ExternalID{steamID}))
+
+		got, err := repo.GetGameByExternalID(ctx, steamID)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, got.ID)
+
+		ids, err := repo.ListExternalIDs(ctx, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, []domain.ExternalID{steamID}, ids)
+	})
+
+	t.Run("create with external ids", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+
+		title := "Celeste " + uniqueWord()
+		steamID := domain.ExternalID{Source: domain.ExternalSourceSteam, ID: uniqueWord()}
+		created, err := repo.CreateGameWithExternalIDs(ctx, domain.Game{Title: title}, []domain.ExternalID{steamID})
+		require.NoError(t, err)
+
+		got, err := repo.GetGameByExternalID(ctx, steamID)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, got.ID)
+
+		// A second import of the same game gets the first one back instead of
+		// a duplicate.
+		again, err := repo.CreateGameWithExternalIDs(ctx, domain.Game{Title: title}, []domain.ExternalID{steamID})
+		require.NoError(t, err)
+		require.Equal(t, created.ID, again.ID)
+
+		page, err := repo.ListGames(ctx, domain.ListGamesParams{Sort: domain.GameSortTitle, TitlePrefix: title, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, page.Games, 1)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+
+		source, err := repo.CreateGame(ctx, domain.Game{Title: "Outer Wilds"})
+		require.NoError(t, err)
+		target, err := repo.CreateGame(ctx, domain.Game{Title: "Outer Wilds (2019)"})
+		require.NoError(t, err)
+
+		steamID := domain.ExternalID{Source: domain.ExternalSourceSteam, ID: uniqueWord()}
+		require.NoError(t, repo.AddExternalIDs(ctx, source.ID, []domain.ExternalID{steamID}))
+
+		target.Platforms = []string{"pc"}
+		merged, err := repo.MergeGames(ctx, source.ID, target)
+		require.NoError(t, err)
+		require.Equal(t, target.ID, merged.ID)
+
+		_, err = repo.GetGameByID(ctx, source.ID)
+		require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+		got, err := repo.GetGameByExternalID(ctx, steamID)
+		require.NoError(t, err)
+		require.Equal(t, target.ID, got.ID)
+		require.Equal(t, []string{"pc"}, got.Platforms)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+
+		_, err := repo.GetGameByID(ctx, uuid.New())
+		require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+		_, err = repo.GetGameByNormalizedTitle(ctx, uniqueWord())
+		require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+		_, err = repo.GetGameByExternalID(ctx, domain.ExternalID{Source: domain.ExternalSourceSteam, ID: uniqueWord()})
+		require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+		_, err = repo.UpdateGame(ctx, domain.Game{ID: uuid.New(), Title: "Missing"})
+		require.ErrorIs(t, err, domain.ErrGameNotFound)
+
+		results, err := repo.SearchGames(ctx, uniqueWord(), 10)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("external ids keep their first game", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+
+		first, err := repo.CreateGame(ctx, domain.Game{Title: "Hades"})
+		require.NoError(t, err)
+		second, err := repo.CreateGame(ctx, domain.Game{Title: "Hades II"})
+		require.NoError(t, err)
+
+		steamID := domain.ExternalID{Source: domain.ExternalSourceSteam, ID: uniqueWord()}
+		require.NoError(t, repo.AddExternalIDs(ctx, first.ID, []domain.ExternalID{steamID}))
+		require.NoError(t, repo.AddExternalIDs(ctx, second.ID, []domain.ExternalID{steamID}))
+
+		got, err := repo.GetGameByExternalID(ctx, steamID)
+		require.NoError(t, err)
+		require.Equal(t, first.ID, got.ID)
+
+		ids, err := repo.ListExternalIDs(ctx, second.ID)
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		repo := factory(t).Games
+		ctx := context.Background()
+
+		created, err := repo.CreateGame(ctx, domain.Game{Title: "Braid"})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DeleteGameByID(ctx, created.ID))
+
+		_, err = repo.GetGameByID(ctx, created.ID)
+		require.ErrorIs(t, err, domain.ErrGameNotFound)
+	})
+}
+
+func RunAccountRepository(t *testing.T, factory Factory) {
+	t.Run("create and get by email", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+		email := uniqueWord() + "@example.com"
+
+		created, err := repo.CreateAccount(ctx, domain.Account{
+			Nickname:       "link",
+			Email:          email,
+			HashedPassword: "hashed",
+		})
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, created.ID)
+
+		got, err := repo.GetAccountByEmail(ctx, email)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, got.ID)
+		require.Equal(t, "link", got.Nickname)
+		require.Equal(t, "hashed", got.HashedPassword)
+	})
+
+	t.Run("email is unique", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+		email := uniqueWord() + "@example.com"
+
+		_, err := repo.CreateAccount(ctx, domain.Account{Nickname: "samus", Email: email, HashedPassword: "hashed"})
+		require.NoError(t, err)
+
+		_, err = repo.CreateAccount(ctx, domain.Account{Nickname: "ridley", Email: email, HashedPassword: "hashed"})
+		require.ErrorIs(t, err, domain.ErrEmailTaken)
+
+		got, err := repo.GetAccountByEmail(ctx, email)
+		require.NoError(t, err)
+		require.Equal(t, "samus", got.Nickname)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		repo := factory(t).Accounts
+
+		_, err := repo.GetAccountByEmail(context.Background(), uniqueWord()+"@example.com")
+		require.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("sessions", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		account, err := repo.CreateAccount(ctx, domain.Account{
+			Nickname:       "zelda",
+			Email:          uniqueWord() + "@example.com",
+			HashedPassword: "hashed",
+		})
+		require.NoError(t, err)
+
+		token := uniqueWord()
+		require.NoError(t, repo.CreateSession(ctx, account.ID, token, time.Now().Add(time.Hour)))
+
+		got, err := repo.GetSessionAccount(ctx, token)
+		require.NoError(t, err)
+		require.Equal(t, account.ID, got.ID)
+		require.Equal(t, auth.RoleMember, got.Role)
+
+		require.NoError(t, repo.DeleteSession(ctx, token))
+
+		_, err = repo.GetSessionAccount(ctx, token)
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
+	t.Run("expired session", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		account, err := repo.CreateAccount(ctx, domain.Account{
+			Nickname:       "ganon",
+			Email:          uniqueWord() + "@example.com",
+			HashedPassword: "hashed",
+		})
+		require.NoError(t, err)
+
+		token := uniqueWord()
+		require.NoError(t, repo.CreateSession(ctx, account.ID, token, time.Now().Add(-time.Minute)))
+
+		_, err = repo.GetSessionAccount(ctx, token)
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		repo := factory(t).Accounts
+
+		_, err := repo.GetSessionAccount(context.Background(), uniqueWord())
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+	})
+
+	t.Run("count active sessions", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		account, err := repo.CreateAccount(ctx, domain.Account{Nickname: "sheik", Email: uniqueWord() + "@example.com", HashedPassword: "hashed"})
+		require.NoError(t, err)
+
+		before, err := repo.CountActiveSessions(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.CreateSession(ctx, account.ID, uniqueWord(), time.Now().Add(time.Hour)))
+		require.NoError(t, repo.CreateSession(ctx, account.ID, uniqueWord(), time.Now().Add(-time.Minute)))
+
+		after, err := repo.CountActiveSessions(ctx)
+		require.NoError(t, err)
+		require.Equal(t, before+1, after)
+	})
+
+	t.Run("get by id", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		account, err := repo.CreateAccount(ctx, domain.Account{
+			Nickname:       "link",
+			Email:          uniqueWord() + "@example.com",
+			HashedPassword: "hashed",
+			Role:           auth.RoleAdmin,
+		})
+		require.NoError(t, err)
+
+		got, err := repo.GetAccountByID(ctx, account.ID)
+		require.NoError(t, err)
+		require.Equal(t, account.Email, got.Email)
+		require.Equal(t, auth.RoleAdmin, got.Role)
+		require.True(t, got.DisabledAt.IsZero())
+
+		_, err = repo.GetAccountByID(ctx, uuid.New())
+		require.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("list in registration order", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		first, err := repo.CreateAccount(ctx, domain.Account{Nickname: "mario", Email: uniqueWord() + "@example.com", HashedPassword: "hashed"})
+		require.NoError(t, err)
+		second, err := repo.CreateAccount(ctx, domain.Account{Nickname: "luigi", Email: uniqueWord() + "@example.com", HashedPassword: "hashed"})
+		require.NoError(t, err)
+
+		accounts, err := repo.ListAccounts(ctx)
+		require.NoError(t, err)
+
+		count, err := repo.CountAccounts(ctx)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, count, len(accounts))
+
+		positions := map[uuid.UUID]int{}
+		for i, account := range accounts {
+			positions[account.ID] = i
+		}
+		require.Contains(t, positions, first.ID)
+		require.Contains(t, positions, second.ID)
+		require.Less(t, positions[first.ID], positions[second.ID])
+	})
+
+	t.Run("update role", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		account, err := repo.CreateAccount(ctx, domain.Account{Nickname: "peach", Email: uniqueWord() + "@example.com", HashedPassword: "hashed"})
+		require.NoError(t, err)
+		require.Equal(t, auth.RoleMember, account.Role)
+
+		promoted, err := repo.UpdateAccountRole(ctx, account.ID, auth.RoleAdmin)
+		require.NoError(t, err)
+		require.Equal(t, auth.RoleAdmin, promoted.Role)
+
+		_, err = repo.UpdateAccountRole(ctx, uuid.New(), auth.RoleAdmin)
+		require.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		account, err := repo.CreateAccount(ctx, domain.Account{Nickname: "bowser", Email: uniqueWord() + "@example.com", HashedPassword: "hashed"})
+		require.NoError(t, err)
+
+		token := uniqueWord()
+		require.NoError(t, repo.CreateSession(ctx, account.ID, token, time.Now().Add(time.Hour)))
+
+		disabled, err := repo.DisableAccount(ctx, account.ID)
+		require.NoError(t, err)
+		require.False(t, disabled.DisabledAt.IsZero())
+
+		_, err = repo.GetSessionAccount(ctx, token)
+		require.ErrorIs(t, err, domain.ErrSessionNotFound)
+
+		again, err := repo.DisableAccount(ctx, account.ID)
+		require.NoError(t, err)
+		require.True(t, disabled.DisabledAt.Equal(again.DisabledAt))
+
+		_, err = repo.DisableAccount(ctx, uuid.New())
+		require.ErrorIs(t, err, domain.ErrAccountNotFound)
+	})
+
+	t.Run("accounts lock serializes callers", func(t *testing.T) {
+		repo := factory(t).Accounts
+		ctx := context.Background()
+
+		const callers = 5
+		counts := make(chan int, callers)
+		var wg sync.WaitGroup
+		for range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := repo.WithAccountsLock(ctx, func(repo domain.AccountRepository) error {
+					count, err := repo.CountAccounts(ctx)
+					if err != nil {
+						return err
+					}
+					counts <- count
+
+					_, err = repo.CreateAccount(ctx, domain.Account{Nickname: "toad", Email: uniqueWord() + "@example.com", HashedPassword: "hashed"})
+					return err
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		close(counts)
+
+		seen := map[int]bool{}
+		for count := range counts {
+			require.False(t, seen[count], "two callers counted %d accounts", count)
+			seen[count] = true
+		}
+		require.Len(t, seen, callers)
+	})
+}
+
+func RunLibraryRepository(t *testing.T, factory Factory) {
+	// setup returns the library together with an account and a game it may
+	// reference.
+	setup := func(t *testing.T) (domain.LibraryRepository, domain.Account, domain.Game) {
+		repos := factory(t)
+		if repos.Library == nil {
+			t.Skip("backend has no library")
+		}
+		ctx := context.Background()
+
+		account, err := repos.Accounts.CreateAccount(ctx, domain.Account{
+			Nickname:       "library",
+			Email:          uniqueWord() + "@example.com",
+			HashedPassword: "hashed",
+		})
+		require.NoError(t, err)
+
+		game, err := repos.Games.CreateGame(ctx, domain.Game{Title: "Okami"})
+		require.NoError(t, err)
+
+		return repos.Library, account, game
+	}
+
+	t.Run("create, get and list", func(t *testing.T) {
+		repo, account, game := setup(t)
+		ctx := context.Background()
+
+		created, err := repo.CreateLibraryEntry(ctx, domain.LibraryEntry{
+			AccountID: account.ID,
+			GameID:    game.ID,
+			Status:    domain.LibraryStatusBacklog,
+			Rating:    7,
+		}, nil)
+		require.NoError(t, err)
+		require.NotEqual(t, uuid.Nil, created.ID)
+		require.Equal(t, "Okami", created.GameTitle)
+		require.False(t, created.InsertedAt.IsZero())
+
+		got, err := repo.GetLibraryEntry(ctx, account.ID, created.ID)
+		require.NoError(t, err)
+		require.Equal(t, created, got)
+
+		_, err = repo.GetLibraryEntry(ctx, uuid.New(), created.ID)
+		require.ErrorIs(t, err, domain.ErrLibraryEntryNotFound)
+
+		entries, err := repo.ListLibraryEntries(ctx, account.ID)
+		require.NoError(t, err)
+		require.Equal(t, []domain.LibraryEntry{created}, entries)
+	})
+
+	t.Run("one entry per game", func(t *testing.T) {
+		repo, account, game := setup(t)
+		ctx := context.Background()
+		entry := domain.LibraryEntry{AccountID: account.ID, GameID: game.ID, Status: domain.LibraryStatusBacklog}
+
+		_, err := repo.CreateLibraryEntry(ctx, entry, nil)
+		require.NoError(t, err)
+
+		_, err = repo.CreateLibraryEntry(ctx, entry, nil)
+		require.ErrorIs(t, err, domain.ErrAlreadyExists)
+
+		entry.GameID = uuid.New()
+		_, err = repo.CreateLibraryEntry(ctx, entry, nil)
+		require.ErrorIs(t, err, domain.ErrReferenceNotFound)
+	})
+
+	t.Run("update, history and delete", func(t *testing.T) {
+		repo, account, game := setup(t)
+		ctx := context.Background()
+
+		entry, err := repo.CreateLibraryEntry(ctx, domain.LibraryEntry{
+			AccountID: account.ID,
+			GameID:    game.ID,
+			Status:    domain.LibraryStatusBacklog,
+		}, []domain.LibraryEntryEvent{
+			{Kind: domain.LibraryEntryEventStatusChanged, To: "backlog"},
+		})
+		require.NoError(t, err)
+
+		entry.Status = domain.LibraryStatusPlaying
+		entry.Notes = "wolf"
+		updated, err := repo.UpdateLibraryEntry(ctx, entry, []domain.LibraryEntryEvent{
+			{Kind: domain.LibraryEntryEventStatusChanged, From: "backlog", To: "playing"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, domain.LibraryStatusPlaying, updated.Status)
+		require.Equal(t, "wolf", updated.Notes)
+
+		events, err := repo.ListLibraryEntryEvents(ctx, account.ID, entry.ID)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		require.Equal(t, "backlog", events[1].From)
+		require.Equal(t, "playing", events[1].To)
+
+		others, err := repo.ListLibraryEntryEvents(ctx, uuid.New(), entry.ID)
+		require.NoError(t, err)
+		require.Empty(t, others)
+
+		entry.AccountID = uuid.New()
+		_, err = repo.UpdateLibraryEntry(ctx, entry, nil)
+		require.ErrorIs(t, err, domain.ErrLibraryEntryNotFound)
+
+		require.NoError(t, repo.DeleteLibraryEntry(ctx, account.ID, entry.ID))
+		require.ErrorIs(t, repo.DeleteLibraryEntry(ctx, account.ID, entry.ID), domain.ErrLibraryEntryNotFound)
+
+		entries, err := repo.ListLibraryEntries(ctx, account.ID)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("import refreshes playtime", func(t *testing.T) {
+		repo, account, game := setup(t)
+		ctx := context.Background()
+		events := []domain.LibraryEntryEvent{{Kind: domain.LibraryEntryEventStatusChanged, To: "backlog"}}
+
+		entry, inserted, err := repo.ImportLibraryEntry(ctx, domain.LibraryEntry{
+			AccountID:       account.ID,
+			GameID:          game.ID,
+			Status:          domain.LibraryStatusBacklog,
+			PlaytimeMinutes: 30,
+		}, events)
+		require.NoError(t, err)
+		require.True(t, inserted)
+
+		again, inserted, err := repo.ImportLibraryEntry(ctx, domain.LibraryEntry{
+			AccountID:       account.ID,
+			GameID:          game.ID,
+			Status:          domain.LibraryStatusBacklog,
+			PlaytimeMinutes: 90,
+		}, events)
+		require.NoError(t, err)
+		require.False(t, inserted)
+		require.Equal(t, entry.ID, again.ID)
+		require.Equal(t, 90, again.PlaytimeMinutes)
+
+		history, err := repo.ListLibraryEntryEvents(ctx, account.ID, entry.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+	})
+}
+
+// uniqueWord returns a lowercase, letters-only word no other test uses, so
+// tests sharing a database don't see each other's rows. Letters only keeps
+// full-text tokenizers from splitting it.
+func uniqueWord() string {
+	return "zq" + strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return 'g' + (r - '0')
+		}
+		return r
+	}, strings.ReplaceAll(uuid.NewString(), "-", "")[:12])
+}
+
+func titles(games []domain.Game) []string {
+	titles := make([]string, len(games))
+	for i, game := range games {
+		titles[i] = game.Title
+	}
+	return titles
+}
@@ -0,0 +1,221 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+type session struct {
+	accountID uuid.UUID
+	expiresAt time.Time
+}
+
+type AccountRepository struct {
+	// locked is held by WithAccountsLock, apart from mu so fn can still call
+	// the other methods.
+	locked   sync.Mutex
+	mu       sync.RWMutex
+	accounts map[string]domain.Account
+	sessions map[string]session
+	now      func() time.Time
+}
+
+var _ domain.AccountRepository = (*AccountRepository)(nil)
+
+func NewAccountRepository() *AccountRepository {
+	return &AccountRepository{
+		accounts: make(map[string]domain.Account),
+		sessions: make(map[string]session),
+		now:      time.Now,
+	}
+}
+
+func (r *AccountRepository) CreateAccount(ctx context.Context, account domain.Account) (domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.accounts[account.Email]; ok {
+		return domain.Account{}, domain.ErrEmailTaken
+	}
+
+	now := r.timestamp()
+	account.ID = uuid.New()
+	account.Role = account.Role.OrMember()
+	account.InsertedAt = now
+	account.UpdatedAt = now
+	r.accounts[account.Email] = account
+
+	return account, nil
+}
+
+func (r *AccountRepository) GetAccountByEmail(ctx context.Context, email string) (domain.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.accounts[email]
+	if !ok {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+func (r *AccountRepository) GetAccountByID(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	account, ok := r.accountByID(id)
+	if !ok {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	return account, nil
+}
+
+func (r *AccountRepository) accountByID(id uuid.UUID) (domain.Account, bool) {
+	for _, account := range r.accounts {
+		if account.ID == id {
+			return account, true
+		}
+	}
+
+	return domain.Account{}, false
+}
+
+func (r *AccountRepository) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accounts := make([]domain.Account, 0, len(r.accounts))
+	for _, account := range r.accounts {
+		accounts = append(accounts, account)
+	}
+	slices.SortFunc(accounts, func(a, b domain.Account) int {
+		if c := a.InsertedAt.Compare(b.InsertedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return accounts, nil
+}
+
+func (r *AccountRepository) CountAccounts(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.accounts), nil
+}
+
+// WithAccountsLock runs fn against the repository itself. Nothing is rolled
+// back when fn fails, which no caller relies on.
+func (r *AccountRepository) WithAccountsLock(ctx context.Context, fn func(repo domain.AccountRepository) error) error {
+	r.locked.Lock()
+	defer r.locked.Unlock()
+
+	return fn(r)
+}
+
+func (r *AccountRepository) UpdateAccountRole(ctx context.Context, id uuid.UUID, role auth.Role) (domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateAccount(id, func(account *domain.Account) {
+		account.Role = role
+	})
+}
+
+func (r *AccountRepository) DisableAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	account, err := r.updateAccount(id, func(account *domain.Account) {
+		if account.DisabledAt.IsZero() {
+			account.DisabledAt = r.timestamp()
+		}
+	})
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	for token, session := range r.sessions {
+		if session.accountID == id {
+			delete(r.sessions, token)
+		}
+	}
+
+	return account, nil
+}
+
+func (r *AccountRepository) updateAccount(id uuid.UUID, change func(*domain.Account)) (domain.Account, error) {
+	account, ok := r.accountByID(id)
+	if !ok {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+
+	change(&account)
+	account.UpdatedAt = r.timestamp()
+	r.accounts[account.Email] = account
+
+	return account, nil
+}
+
+func (r *AccountRepository) timestamp() time.Time {
+	return r.now().UTC().Truncate(time.Microsecond)
+}
+
+func (r *AccountRepository) CreateSession(ctx context.Context, accountID uuid.UUID, token string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[token] = session{accountID, expiresAt}
+	return nil
+}
+
+// GetSessionAccount treats expired sessions as missing. They are not
+// removed, just like the SQL backends leave them in place.
+func (r *AccountRepository) GetSessionAccount(ctx context.Context, token string) (domain.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[token]
+	if !ok || !session.expiresAt.After(r.now()) {
+		return domain.Account{}, domain.ErrSessionNotFound
+	}
+
+	account, ok := r.accountByID(session.accountID)
+	if !ok || !account.DisabledAt.IsZero() {
+		return domain.Account{}, domain.ErrSessionNotFound
+	}
+
+	return account, nil
+}
+
+func (r *AccountRepository) DeleteSession(ctx context.Context, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessions, token)
+	return nil
+}
+
+func (r *AccountRepository) CountActiveSessions(ctx context.Context) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := r.now()
+	count := 0
+	for _, session := range r.sessions {
+		if session.expiresAt.After(now) {
+			count++
+		}
+	}
+	return count, nil
+}
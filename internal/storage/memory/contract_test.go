@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/kalogs-c/nerd-backlog/internal/storage/contracttest"
+)
+
+func TestContract(t *testing.T) {
+	contracttest.Run(t, func(t *testing.T) contracttest.Repositories {
+		games := NewGameRepository()
+		return contracttest.Repositories{
+			Games:    games,
+			Accounts: NewAccountRepository(),
+			Library:  NewLibraryRepository(games),
+		}
+	})
+}
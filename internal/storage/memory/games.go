@@ -0,0 +1,332 @@
+// Package memory keeps repositories in maps guarded by mutexes. It backs fast
+// tests and the demo server; nothing survives a restart.
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/search"
+)
+
+type GameRepository struct {
+	mu          sync.RWMutex
+	games       map[uuid.UUID]domain.Game
+	externalIDs map[domain.ExternalID]uuid.UUID
+	now         func() time.Time
+}
+
+var _ domain.GameRepository = (*GameRepository)(nil)
+
+func NewGameRepository() *GameRepository {
+	return &GameRepository{
+		games:       make(map[uuid.UUID]domain.Game),
+		externalIDs: make(map[domain.ExternalID]uuid.UUID),
+		now:         time.Now,
+	}
+}
+
+func (r *GameRepository) CreateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.timestamp()
+	game.ID = uuid.New()
+	game.InsertedAt = now
+	game.UpdatedAt = now
+	game = withSortedCategories(game)
+	r.games[game.ID] = game
+
+	return cloneGame(game), nil
+}
+
+func (r *GameRepository) GetGameByID(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	game, ok := r.games[id]
+	if !ok {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	return cloneGame(game), nil
+}
+
+// GetGameByNormalizedTitle returns the oldest game with the title, as the SQL
+// backends do.
+func (r *GameRepository) GetGameByNormalizedTitle(ctx context.Context, normalizedTitle string) (domain.Game, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var found *domain.Game
+	for _, game := range r.games {
+		if domain.NormalizeTitle(game.Title) != normalizedTitle {
+			continue
+		}
+		if found == nil || game.InsertedAt.Before(found.InsertedAt) ||
+			(game.InsertedAt.Equal(found.InsertedAt) && game.ID.String() < found.ID.String()) {
+			found = &game
+		}
+	}
+
+	if found == nil {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	return cloneGame(*found), nil
+}
+
+func (r *GameRepository) GetGameByExternalID(ctx context.Context, externalID domain.ExternalID) (domain.Game, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.externalIDs[externalID]
+	if !ok {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	return cloneGame(r.games[id]), nil
+}
+
+type sortedGame struct {
+	game    domain.Game
+	sortKey string
+}
+
+func (r *GameRepository) ListGames(ctx context.Context, params domain.ListGamesParams) (domain.GamePage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prefix := strings.ToLower(params.TitlePrefix)
+
+	var sorted []sortedGame
+	for _, game := range r.games {
+		if params.Platform != "" && !slices.Contains(game.Platforms, params.Platform) {
+			continue
+		}
+		if params.Genre != "" && !slices.Contains(game.Genres, params.Genre) {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(game.Title), prefix) {
+			continue
+		}
+
+		sorted = append(sorted, sortedGame{game, domain.GameSortKey(game, params.Sort)})
+	}
+
+	slices.SortFunc(sorted, func(a, b sortedGame) int {
+		return compareKeys(params, a.sortKey, a.game.ID, b.sortKey, b.game.ID)
+	})
+
+	if params.After != nil {
+		after := params.After
+		sorted = slices.DeleteFunc(sorted, func(s sortedGame) bool {
+			return compareKeys(params, s.sortKey, s.game.ID, after.SortKey, after.ID) <= 0
+		})
+	}
+
+	var next *domain.GameCursor
+	if len(sorted) > params.Limit {
+		sorted = sorted[:params.Limit]
+		last := sorted[len(sorted)-1]
+		next = &domain.GameCursor{SortKey: last.sortKey, ID: last.game.ID}
+	}
+
+	games := make([]domain.Game, len(sorted))
+	for i, s := range sorted {
+		games[i] = cloneGame(s.game)
+	}
+
+	return domain.GamePage{Games: games, Next: next}, nil
+}
+
+// compareKeys orders two games the way params lists them. Empty release date
+// keys come last whatever the direction, as NULLS LAST does in SQL.
+func compareKeys(params domain.ListGamesParams, aKey string, aID uuid.UUID, bKey string, bID uuid.UUID) int {
+	if params.Sort == domain.GameSortReleaseDate && (aKey == "") != (bKey == "") {
+		if aKey == "" {
+			return 1
+		}
+		return -1
+	}
+
+	c := strings.Compare(aKey, bKey)
+	if c == 0 {
+		c = strings.Compare(aID.String(), bID.String())
+	}
+	if params.Descending {
+		return -c
+	}
+	return c
+}
+
+func (r *GameRepository) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameSearchResult, error) {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return []domain.GameSearchResult{}, nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var games []domain.Game
+	for _, game := range r.games {
+		if search.Matches(game, terms) {
+			games = append(games, cloneGame(game))
+		}
+	}
+
+	return search.Rank(games, terms, limit), nil
+}
+
+func (r *GameRepository) UpdateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.updateGame(game)
+}
+
+func (r *GameRepository) updateGame(game domain.Game) (domain.Game, error) {
+	current, ok := r.games[game.ID]
+	if !ok {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	game.CreatedBy = current.CreatedBy
+	game.InsertedAt = current.InsertedAt
+	game.UpdatedAt = r.timestamp()
+	game.DeletedAt = current.DeletedAt
+	game = withSortedCategories(game)
+	r.games[game.ID] = game
+
+	return cloneGame(game), nil
+}
+
+func (r *GameRepository) ListExternalIDs(ctx context.Context, gameID uuid.UUID) ([]domain.ExternalID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	externalIDs := []domain.ExternalID{}
+	for externalID, id := range r.externalIDs {
+		if id == gameID {
+			externalIDs = append(externalIDs, externalID)
+		}
+	}
+
+	slices.SortFunc(externalIDs, func(a, b domain.ExternalID) int {
+		if c := strings.Compare(string(a.Source), string(b.Source)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return externalIDs, nil
+}
+
+// AddExternalIDs maps externalIDs to gameID. IDs already mapped, to this game
+// or another one, are left alone.
+func (r *GameRepository) AddExternalIDs(ctx context.Context, gameID uuid.UUID, externalIDs []domain.ExternalID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, externalID := range externalIDs {
+		if _, ok := r.externalIDs[externalID]; !ok {
+			r.externalIDs[externalID] = gameID
+		}
+	}
+
+	return nil
+}
+
+func (r *GameRepository) CreateGameWithExternalIDs(
+	ctx context.Context,
+	game domain.Game,
+	externalIDs []domain.ExternalID,
+) (domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, externalID := range externalIDs {
+		if id, ok := r.externalIDs[externalID]; ok {
+			return cloneGame(r.games[id]), nil
+		}
+	}
+
+	now := r.timestamp()
+	game.ID = uuid.New()
+	game.InsertedAt = now
+	game.UpdatedAt = now
+	game = withSortedCategories(game)
+	r.games[game.ID] = game
+	for _, externalID := range externalIDs {
+		r.externalIDs[externalID] = game.ID
+	}
+
+	return cloneGame(game), nil
+}
+
+func (r *GameRepository) MergeGames(ctx context.Context, sourceID uuid.UUID, target domain.Game) (domain.Game, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	merged, err := r.updateGame(target)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	for externalID, id := range r.externalIDs {
+		if id == sourceID {
+			r.externalIDs[externalID] = target.ID
+		}
+	}
+	delete(r.games, sourceID)
+
+	return merged, nil
+}
+
+func (r *GameRepository) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.games, id)
+	for externalID, gameID := range r.externalIDs {
+		if gameID == id {
+			delete(r.externalIDs, externalID)
+		}
+	}
+
+	return nil
+}
+
+// timestamp is truncated to what the SQL backends can store.
+func (r *GameRepository) timestamp() time.Time {
+	return r.now().UTC().Truncate(time.Microsecond)
+}
+
+func withSortedCategories(game domain.Game) domain.Game {
+	game.Platforms = sortedUnique(game.Platforms)
+	game.Genres = sortedUnique(game.Genres)
+	return game
+}
+
+func sortedUnique(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+// cloneGame copies the slices, so callers can't change stored games.
+func cloneGame(game domain.Game) domain.Game {
+	game.Platforms = slices.Clone(game.Platforms)
+	game.Genres = slices.Clone(game.Genres)
+	return game
+}
@@ -0,0 +1,227 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+// LibraryRepository reads game titles from the games it is given. Entries
+// whose game is gone are hidden, as the SQL join hides them; merging games
+// does not move entries over to the target.
+type LibraryRepository struct {
+	mu          sync.RWMutex
+	games       domain.GameRepository
+	entries     map[uuid.UUID]domain.LibraryEntry
+	events      []domain.LibraryEntryEvent
+	nextEventID int64
+	now         func() time.Time
+}
+
+var _ domain.LibraryRepository = (*LibraryRepository)(nil)
+
+func NewLibraryRepository(games domain.GameRepository) *LibraryRepository {
+	return &LibraryRepository{
+		games:   games,
+		entries: make(map[uuid.UUID]domain.LibraryEntry),
+		now:     time.Now,
+	}
+}
+
+func (r *LibraryRepository) CreateLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.games.GetGameByID(ctx, entry.GameID); err != nil {
+		return domain.LibraryEntry{}, domain.ErrReferenceNotFound
+	}
+	if _, ok := r.entryForGame(entry.AccountID, entry.GameID); ok {
+		return domain.LibraryEntry{}, domain.ErrAlreadyExists
+	}
+
+	now := r.timestamp()
+	entry.ID = uuid.New()
+	entry.GameTitle = ""
+	entry.PlaytimeMinutes = 0
+	entry.LastPlayedAt = time.Time{}
+	entry.InsertedAt = now
+	entry.UpdatedAt = now
+	r.entries[entry.ID] = entry
+	r.appendEvents(entry, events)
+
+	return r.withTitle(ctx, entry)
+}
+
+func (r *LibraryRepository) GetLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) (domain.LibraryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.entries[id]
+	if !ok || entry.AccountID != accountID {
+		return domain.LibraryEntry{}, domain.ErrLibraryEntryNotFound
+	}
+
+	return r.withTitle(ctx, entry)
+}
+
+// ListLibraryEntries returns the most recently updated entries first.
+func (r *LibraryRepository) ListLibraryEntries(ctx context.Context, accountID uuid.UUID) ([]domain.LibraryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []domain.LibraryEntry{}
+	for _, entry := range r.entries {
+		if entry.AccountID != accountID {
+			continue
+		}
+
+		entry, err := r.withTitle(ctx, entry)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b domain.LibraryEntry) int {
+		if c := b.UpdatedAt.Compare(a.UpdatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+
+	return entries, nil
+}
+
+func (r *LibraryRepository) UpdateLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.entries[entry.ID]
+	if !ok || stored.AccountID != entry.AccountID {
+		return domain.LibraryEntry{}, domain.ErrLibraryEntryNotFound
+	}
+
+	stored.Status = entry.Status
+	stored.StartedAt = entry.StartedAt
+	stored.FinishedAt = entry.FinishedAt
+	stored.Rating = entry.Rating
+	stored.Notes = entry.Notes
+	stored.UpdatedAt = r.timestamp()
+	r.entries[stored.ID] = stored
+	r.appendEvents(stored, events)
+
+	return r.withTitle(ctx, stored)
+}
+
+// DeleteLibraryEntry keeps the entry's events, but like the SQL backends they
+// no longer belong to an entry and can't be listed.
+func (r *LibraryRepository) DeleteLibraryEntry(ctx context.Context, accountID uuid.UUID, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[id]
+	if !ok || entry.AccountID != accountID {
+		return domain.ErrLibraryEntryNotFound
+	}
+
+	delete(r.entries, id)
+	for i := range r.events {
+		if r.events[i].EntryID == id {
+			r.events[i].EntryID = uuid.Nil
+		}
+	}
+
+	return nil
+}
+
+func (r *LibraryRepository) ImportLibraryEntry(ctx context.Context, entry domain.LibraryEntry, events []domain.LibraryEntryEvent) (domain.LibraryEntry, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.games.GetGameByID(ctx, entry.GameID); err != nil {
+		return domain.LibraryEntry{}, false, domain.ErrReferenceNotFound
+	}
+
+	if stored, ok := r.entryForGame(entry.AccountID, entry.GameID); ok {
+		stored.PlaytimeMinutes = entry.PlaytimeMinutes
+		if !entry.LastPlayedAt.IsZero() {
+			stored.LastPlayedAt = entry.LastPlayedAt
+		}
+		stored.UpdatedAt = r.timestamp()
+		r.entries[stored.ID] = stored
+
+		imported, err := r.withTitle(ctx, stored)
+		return imported, false, err
+	}
+
+	now := r.timestamp()
+	imported := domain.LibraryEntry{
+		ID:              uuid.New(),
+		AccountID:       entry.AccountID,
+		GameID:          entry.GameID,
+		Status:          entry.Status,
+		StartedAt:       entry.StartedAt,
+		PlaytimeMinutes: entry.PlaytimeMinutes,
+		LastPlayedAt:    entry.LastPlayedAt,
+		TimeStamps:      domain.TimeStamps{InsertedAt: now, UpdatedAt: now},
+	}
+	r.entries[imported.ID] = imported
+	r.appendEvents(imported, events)
+
+	imported, err := r.withTitle(ctx, imported)
+	return imported, true, err
+}
+
+func (r *LibraryRepository) ListLibraryEntryEvents(ctx context.Context, accountID uuid.UUID, entryID uuid.UUID) ([]domain.LibraryEntryEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []domain.LibraryEntryEvent{}
+	for _, event := range r.events {
+		if event.EntryID == entryID && event.AccountID == accountID {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (r *LibraryRepository) entryForGame(accountID uuid.UUID, gameID uuid.UUID) (domain.LibraryEntry, bool) {
+	for _, entry := range r.entries {
+		if entry.AccountID == accountID && entry.GameID == gameID {
+			return entry, true
+		}
+	}
+
+	return domain.LibraryEntry{}, false
+}
+
+func (r *LibraryRepository) appendEvents(entry domain.LibraryEntry, events []domain.LibraryEntryEvent) {
+	now := r.timestamp()
+	for _, event := range events {
+		r.nextEventID++
+		event.ID = r.nextEventID
+		event.EntryID = entry.ID
+		event.AccountID = entry.AccountID
+		event.InsertedAt = now
+		r.events = append(r.events, event)
+	}
+}
+
+func (r *LibraryRepository) withTitle(ctx context.Context, entry domain.LibraryEntry) (domain.LibraryEntry, error) {
+	game, err := r.games.GetGameByID(ctx, entry.GameID)
+	if err != nil {
+		return domain.LibraryEntry{}, domain.ErrLibraryEntryNotFound
+	}
+
+	entry.GameTitle = game.Title
+	return entry, nil
+}
+
+func (r *LibraryRepository) timestamp() time.Time {
+	return r.now().UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+// The demo account Seed creates.
+const (
+	DemoEmail    = "demo@example.com"
+	DemoPassword = "demo-backlog"
+)
+
+var demoGames = []domain.Game{
+	{
+		Title:       "Chrono Trigger",
+		ReleaseDate: time.Date(1995, time.March, 11, 0, 0, 0, 0, time.UTC),
+		Summary:     "A band of heroes travels through time to prevent a global catastrophe.",
+		Developer:   "Square",
+		Publisher:   "Square",
+		Platforms:   []string{"snes", "ds"},
+		Genres:      []string{"rpg"},
+	},
+	{
+		Title:       "Super Metroid",
+		ReleaseDate: time.Date(1994, time.March, 19, 0, 0, 0, 0, time.UTC),
+		Summary:     "Samus Aran returns to planet Zebes to recover the last Metroid.",
+		Developer:   "Nintendo R&D1",
+		Publisher:   "Nintendo",
+		Platforms:   []string{"snes"},
+		Genres:      []string{"metroidvania", "action"},
+	},
+	{
+		Title:       "Half-Life",
+		ReleaseDate: time.Date(1998, time.November, 19, 0, 0, 0, 0, time.UTC),
+		Summary:     "A scientist fights his way out of a research facility overrun by aliens.",
+		Developer:   "Valve",
+		Publisher:   "Sierra",
+		Platforms:   []string{"pc"},
+		Genres:      []string{"shooter"},
+	},
+	{
+		Title:       "Hollow Knight",
+		ReleaseDate: time.Date(2017, time.February, 24, 0, 0, 0, 0, time.UTC),
+		Summary:     "A knight explores the ruined insect kingdom of Hallownest.",
+		Developer:   "Team Cherry",
+		Publisher:   "Team Cherry",
+		Platforms:   []string{"pc", "switch"},
+		Genres:      []string{"metroidvania"},
+	},
+	{
+		Title:       "Disco Elysium",
+		ReleaseDate: time.Date(2019, time.October, 15, 0, 0, 0, 0, time.UTC),
+		Summary:     "An amnesiac detective investigates a murder in the city of Revachol.",
+		Developer:   "ZA/UM",
+		Publisher:   "ZA/UM",
+		Platforms:   []string{"pc"},
+		Genres:      []string{"rpg"},
+	},
+}
+
+// Seed fills the repositories with a demo account and a small catalog. The
+// demo account is the first one, so it is an admin.
+func Seed(ctx context.Context, games domain.GameRepository, accounts domain.AccountRepository) error {
+	hashedPassword, err := auth.HashPassword(DemoPassword)
+	if err != nil {
+		return fmt.Errorf("hash demo password: %w", err)
+	}
+
+	account, err := accounts.CreateAccount(ctx, domain.Account{
+		Nickname:       "demo",
+		Email:          DemoEmail,
+		HashedPassword: hashedPassword,
+		Role:           auth.RoleAdmin,
+	})
+	if err != nil {
+		return fmt.Errorf("create demo account: %w", err)
+	}
+
+	for _, game := range demoGames {
+		game.CreatedBy = account.ID
+		if _, err := games.CreateGame(ctx, game); err != nil {
+			return fmt.Errorf("create demo game %q: %w", game.Title, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+	games := NewGameRepository()
+	accounts := NewAccountRepository()
+
+	require.NoError(t, Seed(ctx, games, accounts))
+
+	account, err := accounts.GetAccountByEmail(ctx, DemoEmail)
+	require.NoError(t, err)
+	require.Equal(t, "demo", account.Nickname)
+	require.Equal(t, auth.RoleAdmin, account.Role)
+
+	page, err := games.ListGames(ctx, domain.ListGamesParams{Sort: domain.GameSortTitle, Limit: 100})
+	require.NoError(t, err)
+	require.Len(t, page.Games, len(demoGames))
+	require.Equal(t, "Chrono Trigger", page.Games[0].Title)
+	require.Equal(t, account.ID, page.Games[0].CreatedBy)
+}
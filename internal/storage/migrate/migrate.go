@@ -0,0 +1,112 @@
+// Package migrate runs the goose migrations of either storage backend on
+// behalf of the migrate subcommand and the server boot.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
+)
+
+var ErrSchemaBehind = errors.New("database schema is behind the binary")
+
+const (
+	CommandUp     = "up"
+	CommandDown   = "down"
+	CommandStatus = "status"
+	CommandRedo   = "redo"
+)
+
+// Run executes one migrate subcommand, writing what it did to out.
+func Run(ctx context.Context, provider *goose.Provider, command string, out io.Writer) error {
+	switch command {
+	case CommandUp:
+		results, err := provider.Up(ctx)
+		printResults(out, results...)
+		if err == nil && len(results) == 0 {
+			_, _ = fmt.Fprintln(out, "no migrations to apply")
+		}
+		return err
+	case CommandDown:
+		result, err := provider.Down(ctx)
+		printResults(out, result)
+		return err
+	case CommandRedo:
+		down, err := provider.Down(ctx)
+		printResults(out, down)
+		if err != nil {
+			return err
+		}
+
+		up, err := provider.ApplyVersion(ctx, down.Source.Version, true)
+		printResults(out, up)
+		return err
+	case CommandStatus:
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "-"
+			if !status.AppliedAt.IsZero() {
+				appliedAt = status.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			}
+			_, _ = fmt.Fprintf(out, "%-9s %-19s %s\n", status.State, appliedAt, status.Source.Path)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+}
+
+// EnsureCurrent returns ErrSchemaBehind when the database is missing
+// migrations the binary ships with.
+func EnsureCurrent(ctx context.Context, provider *goose.Provider) error {
+	pending, err := provider.HasPending(ctx)
+	if err != nil {
+		return fmt.Errorf("check pending migrations: %w", err)
+	}
+	if !pending {
+		return nil
+	}
+
+	current, target, err := provider.GetVersions(ctx)
+	if err != nil {
+		return fmt.Errorf("get schema versions: %w", err)
+	}
+
+	return fmt.Errorf("%w: database is at %d, binary expects %d", ErrSchemaBehind, current, target)
+}
+
+// Latest is the highest migration version in fsys, the schema version a binary
+// embedding it expects.
+func Latest(fsys fs.FS) (int64, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, name := range names {
+		version, err := goose.NumericComponent(name)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s: %w", name, err)
+		}
+		latest = max(latest, version)
+	}
+
+	return latest, nil
+}
+
+func printResults(out io.Writer, results ...*goose.MigrationResult) {
+	for _, result := range results {
+		if result != nil {
+			_, _ = fmt.Fprintln(out, result)
+		}
+	}
+}
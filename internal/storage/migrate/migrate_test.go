@@ -0,0 +1,54 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/storage/sqlite"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/sqlite/migrations"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	db, err := sqlite.Connect(ctx, "file:"+t.TempDir()+"/backlog.db")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	provider, err := migrations.Provide(db)
+	require.NoError(t, err)
+
+	latest, err := Latest(migrations.Embed)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, EnsureCurrent(ctx, provider), ErrSchemaBehind)
+
+	var out bytes.Buffer
+	require.NoError(t, Run(ctx, provider, CommandUp, &out))
+	require.Contains(t, out.String(), "OK")
+	require.NoError(t, EnsureCurrent(ctx, provider))
+
+	version, err := provider.GetDBVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, latest, version)
+
+	out.Reset()
+	require.NoError(t, Run(ctx, provider, CommandUp, &out))
+	require.Contains(t, out.String(), "no migrations to apply")
+
+	require.NoError(t, Run(ctx, provider, CommandRedo, &out))
+	require.NoError(t, EnsureCurrent(ctx, provider))
+
+	require.NoError(t, Run(ctx, provider, CommandDown, &out))
+	err = EnsureCurrent(ctx, provider)
+	require.ErrorIs(t, err, ErrSchemaBehind)
+
+	out.Reset()
+	require.NoError(t, Run(ctx, provider, CommandStatus, &out))
+	require.Contains(t, out.String(), "pending")
+	require.Contains(t, out.String(), "applied")
+
+	require.Error(t, Run(ctx, provider, "sideways", &out))
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"log/slog"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/tracelog"
 )
@@ -55,12 +57,16 @@ func Connect(ctx context.Context, dsn string, logger *slog.Logger) (*pgxpool.Poo
 		return nil, fmt.Errorf("parse db config %s failed: %w", dsn, err)
 	}
 
+	// The span tracer goes first, so the query log line carries the query
+	// span's IDs.
+	tracers := []pgx.QueryTracer{QueryTracer{}}
 	if logger != nil {
-		config.ConnConfig.Tracer = &tracelog.TraceLog{
+		tracers = append(tracers, &tracelog.TraceLog{
 			Logger:   &SlogPgxLogger{logger: logger},
 			LogLevel: tracelog.LogLevelInfo,
-		}
+		})
 	}
+	config.ConnConfig.Tracer = multitracer.New(tracers...)
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
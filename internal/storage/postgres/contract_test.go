@@ -0,0 +1,100 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/kalogs-c/nerd-backlog/internal/accounts"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/library"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/contracttest"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	"github.com/kalogs-c/nerd-backlog/internal/testutils"
+	"github.com/kalogs-c/nerd-backlog/sql/migrations"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+var testDB *pgxpool.Pool
+
+func TestMain(m *testing.M) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	dsn, terminate, err := testutils.StartPostgresContainer(ctx)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	testDB = postgres.MustConnect(ctx, dsn, nil)
+	gooseProvider := migrations.MustProvide(testDB)
+
+	_, err = gooseProvider.Up(context.Background())
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	exitCode := m.Run()
+
+	if err := terminate(context.Background()); err != nil {
+		log.Println(err)
+	}
+
+	os.Exit(exitCode)
+}
+
+func TestContract(t *testing.T) {
+	contracttest.Run(t, func(t *testing.T) contracttest.Repositories {
+		queries := sqlc.New(testDB)
+		return contracttest.Repositories{
+			Games:    games.NewRepository(queries, testDB),
+			Accounts: accounts.NewRepository(queries, testDB),
+			Library:  library.NewRepository(queries, testDB),
+		}
+	})
+}
+
+func TestPoolCollector(t *testing.T) {
+	require.NoError(t, testDB.Ping(context.Background()))
+
+	collector := postgres.NewPoolCollector(testDB)
+
+	require.Equal(t, 8, testutil.CollectAndCount(collector))
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(fmt.Sprintf(`
+# HELP nerd_backlog_db_pool_max_connections Most connections the pool will open.
+# TYPE nerd_backlog_db_pool_max_connections gauge
+nerd_backlog_db_pool_max_connections %d
+`, testDB.Config().MaxConns)), "nerd_backlog_db_pool_max_connections"))
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	_, err := sqlc.New(testDB).CountAccounts(ctx)
+	require.NoError(t, err)
+	_, err = testDB.Exec(ctx, "SELECT * FROM no_such_table")
+	require.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, "CountAccounts", spans[0].Name())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, "SELECT", spans[1].Name())
+	require.Equal(t, "Error", spans[1].Status().Code.String())
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+// SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	codeUniqueViolation      = "23505"
+	codeForeignKeyViolation  = "23503"
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+// uniqueConstraints names the unique constraints that have a domain error of
+// their own. Others surface as domain.ErrAlreadyExists.
+var uniqueConstraints = map[string]error{
+	"accounts_email_key": domain.ErrEmailTaken,
+}
+
+// translatedError pairs a domain error with the driver error behind it. Its
+// message is the domain error's alone, since messages reach API clients; the
+// driver error stays reachable through Unwrap and is logged via LogValue.
+type translatedError struct {
+	domain error
+	driver error
+}
+
+func (e *translatedError) Error() string {
+	return e.domain.Error()
+}
+
+func (e *translatedError) LogValue() slog.Value {
+	return slog.StringValue(fmt.Sprintf("%v: %v", e.domain, e.driver))
+}
+
+func (e *translatedError) Unwrap() []error {
+	return []error{e.domain, e.driver}
+}
+
+// TranslateError maps a driver error onto the domain. pgx.ErrNoRows becomes
+// notFound, or is left alone when notFound is nil. Constraint violations and
+// serialization failures are wrapped with the matching domain error. Anything
+// else, nil and already translated errors included, is returned unchanged.
+func TranslateError(err error, notFound error) error {
+	if errors.Is(err, pgx.ErrNoRows) && notFound != nil {
+		return notFound
+	}
+
+	var translated *translatedError
+	var pgErr *pgconn.PgError
+	if errors.As(err, &translated) || !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case codeUniqueViolation:
+		if target, ok := uniqueConstraints[pgErr.ConstraintName]; ok {
+			return &translatedError{target, err}
+		}
+		return &translatedError{domain.ErrAlreadyExists, err}
+	case codeForeignKeyViolation:
+		return &translatedError{domain.ErrReferenceNotFound, err}
+	case codeSerializationFailure, codeDeadlockDetected:
+		return &translatedError{domain.ErrConcurrentUpdate, err}
+	default:
+		return err
+	}
+}
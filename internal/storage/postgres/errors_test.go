@@ -0,0 +1,135 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/postgres"
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+func createAccount(t *testing.T, queries *sqlc.Queries) sqlc.Account {
+	t.Helper()
+
+	account, err := queries.CreateAccount(context.Background(), sqlc.CreateAccountParams{
+		Nickname:       "kirby",
+		Email:          fmt.Sprintf("%s@example.com", uuid.NewString()),
+		HashedPassword: "hashed",
+	})
+	require.NoError(t, err)
+
+	return account
+}
+
+func TestTranslateError_NotFound(t *testing.T) {
+	_, err := sqlc.New(testDB).GetGame(context.Background(), uuid.New())
+
+	require.ErrorIs(t, postgres.TranslateError(err, domain.ErrGameNotFound), domain.ErrGameNotFound)
+	require.ErrorIs(t, postgres.TranslateError(err, nil), pgx.ErrNoRows)
+}
+
+func TestTranslateError_UniqueViolation(t *testing.T) {
+	queries := sqlc.New(testDB)
+	account := createAccount(t, queries)
+
+	_, err := queries.CreateAccount(context.Background(), sqlc.CreateAccountParams{
+		Nickname:       "meta knight",
+		Email:          account.Email,
+		HashedPassword: "hashed",
+	})
+	translated := postgres.TranslateError(err, nil)
+
+	require.ErrorIs(t, translated, domain.ErrEmailTaken)
+	require.Equal(t, translated, postgres.TranslateError(translated, nil))
+}
+
+func TestTranslateError_HidesDriverMessage(t *testing.T) {
+	driver := &pgconn.PgError{
+		Code:           "23505",
+		Message:        `duplicate key value violates unique constraint "accounts_email_key"`,
+		ConstraintName: "accounts_email_key",
+	}
+	translated := postgres.TranslateError(fmt.Errorf("create account: %w", driver), nil)
+
+	require.EqualError(t, translated, domain.ErrEmailTaken.Error())
+	require.ErrorIs(t, translated, driver)
+
+	logged, ok := translated.(slog.LogValuer)
+	require.True(t, ok)
+	require.Contains(t, logged.LogValue().String(), "SQLSTATE 23505")
+}
+
+func TestTranslateError_UnnamedUniqueViolation(t *testing.T) {
+	queries := sqlc.New(testDB)
+	account := createAccount(t, queries)
+	expiresAt := pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+	token := uuid.NewString()
+
+	params := sqlc.CreateSessionParams{Token: token, AccountID: account.ID, ExpiresAt: expiresAt}
+	require.NoError(t, queries.CreateSession(context.Background(), params))
+	err := queries.CreateSession(context.Background(), params)
+
+	require.ErrorIs(t, postgres.TranslateError(err, nil), domain.ErrAlreadyExists)
+}
+
+func TestTranslateError_ForeignKeyViolation(t *testing.T) {
+	err := sqlc.New(testDB).CreateSession(context.Background(), sqlc.CreateSessionParams{
+		Token:     uuid.NewString(),
+		AccountID: uuid.New(),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+
+	require.ErrorIs(t, postgres.TranslateError(err, nil), domain.ErrReferenceNotFound)
+}
+
+func TestTranslateError_SerializationFailure(t *testing.T) {
+	ctx := context.Background()
+	account := createAccount(t, sqlc.New(testDB))
+
+	tx, err := testDB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead})
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var nickname string
+	err = tx.QueryRow(ctx, "SELECT nickname FROM accounts WHERE id = $1", account.ID).Scan(&nickname)
+	require.NoError(t, err)
+
+	_, err = testDB.Exec(ctx, "UPDATE accounts SET nickname = 'dedede' WHERE id = $1", account.ID)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(ctx, "UPDATE accounts SET nickname = 'waddle dee' WHERE id = $1", account.ID)
+	require.ErrorIs(t, postgres.TranslateError(err, nil), domain.ErrConcurrentUpdate)
+}
+
+func TestWithTx_TranslatesErrors(t *testing.T) {
+	ctx := context.Background()
+	queries := sqlc.New(testDB)
+	account := createAccount(t, queries)
+
+	err := postgres.WithTx(ctx, testDB, queries, func(q *sqlc.Queries) error {
+		_, err := q.CreateAccount(ctx, sqlc.CreateAccountParams{
+			Nickname:       "king dedede",
+			Email:          account.Email,
+			HashedPassword: "hashed",
+		})
+		return err
+	})
+	require.ErrorIs(t, err, domain.ErrEmailTaken)
+
+	notFound := errors.New("not found")
+	err = postgres.WithTx(ctx, testDB, queries, func(q *sqlc.Queries) error {
+		return notFound
+	})
+	require.ErrorIs(t, err, notFound)
+}
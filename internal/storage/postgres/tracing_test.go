@@ -0,0 +1,13 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryName(t *testing.T) {
+	require.Equal(t, "ListGames", queryName("-- name: ListGames :many\nSELECT id FROM games"))
+	require.Equal(t, "SELECT", queryName("  select 1"))
+	require.Equal(t, "query", queryName(""))
+}
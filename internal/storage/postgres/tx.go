@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	sqlc "github.com/kalogs-c/nerd-backlog/sql/sqlc_generated"
+)
+
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// WithTx runs fn with queries bound to a new transaction. The transaction is
+// committed when fn succeeds and rolled back otherwise. Driver errors, from fn
+// or the commit, come back translated by TranslateError.
+func WithTx(ctx context.Context, db TxBeginner, queries *sqlc.Queries, fn func(*sqlc.Queries) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return TranslateError(err, nil)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return TranslateError(fmt.Errorf("commit transaction: %w", err), nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+// Package search ranks games for the storage backends without a full-text
+// engine of their own.
+package search
+
+import (
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+)
+
+// Matching is a plain substring match on every term, with no stemming or
+// trigrams. Title hits weigh more than summary hits.
+const (
+	titleWeight      = 1.0
+	summaryWeight    = 0.5
+	wholeQueryWeight = 1.0
+	snippetWords     = 20
+	snippetLead      = 5
+)
+
+// Terms splits query into its distinct lowercase words, stripped of
+// punctuation.
+func Terms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(strings.ToLower(query)) {
+		term := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if term != "" && !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// Matches reports whether any term appears in the game's title or summary.
+func Matches(game domain.Game, terms []string) bool {
+	text := strings.ToLower(game.Title + " " + game.Summary)
+	return slices.ContainsFunc(terms, func(term string) bool { return strings.Contains(text, term) })
+}
+
+// Rank scores games against terms and returns the best limit of them, with
+// highlighted snippets.
+func Rank(games []domain.Game, terms []string, limit int) []domain.GameSearchResult {
+	phrase := strings.Join(terms, " ")
+
+	results := make([]domain.GameSearchResult, len(games))
+	for i, game := range games {
+		title := strings.ToLower(game.Title)
+		summary := strings.ToLower(game.Summary)
+
+		var score float64
+		for _, term := range terms {
+			if strings.Contains(title, term) {
+				score += titleWeight
+			}
+			if strings.Contains(summary, term) {
+				score += summaryWeight
+			}
+		}
+		if strings.Contains(domain.NormalizeTitle(game.Title), phrase) {
+			score += wholeQueryWeight
+		}
+
+		results[i] = domain.GameSearchResult{
+			Game:           game,
+			Score:          score,
+			TitleSnippet:   mark(game.Title, terms),
+			SummarySnippet: mark(summarySnippet(game.Summary, terms), terms),
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b domain.GameSearchResult) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Game.Title, b.Game.Title)
+	})
+
+	return results[:min(limit, len(results))]
+}
+
+// summarySnippet cuts the summary down to a window of words around the first
+// matching one, or its opening words when nothing matches.
+func summarySnippet(summary string, terms []string) string {
+	words := strings.Fields(summary)
+
+	start := 0
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		if slices.ContainsFunc(terms, func(term string) bool { return strings.Contains(lower, term) }) {
+			start = max(0, i-snippetLead)
+			break
+		}
+	}
+
+	end := min(len(words), start+snippetWords)
+	return strings.Join(words[start:end], " ")
+}
+
+// mark escapes text for HTML and wraps every occurrence of a term in <mark>.
+func mark(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	// Lowercasing changed byte offsets, so matches can't be mapped back.
+	if len(lower) != len(text) {
+		return html.EscapeString(text)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		matched := 0
+		for _, term := range terms {
+			if strings.HasPrefix(lower[i:], term) && len(term) > matched {
+				matched = len(term)
+			}
+		}
+
+		if matched == 0 {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			b.WriteString(html.EscapeString(text[i : i+size]))
+			i += size
+			continue
+		}
+
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[i : i+matched]))
+		b.WriteString("</mark>")
+		i += matched
+	}
+
+	return b.String()
+}
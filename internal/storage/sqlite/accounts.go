@@ -0,0 +1,194 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+const accountColumns = "accounts.id, accounts.nickname, accounts.email, accounts.hashed_password, " +
+	"accounts.role, accounts.disabled_at, accounts.inserted_at, accounts.updated_at, accounts.deleted_at"
+
+type accountRepository struct {
+	conn *sql.DB
+	// db is conn, or the transaction WithAccountsLock runs fn in.
+	db  querier
+	now func() time.Time
+}
+
+func NewAccountRepository(db *sql.DB) domain.AccountRepository {
+	return &accountRepository{db, db, time.Now}
+}
+
+func (r *accountRepository) CreateAccount(ctx context.Context, account domain.Account) (domain.Account, error) {
+	now := r.now().UTC().Truncate(time.Microsecond)
+	account.ID = uuid.New()
+	account.Role = account.Role.OrMember()
+	account.InsertedAt = now
+	account.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO accounts (id, nickname, email, hashed_password, role, inserted_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		account.ID.String(), account.Nickname, account.Email, account.HashedPassword, string(account.Role),
+		formatTime(now), formatTime(now),
+	)
+	if isUniqueViolation(err) {
+		return domain.Account{}, domain.ErrEmailTaken
+	}
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	return account, nil
+}
+
+func (r *accountRepository) GetAccountByEmail(ctx context.Context, email string) (domain.Account, error) {
+	return r.oneAccount(ctx, domain.ErrAccountNotFound, "SELECT "+accountColumns+" FROM accounts WHERE email = ?", email)
+}
+
+func (r *accountRepository) GetAccountByID(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	return r.oneAccount(ctx, domain.ErrAccountNotFound, "SELECT "+accountColumns+" FROM accounts WHERE id = ?", id.String())
+}
+
+func (r *accountRepository) ListAccounts(ctx context.Context) ([]domain.Account, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+accountColumns+" FROM accounts ORDER BY inserted_at, id")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	accounts := []domain.Account{}
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+func (r *accountRepository) CountAccounts(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT count(*) FROM accounts").Scan(&count)
+	return count, err
+}
+
+// WithAccountsLock needs no explicit lock: the database has a single
+// connection, so transactions never overlap.
+func (r *accountRepository) WithAccountsLock(ctx context.Context, fn func(repo domain.AccountRepository) error) error {
+	return withTx(ctx, r.conn, func(tx *sql.Tx) error {
+		return fn(&accountRepository{r.conn, tx, r.now})
+	})
+}
+
+func (r *accountRepository) UpdateAccountRole(ctx context.Context, id uuid.UUID, role auth.Role) (domain.Account, error) {
+	_, err := r.db.ExecContext(ctx, "UPDATE accounts SET role = ?, updated_at = ? WHERE id = ?",
+		string(role), formatTime(r.now()), id.String())
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	return r.GetAccountByID(ctx, id)
+}
+
+func (r *accountRepository) DisableAccount(ctx context.Context, id uuid.UUID) (domain.Account, error) {
+	now := formatTime(r.now())
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE accounts
+		SET disabled_at = COALESCE(disabled_at, ?), updated_at = ?
+		WHERE id = ?`,
+		now, now, id.String(),
+	)
+	if err != nil {
+		return domain.Account{}, err
+	}
+
+	// Sessions of disabled accounts are already ignored, so a failure here
+	// only leaves dead rows behind.
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM sessions WHERE account_id = ?", id.String()); err != nil {
+		return domain.Account{}, err
+	}
+
+	return r.GetAccountByID(ctx, id)
+}
+
+func (r *accountRepository) CreateSession(ctx context.Context, accountID uuid.UUID, token string, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO sessions (token, account_id, expires_at, inserted_at)
+		VALUES (?, ?, ?, ?)`,
+		token, accountID.String(), formatTime(expiresAt), formatTime(r.now()),
+	)
+	return err
+}
+
+func (r *accountRepository) GetSessionAccount(ctx context.Context, token string) (domain.Account, error) {
+	return r.oneAccount(ctx, domain.ErrSessionNotFound, `
+		SELECT `+accountColumns+` FROM sessions
+		JOIN accounts ON accounts.id = sessions.account_id
+		WHERE sessions.token = ? AND sessions.expires_at > ? AND accounts.disabled_at IS NULL`,
+		token, formatTime(r.now()),
+	)
+}
+
+func (r *accountRepository) DeleteSession(ctx context.Context, token string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM sessions WHERE token = ?", token)
+	return err
+}
+
+func (r *accountRepository) CountActiveSessions(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, "SELECT count(*) FROM sessions WHERE expires_at > ?", formatTime(r.now())).Scan(&count)
+	return count, err
+}
+
+func (r *accountRepository) oneAccount(ctx context.Context, notFound error, query string, args ...any) (domain.Account, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return domain.Account{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return domain.Account{}, err
+		}
+		return domain.Account{}, notFound
+	}
+
+	return scanAccount(rows)
+}
+
+func scanAccount(rows *sql.Rows) (domain.Account, error) {
+	var account domain.Account
+	var role string
+	var disabledAt, insertedAt, updatedAt, deletedAt sql.NullString
+
+	err := rows.Scan(&account.ID, &account.Nickname, &account.Email, &account.HashedPassword,
+		&role, &disabledAt, &insertedAt, &updatedAt, &deletedAt)
+	if err != nil {
+		return domain.Account{}, err
+	}
+	account.Role = auth.Role(role)
+
+	if account.DisabledAt, err = parseTime(disabledAt); err != nil {
+		return domain.Account{}, err
+	}
+	if account.InsertedAt, err = parseTime(insertedAt); err != nil {
+		return domain.Account{}, err
+	}
+	if account.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return domain.Account{}, err
+	}
+	if account.DeletedAt, err = parseTime(deletedAt); err != nil {
+		return domain.Account{}, err
+	}
+
+	return account, nil
+}
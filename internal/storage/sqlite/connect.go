@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	// The pure Go driver registers itself as "sqlite", so builds need no CGO.
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// timeLayout is how timestamps are stored. It is fixed width, so comparing the
+// text compares the instants.
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+const dateLayout = "2006-01-02"
+
+// pragmas are applied to every connection. Foreign keys are off by default in
+// SQLite, and the busy timeout makes writers wait instead of failing at once.
+var pragmas = []string{
+	"foreign_keys(1)",
+	"busy_timeout(5000)",
+	"journal_mode(WAL)",
+}
+
+func MustConnect(ctx context.Context, dsn string) *sql.DB {
+	db, err := Connect(ctx, dsn)
+	if err != nil {
+		log.Fatalf("db connection failed: %v", err)
+	}
+	return db
+}
+
+// Connect opens the database at dsn, a file path or file: URI as understood by
+// modernc.org/sqlite.
+func Connect(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", withPragmas(dsn))
+	if err != nil {
+		return nil, fmt.Errorf("open db %s failed: %w", dsn, err)
+	}
+
+	// SQLite allows one writer at a time, and every connection to an in-memory
+	// database would otherwise get a database of its own.
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("ping db error: %w", err)
+	}
+
+	return db, nil
+}
+
+func withPragmas(dsn string) string {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+
+	for _, pragma := range pragmas {
+		dsn += separator + "_pragma=" + pragma
+		separator = "&"
+	}
+
+	return dsn
+}
+
+// withTx runs fn inside a transaction, committing when it succeeds and rolling
+// back otherwise.
+func withTx(ctx context.Context, db *sql.DB, fn func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func parseTime(value sql.NullString) (time.Time, error) {
+	if !value.Valid {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(timeLayout, value.String)
+}
+
+func nullUUID(id uuid.UUID) sql.NullString {
+	if id == uuid.Nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: id.String(), Valid: true}
+}
+
+func nullDate(t time.Time) sql.NullString {
+	if t.IsZero() {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: t.Format(dateLayout), Valid: true}
+}
+
+func parseDate(value sql.NullString) (time.Time, error) {
+	if !value.Valid {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(dateLayout, value.String)
+}
+
+func isUniqueViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
@@ -0,0 +1,39 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/storage/sqlite/migrations"
+)
+
+// newTestDB returns a migrated database in a file of its own, removed when the
+// test ends.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := Connect(ctx, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	_, err = migrations.MustProvide(db).Up(ctx)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestConnect_ForeignKeys(t *testing.T) {
+	db := newTestDB(t)
+
+	var enabled int
+	require.NoError(t, db.QueryRow("PRAGMA foreign_keys").Scan(&enabled))
+	require.Equal(t, 1, enabled)
+
+	_, err := db.Exec("INSERT INTO game_platforms (game_id, platform) VALUES ('missing', 'pc')")
+	require.Error(t, err)
+}
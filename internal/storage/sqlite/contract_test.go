@@ -0,0 +1,17 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/kalogs-c/nerd-backlog/internal/storage/contracttest"
+)
+
+func TestContract(t *testing.T) {
+	contracttest.Run(t, func(t *testing.T) contracttest.Repositories {
+		db := newTestDB(t)
+		return contracttest.Repositories{
+			Games:    NewGameRepository(db),
+			Accounts: NewAccountRepository(db),
+		}
+	})
+}
@@ -0,0 +1,517 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/storage/search"
+)
+
+// querier is satisfied by both *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+const gameColumns = "games.id, games.title, games.release_date, games.summary, games.developer, " +
+	"games.publisher, games.cover_url, games.inserted_at, games.updated_at, games.created_by"
+
+type gameRepository struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewGameRepository(db *sql.DB) domain.GameRepository {
+	return &gameRepository{db, time.Now}
+}
+
+func (r *gameRepository) CreateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	return r.createGame(ctx, game, nil)
+}
+
+func (r *gameRepository) CreateGameWithExternalIDs(
+	ctx context.Context,
+	game domain.Game,
+	externalIDs []domain.ExternalID,
+) (domain.Game, error) {
+	created, err := r.createGame(ctx, game, externalIDs)
+
+	var taken externalIDTakenError
+	if errors.As(err, &taken) {
+		return r.GetGameByExternalID(ctx, taken.externalID)
+	}
+
+	return created, err
+}
+
+// externalIDTakenError rolls back a game whose external ID turned out to be
+// mapped to another game already.
+type externalIDTakenError struct {
+	externalID domain.ExternalID
+}
+
+func (e externalIDTakenError) Error() string {
+	return fmt.Sprintf("external id %s:%s already mapped", e.externalID.Source, e.externalID.ID)
+}
+
+func (r *gameRepository) createGame(ctx context.Context, game domain.Game, externalIDs []domain.ExternalID) (domain.Game, error) {
+	now := r.now().UTC()
+	game.ID = uuid.New()
+	game.InsertedAt = now
+	game.UpdatedAt = now
+
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO games (id, title, normalized_title, release_date, summary, developer, publisher, cover_url, created_by, inserted_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			game.ID.String(), game.Title, domain.NormalizeTitle(game.Title), nullDate(game.ReleaseDate),
+			game.Summary, game.Developer, game.Publisher, game.CoverURL, nullUUID(game.CreatedBy),
+			formatTime(now), formatTime(now),
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, externalID := range externalIDs {
+			result, err := tx.ExecContext(ctx, `
+				INSERT INTO external_ids (provider, external_id, game_id, inserted_at)
+				VALUES (?, ?, ?, ?)
+				ON CONFLICT DO NOTHING`,
+				string(externalID.Source), externalID.ID, game.ID.String(), formatTime(now),
+			)
+			if err != nil {
+				return err
+			}
+			claimed, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if claimed == 0 {
+				return externalIDTakenError{externalID}
+			}
+		}
+
+		return addCategories(ctx, tx, game.ID, game.Platforms, game.Genres)
+	})
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	return truncateTimestamps(game), nil
+}
+
+func (r *gameRepository) GetGameByID(ctx context.Context, id uuid.UUID) (domain.Game, error) {
+	return r.oneGame(ctx, "SELECT "+gameColumns+" FROM games WHERE id = ?", id.String())
+}
+
+func (r *gameRepository) GetGameByNormalizedTitle(ctx context.Context, normalizedTitle string) (domain.Game, error) {
+	return r.oneGame(ctx, `
+		SELECT `+gameColumns+` FROM games
+		WHERE normalized_title = ?
+		ORDER BY inserted_at, id
+		LIMIT 1`, normalizedTitle)
+}
+
+func (r *gameRepository) GetGameByExternalID(ctx context.Context, externalID domain.ExternalID) (domain.Game, error) {
+	return r.oneGame(ctx, `
+		SELECT `+gameColumns+` FROM games
+		JOIN external_ids ON external_ids.game_id = games.id
+		WHERE external_ids.provider = ? AND external_ids.external_id = ?`,
+		string(externalID.Source), externalID.ID)
+}
+
+func (r *gameRepository) oneGame(ctx context.Context, query string, args ...any) (domain.Game, error) {
+	games, err := r.queryGames(ctx, query, args...)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	if len(games) == 0 {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	return games[0], nil
+}
+
+// ListGames mirrors the Postgres keyset pagination, with missing release dates
+// sorting last in either direction.
+func (r *gameRepository) ListGames(ctx context.Context, params domain.ListGamesParams) (domain.GamePage, error) {
+	sortKey := "lower(games.title)"
+	switch params.Sort {
+	case domain.GameSortInsertedAt:
+		sortKey = "substr(games.inserted_at, 1, 26)"
+	case domain.GameSortReleaseDate:
+		sortKey = "games.release_date"
+	}
+
+	var where []string
+	var args []any
+	if params.Platform != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM game_platforms WHERE game_platforms.game_id = games.id AND game_platforms.platform = ?)")
+		args = append(args, params.Platform)
+	}
+	if params.Genre != "" {
+		where = append(where, "EXISTS (SELECT 1 FROM game_genres WHERE game_genres.game_id = games.id AND game_genres.genre = ?)")
+		args = append(args, params.Genre)
+	}
+	if params.TitlePrefix != "" {
+		where = append(where, "substr(lower(games.title), 1, length(?)) = lower(?)")
+		args = append(args, params.TitlePrefix, params.TitlePrefix)
+	}
+
+	direction, comparison := "ASC", ">"
+	if params.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if after := params.After; after != nil {
+		switch {
+		case params.Sort == domain.GameSortReleaseDate && after.SortKey == "":
+			where = append(where, "(sort_key IS NULL AND games.id "+comparison+" ?)")
+			args = append(args, after.ID.String())
+		case params.Sort == domain.GameSortReleaseDate:
+			where = append(where, "((sort_key, games.id) "+comparison+" (?, ?) OR sort_key IS NULL)")
+			args = append(args, after.SortKey, after.ID.String())
+		default:
+			where = append(where, "(sort_key, games.id) "+comparison+" (?, ?)")
+			args = append(args, after.SortKey, after.ID.String())
+		}
+	}
+
+	query := "SELECT * FROM (SELECT " + gameColumns + ", " + sortKey + " AS sort_key FROM games) AS games"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY sort_key " + direction + " NULLS LAST, games.id " + direction + " LIMIT ?"
+	args = append(args, params.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return domain.GamePage{}, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var games []domain.Game
+	var sortKeys []string
+	for rows.Next() {
+		var sortKey sql.NullString
+		game, err := scanGame(rows, &sortKey)
+		if err != nil {
+			return domain.GamePage{}, err
+		}
+		games = append(games, game)
+		sortKeys = append(sortKeys, sortKey.String)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.GamePage{}, err
+	}
+
+	var next *domain.GameCursor
+	if len(games) > params.Limit {
+		games = games[:params.Limit]
+		last := games[len(games)-1]
+		next = &domain.GameCursor{SortKey: sortKeys[len(games)-1], ID: last.ID}
+	}
+
+	games, err = r.withCategories(ctx, games)
+	if err != nil {
+		return domain.GamePage{}, err
+	}
+
+	return domain.GamePage{Games: games, Next: next}, nil
+}
+
+func (r *gameRepository) SearchGames(ctx context.Context, query string, limit int) ([]domain.GameSearchResult, error) {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return []domain.GameSearchResult{}, nil
+	}
+
+	conditions := make([]string, len(terms))
+	args := make([]any, len(terms))
+	for i, term := range terms {
+		conditions[i] = "instr(lower(games.title || ' ' || games.summary), ?) > 0"
+		args[i] = term
+	}
+
+	games, err := r.queryGames(ctx, "SELECT "+gameColumns+" FROM games WHERE "+strings.Join(conditions, " OR "), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return search.Rank(games, terms, limit), nil
+}
+
+func (r *gameRepository) UpdateGame(ctx context.Context, game domain.Game) (domain.Game, error) {
+	var updated domain.Game
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		updated, err = r.updateGame(ctx, tx, game)
+		return err
+	})
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	return updated, nil
+}
+
+func (r *gameRepository) updateGame(ctx context.Context, tx *sql.Tx, game domain.Game) (domain.Game, error) {
+	now := r.now().UTC()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE games
+		SET title = ?, normalized_title = ?, release_date = ?, summary = ?, developer = ?,
+			publisher = ?, cover_url = ?, updated_at = ?
+		WHERE id = ?`,
+		game.Title, domain.NormalizeTitle(game.Title), nullDate(game.ReleaseDate), game.Summary,
+		game.Developer, game.Publisher, game.CoverURL, formatTime(now), game.ID.String(),
+	)
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return domain.Game{}, err
+	}
+	if affected == 0 {
+		return domain.Game{}, domain.ErrGameNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM game_platforms WHERE game_id = ?", game.ID.String()); err != nil {
+		return domain.Game{}, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM game_genres WHERE game_id = ?", game.ID.String()); err != nil {
+		return domain.Game{}, err
+	}
+	if err := addCategories(ctx, tx, game.ID, game.Platforms, game.Genres); err != nil {
+		return domain.Game{}, err
+	}
+
+	var insertedAt sql.NullString
+	var createdBy uuid.NullUUID
+	err = tx.QueryRowContext(ctx, "SELECT inserted_at, created_by FROM games WHERE id = ?", game.ID.String()).
+		Scan(&insertedAt, &createdBy)
+	if err != nil {
+		return domain.Game{}, err
+	}
+	game.CreatedBy = createdBy.UUID
+
+	game.InsertedAt, err = parseTime(insertedAt)
+	if err != nil {
+		return domain.Game{}, err
+	}
+	game.UpdatedAt = now
+
+	return truncateTimestamps(game), nil
+}
+
+func (r *gameRepository) ListExternalIDs(ctx context.Context, gameID uuid.UUID) ([]domain.ExternalID, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT provider, external_id FROM external_ids
+		WHERE game_id = ?
+		ORDER BY provider, external_id`, gameID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	externalIDs := []domain.ExternalID{}
+	for rows.Next() {
+		var externalID domain.ExternalID
+		if err := rows.Scan(&externalID.Source, &externalID.ID); err != nil {
+			return nil, err
+		}
+		externalIDs = append(externalIDs, externalID)
+	}
+
+	return externalIDs, rows.Err()
+}
+
+// AddExternalIDs maps externalIDs to gameID. IDs already mapped, to this game
+// or another one, are left alone.
+func (r *gameRepository) AddExternalIDs(ctx context.Context, gameID uuid.UUID, externalIDs []domain.ExternalID) error {
+	for _, externalID := range externalIDs {
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO external_ids (provider, external_id, game_id, inserted_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT DO NOTHING`,
+			string(externalID.Source), externalID.ID, gameID.String(), formatTime(r.now()),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MergeGames saves target, moves the source's external IDs onto it and deletes
+// the source. Library entries and imports are not stored in SQLite, so there is
+// nothing else to repoint.
+func (r *gameRepository) MergeGames(ctx context.Context, sourceID uuid.UUID, target domain.Game) (domain.Game, error) {
+	var merged domain.Game
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		merged, err = r.updateGame(ctx, tx, target)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE external_ids SET game_id = ? WHERE game_id = ?",
+			target.ID.String(), sourceID.String())
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM games WHERE id = ?", sourceID.String())
+		return err
+	})
+	if err != nil {
+		return domain.Game{}, err
+	}
+
+	return merged, nil
+}
+
+func (r *gameRepository) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM games WHERE id = ?", id.String())
+	return err
+}
+
+func (r *gameRepository) queryGames(ctx context.Context, query string, args ...any) ([]domain.Game, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var games []domain.Game
+	for rows.Next() {
+		game, err := scanGame(rows)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.withCategories(ctx, games)
+}
+
+// withCategories loads platforms and genres for all games in one query each.
+func (r *gameRepository) withCategories(ctx context.Context, games []domain.Game) ([]domain.Game, error) {
+	if len(games) == 0 {
+		return games, nil
+	}
+
+	placeholders := make([]string, len(games))
+	ids := make([]any, len(games))
+	index := make(map[uuid.UUID]int, len(games))
+	for i, game := range games {
+		placeholders[i] = "?"
+		ids[i] = game.ID.String()
+		index[game.ID] = i
+	}
+	in := "(" + strings.Join(placeholders, ", ") + ")"
+
+	err := r.eachCategory(ctx, "SELECT game_id, platform FROM game_platforms WHERE game_id IN "+in+" ORDER BY platform", ids,
+		func(id uuid.UUID, platform string) {
+			games[index[id]].Platforms = append(games[index[id]].Platforms, platform)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachCategory(ctx, "SELECT game_id, genre FROM game_genres WHERE game_id IN "+in+" ORDER BY genre", ids,
+		func(id uuid.UUID, genre string) {
+			games[index[id]].Genres = append(games[index[id]].Genres, genre)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return games, nil
+}
+
+func (r *gameRepository) eachCategory(ctx context.Context, query string, args []any, fn func(uuid.UUID, string)) error {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			return err
+		}
+		fn(id, value)
+	}
+
+	return rows.Err()
+}
+
+func addCategories(ctx context.Context, q querier, gameID uuid.UUID, platforms []string, genres []string) error {
+	for _, platform := range platforms {
+		_, err := q.ExecContext(ctx, "INSERT INTO game_platforms (game_id, platform) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			gameID.String(), platform)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, genre := range genres {
+		_, err := q.ExecContext(ctx, "INSERT INTO game_genres (game_id, genre) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			gameID.String(), genre)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanGame(rows *sql.Rows, extra ...any) (domain.Game, error) {
+	var game domain.Game
+	var releaseDate, insertedAt, updatedAt sql.NullString
+	var createdBy uuid.NullUUID
+
+	dest := []any{&game.ID, &game.Title, &releaseDate, &game.Summary, &game.Developer,
+		&game.Publisher, &game.CoverURL, &insertedAt, &updatedAt, &createdBy}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return domain.Game{}, err
+	}
+	game.CreatedBy = createdBy.UUID
+
+	var err error
+	if game.ReleaseDate, err = parseDate(releaseDate); err != nil {
+		return domain.Game{}, err
+	}
+	if game.InsertedAt, err = parseTime(insertedAt); err != nil {
+		return domain.Game{}, err
+	}
+	if game.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return domain.Game{}, err
+	}
+
+	return game, nil
+}
+
+// truncateTimestamps drops the precision SQLite does not store, so a game
+// returned from a write equals the same game read back.
+func truncateTimestamps(game domain.Game) domain.Game {
+	game.InsertedAt = game.InsertedAt.Truncate(time.Microsecond)
+	game.UpdatedAt = game.UpdatedAt.Truncate(time.Microsecond)
+	return game
+}
@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SQLite has no UUID or TIMESTAMPTZ types: ids are stored as text and
+-- timestamps as fixed-width UTC text, which sorts chronologically.
+CREATE TABLE IF NOT EXISTS games (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    normalized_title TEXT NOT NULL DEFAULT '',
+    release_date TEXT,
+    summary TEXT NOT NULL DEFAULT '',
+    developer TEXT NOT NULL DEFAULT '',
+    publisher TEXT NOT NULL DEFAULT '',
+    cover_url TEXT NOT NULL DEFAULT '',
+    inserted_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS games_normalized_title_idx ON games (normalized_title);
+
+CREATE TABLE IF NOT EXISTS game_platforms (
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    PRIMARY KEY (game_id, platform)
+);
+
+CREATE INDEX IF NOT EXISTS game_platforms_platform_idx ON game_platforms (platform);
+
+CREATE TABLE IF NOT EXISTS game_genres (
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    genre TEXT NOT NULL,
+    PRIMARY KEY (game_id, genre)
+);
+
+CREATE INDEX IF NOT EXISTS game_genres_genre_idx ON game_genres (genre);
+
+CREATE TABLE IF NOT EXISTS external_ids (
+    provider TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    inserted_at TEXT NOT NULL,
+    PRIMARY KEY (provider, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS external_ids_game_id_idx ON external_ids (game_id);
+
+CREATE TABLE IF NOT EXISTS accounts (
+    id TEXT PRIMARY KEY,
+    nickname TEXT NOT NULL,
+    email TEXT NOT NULL UNIQUE,
+    hashed_password TEXT NOT NULL,
+    inserted_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    deleted_at TEXT
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    token TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    expires_at TEXT NOT NULL,
+    inserted_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS sessions_account_id_idx ON sessions (account_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS accounts;
+DROP TABLE IF EXISTS external_ids;
+DROP TABLE IF EXISTS game_genres;
+DROP TABLE IF EXISTS game_platforms;
+DROP TABLE IF EXISTS games;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE games
+    ADD COLUMN created_by TEXT REFERENCES accounts(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS games_created_by_idx ON games (created_by);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS games_created_by_idx;
+
+ALTER TABLE games
+    DROP COLUMN created_by;
+-- +goose StatementEnd
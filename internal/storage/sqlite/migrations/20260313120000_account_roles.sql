@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('member', 'admin'));
+
+ALTER TABLE accounts
+    ADD COLUMN disabled_at TEXT;
+
+UPDATE accounts
+SET role = 'admin'
+WHERE id = (SELECT id FROM accounts ORDER BY inserted_at, id LIMIT 1);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts
+    DROP COLUMN disabled_at;
+
+ALTER TABLE accounts
+    DROP COLUMN role;
+-- +goose StatementEnd
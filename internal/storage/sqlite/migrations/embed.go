@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"database/sql"
+	"embed"
+	"log"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/database"
+)
+
+//go:embed *.sql
+var Embed embed.FS
+
+func MustProvide(db *sql.DB) *goose.Provider {
+	provider, err := Provide(db)
+	if err != nil {
+		log.Fatalf("migrations provider failed %v", err)
+	}
+	return provider
+}
+
+func Provide(db *sql.DB) (*goose.Provider, error) {
+	return goose.NewProvider(database.DialectSQLite3, db, Embed)
+}
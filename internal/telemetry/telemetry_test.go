@@ -0,0 +1,62 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestLogHandler_AddsTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&buf, nil))).With("app", "test")
+
+	logger.InfoContext(context.Background(), "untraced")
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.NotContains(t, record, "trace_id")
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	buf.Reset()
+	logger.WithGroup("request").InfoContext(ctx, "traced", "path", "/")
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "test", record["app"])
+
+	request := record["request"].(map[string]any)
+	require.Equal(t, span.SpanContext().TraceID().String(), request["trace_id"])
+	require.Equal(t, span.SpanContext().SpanID().String(), request["span_id"])
+}
+
+func TestSetup_FileExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	file := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterFile, File: file, ServiceVersion: "test"})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "GET /api/games")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	contents, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var exported struct{ Name string }
+	require.NoError(t, json.Unmarshal(contents, &exported))
+	require.Equal(t, "GET /api/games", exported.Name)
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Exporter: "carrier-pigeon"})
+	require.ErrorContains(t, err, "carrier-pigeon")
+}
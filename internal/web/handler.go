@@ -0,0 +1,213 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/kalogs-c/nerd-backlog/internal/accounts"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+	"github.com/kalogs-c/nerd-backlog/pkg/validator"
+)
+
+// RequireSession lets signed-in requests through and sends everyone else to
+// the login page.
+func (h *Handler) RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(auth.SessionCookieName)
+		if err != nil || cookie.Value == "" {
+			redirect(w, r, "/login")
+			return
+		}
+
+		account, err := h.sessions.GetSessionAccount(r.Context(), cookie.Value)
+		if err != nil {
+			redirect(w, r, "/login")
+			return
+		}
+
+		ctx := auth.WithAccountID(r.Context(), account.ID)
+		ctx = auth.WithRole(ctx, account.Role)
+		ctx = auth.WithSessionToken(ctx, cookie.Value)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/library", http.StatusSeeOther)
+}
+
+type accountForm struct {
+	Nickname string
+	Email    string
+}
+
+func (h *Handler) LoginPage(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, http.StatusOK, "login", "form", view{Title: "Login", Data: accountForm{}})
+}
+
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	payload := accounts.LoginPayload{
+		Email:    strings.TrimSpace(r.PostFormValue("email")),
+		Password: r.PostFormValue("password"),
+	}
+	form := accountForm{Email: payload.Email}
+
+	problems := payload.Valid(r.Context())
+	if len(problems) > 0 {
+		h.renderForm(w, r, "login", form, problems)
+		return
+	}
+
+	_, session, err := h.accounts.Login(r.Context(), payload.Email, payload.Password)
+	if errors.Is(err, domain.ErrInvalidCredentials) || errors.Is(err, domain.ErrAccountDisabled) {
+		problems.Add("email", err.Error())
+		h.renderForm(w, r, "login", form, problems)
+		return
+	}
+	if err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to login", err)
+		return
+	}
+
+	accounts.SetSessionCookie(w, r, session)
+	redirect(w, r, "/library")
+}
+
+func (h *Handler) RegisterPage(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, http.StatusOK, "register", "form", view{Title: "Register", Data: accountForm{}})
+}
+
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	payload := accounts.RegisterPayload{
+		Nickname: strings.TrimSpace(r.PostFormValue("nickname")),
+		Email:    strings.TrimSpace(r.PostFormValue("email")),
+		Password: r.PostFormValue("password"),
+	}
+	form := accountForm{Nickname: payload.Nickname, Email: payload.Email}
+
+	problems := payload.Valid(r.Context())
+	if len(problems) > 0 {
+		h.renderForm(w, r, "register", form, problems)
+		return
+	}
+
+	_, session, err := h.accounts.Register(r.Context(), payload.Nickname, payload.Email, payload.Password)
+	if errors.Is(err, domain.ErrEmailTaken) {
+		problems.Add("email", "email already registered")
+		h.renderForm(w, r, "register", form, problems)
+		return
+	}
+	if err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to register", err)
+		return
+	}
+
+	accounts.SetSessionCookie(w, r, session)
+	redirect(w, r, "/library")
+}
+
+func (h *Handler) renderForm(w http.ResponseWriter, r *http.Request, page string, form accountForm, problems validator.Problems) {
+	title := strings.ToUpper(page[:1]) + page[1:]
+	h.render(w, r, http.StatusUnprocessableEntity, page, "form", view{
+		Title:    title,
+		Problems: problems,
+		Data:     form,
+	})
+}
+
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	token, _ := auth.SessionTokenFromContext(r.Context())
+	if err := h.accounts.LogoutSession(r.Context(), token); err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to logout", err)
+		return
+	}
+
+	accounts.ClearSessionCookie(w, r)
+	redirect(w, r, "/login")
+}
+
+type libraryPage struct {
+	Entries  []domain.LibraryEntry
+	Statuses []domain.LibraryStatus
+	Status   string
+}
+
+// Library lists the account's entries. The status filter re-renders only the
+// table when changed through HTMX.
+func (h *Handler) Library(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status != "" {
+		if _, err := domain.ParseLibraryStatus(status); err != nil {
+			h.error(w, r, http.StatusBadRequest, "unknown library status", err)
+			return
+		}
+	}
+
+	entries, err := h.library.ListEntries(r.Context())
+	if err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to list library", err)
+		return
+	}
+
+	if status != "" {
+		filtered := entries[:0]
+		for _, entry := range entries {
+			if string(entry.Status) == status {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	h.render(w, r, http.StatusOK, "library", "library-table", view{
+		Title: "Library",
+		Data: libraryPage{
+			Entries:  entries,
+			Statuses: domain.LibraryStatuses,
+			Status:   status,
+		},
+	})
+}
+
+type gamePage struct {
+	Game  domain.Game
+	Entry *domain.LibraryEntry
+}
+
+func (h *Handler) Game(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.error(w, r, http.StatusNotFound, "game not found", err)
+		return
+	}
+
+	game, err := h.games.GetGameByID(r.Context(), id)
+	if errors.Is(err, domain.ErrGameNotFound) {
+		h.error(w, r, http.StatusNotFound, "game not found", err)
+		return
+	}
+	if err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to retrieve game", err)
+		return
+	}
+
+	entries, err := h.library.ListEntries(r.Context())
+	if err != nil {
+		h.error(w, r, http.StatusInternalServerError, "failed to list library", err)
+		return
+	}
+
+	page := gamePage{Game: game}
+	for _, entry := range entries {
+		if entry.GameID == game.ID {
+			page.Entry = &entry
+			break
+		}
+	}
+
+	h.render(w, r, http.StatusOK, "game", "content", view{Title: game.Title, Data: page})
+}
@@ -0,0 +1,346 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kalogs-c/nerd-backlog/internal/accounts"
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/internal/games"
+	"github.com/kalogs-c/nerd-backlog/internal/library"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+)
+
+type stubSessionStore struct {
+	accountID uuid.UUID
+	err       error
+}
+
+func (s stubSessionStore) GetSessionAccount(ctx context.Context, token string) (domain.Account, error) {
+	return domain.Account{ID: s.accountID}, s.err
+}
+
+type fixture struct {
+	accounts *accounts.MockAccountService
+	games    *games.MockGameService
+	library  *library.MockLibraryService
+	handler  *Handler
+}
+
+func newFixture(sessions SessionStore) fixture {
+	f := fixture{
+		accounts: new(accounts.MockAccountService),
+		games:    new(games.MockGameService),
+		library:  new(library.MockLibraryService),
+	}
+	f.handler = NewHandler(f.accounts, f.games, f.library, sessions, slog.Default())
+	return f
+}
+
+func signedIn(req *http.Request) *http.Request {
+	return req.WithContext(auth.WithAccountID(req.Context(), uuid.New()))
+}
+
+func postForm(path string, values url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestHandler_RequireSession(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("no cookie", func(t *testing.T) {
+		f := newFixture(stubSessionStore{accountID: uuid.New()})
+		w := httptest.NewRecorder()
+
+		f.handler.RequireSession(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/library", nil))
+
+		require.Equal(t, http.StatusSeeOther, w.Code)
+		require.Equal(t, "/login", w.Header().Get("Location"))
+	})
+
+	t.Run("invalid session over htmx", func(t *testing.T) {
+		f := newFixture(stubSessionStore{err: domain.ErrSessionNotFound})
+		req := httptest.NewRequest(http.MethodGet, "/library", nil)
+		req.Header.Set("HX-Request", "true")
+		req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: "stale"})
+		w := httptest.NewRecorder()
+
+		f.handler.RequireSession(next).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNoContent, w.Code)
+		require.Equal(t, "/login", w.Header().Get("HX-Redirect"))
+	})
+
+	t.Run("valid session", func(t *testing.T) {
+		f := newFixture(stubSessionStore{accountID: uuid.New()})
+		req := httptest.NewRequest(http.MethodGet, "/library", nil)
+		req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: "token"})
+		w := httptest.NewRecorder()
+
+		f.handler.RequireSession(next).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestHandler_LoginPage(t *testing.T) {
+	f := newFixture(nil)
+	w := httptest.NewRecorder()
+
+	f.handler.LoginPage(w, httptest.NewRequest(http.MethodGet, "/login", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "<!DOCTYPE html>")
+	require.Contains(t, w.Body.String(), `id="login-form"`)
+}
+
+func TestHandler_Login(t *testing.T) {
+	f := newFixture(nil)
+	session := domain.Session{Token: "token", ExpiresAt: time.Now().Add(time.Hour)}
+	f.accounts.On("Login", mock.Anything, "mario@example.com", "itsame123").Return(domain.Account{}, session, nil)
+
+	w := httptest.NewRecorder()
+	f.handler.Login(w, postForm("/login", url.Values{
+		"email":    {"mario@example.com"},
+		"password": {"itsame123"},
+	}))
+
+	require.Equal(t, http.StatusSeeOther, w.Code)
+	require.Equal(t, "/library", w.Header().Get("Location"))
+	require.Len(t, w.Result().Cookies(), 1)
+	require.Equal(t, "token", w.Result().Cookies()[0].Value)
+}
+
+func TestHandler_Login_InvalidCredentialsOverHTMX(t *testing.T) {
+	f := newFixture(nil)
+	f.accounts.On("Login", mock.Anything, "mario@example.com", "wrong").
+		Return(domain.Account{}, domain.Session{}, domain.ErrInvalidCredentials)
+
+	req := postForm("/login", url.Values{"email": {"mario@example.com"}, "password": {"wrong"}})
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+
+	f.handler.Login(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	body := w.Body.String()
+	require.NotContains(t, body, "<!DOCTYPE html>")
+	require.Contains(t, body, "invalid email or password")
+	require.Contains(t, body, `value="mario@example.com"`)
+}
+
+func TestHandler_Register(t *testing.T) {
+	f := newFixture(nil)
+	session := domain.Session{Token: "token", ExpiresAt: time.Now().Add(time.Hour)}
+	f.accounts.On("Register", mock.Anything, "luigi", "luigi@example.com", "greenbros").
+		Return(domain.Account{}, session, nil)
+
+	req := postForm("/register", url.Values{
+		"nickname": {"luigi"},
+		"email":    {"luigi@example.com"},
+		"password": {"greenbros"},
+	})
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+
+	f.handler.Register(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, "/library", w.Header().Get("HX-Redirect"))
+	f.accounts.AssertExpectations(t)
+}
+
+func TestHandler_Register_Invalid(t *testing.T) {
+	f := newFixture(nil)
+	w := httptest.NewRecorder()
+
+	f.handler.Register(w, postForm("/register", url.Values{
+		"nickname": {"luigi"},
+		"email":    {"not-an-email"},
+		"password": {"short"},
+	}))
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), "password must be at least 8 characters long")
+	f.accounts.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_Register_EmailTaken(t *testing.T) {
+	f := newFixture(nil)
+	f.accounts.On("Register", mock.Anything, "luigi", "luigi@example.com", "greenbros").
+		Return(domain.Account{}, domain.Session{}, domain.ErrEmailTaken)
+
+	w := httptest.NewRecorder()
+	f.handler.Register(w, postForm("/register", url.Values{
+		"nickname": {"luigi"},
+		"email":    {"luigi@example.com"},
+		"password": {"greenbros"},
+	}))
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), "email already registered")
+}
+
+func TestHandler_Library(t *testing.T) {
+	entries := []domain.LibraryEntry{
+		{GameID: uuid.New(), GameTitle: "Chrono Trigger", Status: domain.LibraryStatusPlaying, PlaytimeMinutes: 90},
+		{GameID: uuid.New(), GameTitle: "Final Fantasy VI", Status: domain.LibraryStatusBacklog},
+	}
+
+	t.Run("full page", func(t *testing.T) {
+		f := newFixture(nil)
+		f.library.On("ListEntries", mock.Anything).Return(entries, nil)
+		w := httptest.NewRecorder()
+
+		f.handler.Library(w, signedIn(httptest.NewRequest(http.MethodGet, "/library", nil)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		require.Contains(t, body, "<!DOCTYPE html>")
+		require.Contains(t, body, "Logout")
+		require.Contains(t, body, "Chrono Trigger")
+		require.Contains(t, body, "1.5 h")
+		require.Contains(t, body, "Final Fantasy VI")
+	})
+
+	t.Run("filtered fragment", func(t *testing.T) {
+		f := newFixture(nil)
+		f.library.On("ListEntries", mock.Anything).Return(entries, nil)
+		req := signedIn(httptest.NewRequest(http.MethodGet, "/library?status=backlog", nil))
+		req.Header.Set("HX-Request", "true")
+		w := httptest.NewRecorder()
+
+		f.handler.Library(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		require.True(t, strings.HasPrefix(strings.TrimSpace(body), `<table id="library-table"`))
+		require.Contains(t, body, "Final Fantasy VI")
+		require.NotContains(t, body, "Chrono Trigger")
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		f := newFixture(nil)
+		w := httptest.NewRecorder()
+
+		f.handler.Library(w, signedIn(httptest.NewRequest(http.MethodGet, "/library?status=beaten", nil)))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		f.library.AssertNotCalled(t, "ListEntries", mock.Anything)
+	})
+}
+
+func TestHandler_Game(t *testing.T) {
+	f := newFixture(nil)
+	game := domain.Game{
+		ID:        uuid.New(),
+		Title:     "Deus Ex",
+		Developer: "Ion Storm",
+		Platforms: []string{"pc", "ps2"},
+		Summary:   "A conspiracy thriller.",
+	}
+	f.games.On("GetGameByID", mock.Anything, game.ID).Return(game, nil)
+	f.library.On("ListEntries", mock.Anything).Return([]domain.LibraryEntry{
+		{GameID: game.ID, Status: domain.LibraryStatusCompleted, Rating: 10, Notes: "Never asked for this."},
+	}, nil)
+
+	req := signedIn(httptest.NewRequest(http.MethodGet, "/games/"+game.ID.String(), nil))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", game.ID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	f.handler.Game(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	require.Contains(t, body, "<title>Deus Ex - Nerd Backlog</title>")
+	require.Contains(t, body, "pc, ps2")
+	require.Contains(t, body, "10/10")
+	require.Contains(t, body, "Never asked for this.")
+}
+
+func TestHandler_Game_NotFound(t *testing.T) {
+	f := newFixture(nil)
+	id := uuid.New()
+	f.games.On("GetGameByID", mock.Anything, id).Return(domain.Game{}, domain.ErrGameNotFound)
+
+	req := signedIn(httptest.NewRequest(http.MethodGet, "/games/"+id.String(), nil))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	w := httptest.NewRecorder()
+
+	f.handler.Game(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Contains(t, w.Body.String(), "game not found")
+}
+
+func TestHandler_Logout(t *testing.T) {
+	f := newFixture(nil)
+	f.accounts.On("LogoutSession", mock.Anything, "token").Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	req = req.WithContext(auth.WithSessionToken(req.Context(), "token"))
+	w := httptest.NewRecorder()
+
+	f.handler.Logout(w, req)
+
+	require.Equal(t, http.StatusSeeOther, w.Code)
+	require.Equal(t, "/login", w.Header().Get("Location"))
+	require.Equal(t, -1, w.Result().Cookies()[0].MaxAge)
+}
+
+func TestHandler_Logout_Error(t *testing.T) {
+	f := newFixture(nil)
+	f.accounts.On("LogoutSession", mock.Anything, "token").Return(errors.New("db down"))
+
+	req := httptest.NewRequest(http.MethodPost, "/logout", nil)
+	req = req.WithContext(auth.WithSessionToken(req.Context(), "token"))
+	w := httptest.NewRecorder()
+
+	f.handler.Logout(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestStatic(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	http.StripPrefix("/static/", Static()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/static/style.css", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Type"), "text/css")
+}
+
+func TestStatic_ServesLayoutAssets(t *testing.T) {
+	layout, err := files.ReadFile("templates/layout.html")
+	require.NoError(t, err)
+
+	assets := regexp.MustCompile(`(?:src|href)="(/static/[^"]+)"`).FindAllStringSubmatch(string(layout), -1)
+	require.NotEmpty(t, assets)
+
+	for _, asset := range assets {
+		w := httptest.NewRecorder()
+		http.StripPrefix("/static/", Static()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, asset[1], nil))
+		require.Equal(t, http.StatusOK, w.Code, asset[1])
+	}
+}
@@ -0,0 +1,161 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kalogs-c/nerd-backlog/internal/domain"
+	"github.com/kalogs-c/nerd-backlog/pkg/auth"
+	"github.com/kalogs-c/nerd-backlog/pkg/validator"
+)
+
+//go:embed templates static
+var files embed.FS
+
+const dateLayout = "2006-01-02"
+
+// pages lists every page template. Each one is parsed together with the
+// layout, so pages can share block names without clashing.
+var pages = []string{"login", "register", "library", "game", "error"}
+
+type SessionStore interface {
+	GetSessionAccount(ctx context.Context, token string) (domain.Account, error)
+}
+
+// Handler serves the HTML UI. Requests sent by HTMX, flagged with the
+// HX-Request header, get only the fragment they target instead of the full
+// layout.
+type Handler struct {
+	accounts  domain.AccountService
+	games     domain.GameService
+	library   domain.LibraryService
+	sessions  SessionStore
+	logger    *slog.Logger
+	templates map[string]*template.Template
+}
+
+func NewHandler(
+	accounts domain.AccountService,
+	games domain.GameService,
+	library domain.LibraryService,
+	sessions SessionStore,
+	logger *slog.Logger,
+) *Handler {
+	return &Handler{accounts, games, library, sessions, logger, parseTemplates()}
+}
+
+// parseTemplates panics on error, the templates are embedded so a broken one
+// can only come from a bad build.
+func parseTemplates() map[string]*template.Template {
+	funcs := template.FuncMap{
+		"date":     formatDate,
+		"playtime": formatPlaytime,
+		"join":     strings.Join,
+	}
+
+	templates := make(map[string]*template.Template, len(pages))
+	for _, page := range pages {
+		templates[page] = template.Must(template.New(page).Funcs(funcs).ParseFS(
+			files,
+			"templates/layout.html",
+			"templates/"+page+".html",
+		))
+	}
+
+	return templates
+}
+
+// Static serves the embedded CSS and scripts.
+func Static() http.Handler {
+	static, err := fs.Sub(files, "static")
+	if err != nil {
+		panic(err)
+	}
+
+	return http.FileServerFS(static)
+}
+
+// view is what every template receives. Data holds the page specific values.
+type view struct {
+	Title    string
+	SignedIn bool
+	Problems validator.Problems
+	Data     any
+}
+
+func isHTMX(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true"
+}
+
+// render writes page wrapped in the layout, or just its fragment block when
+// HTMX asked for it. Templates are executed into a buffer first so a failing
+// template doesn't leave half a page behind.
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, status int, page string, fragment string, v view) {
+	_, v.SignedIn = auth.AccountIDFromContext(r.Context())
+
+	name := "layout"
+	if isHTMX(r) && fragment != "" {
+		name = fragment
+	}
+
+	var buf bytes.Buffer
+	if err := h.templates[page].ExecuteTemplate(&buf, name, v); err != nil {
+		h.logger.Error("failed to render template", "page", page, "template", name, "err", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = buf.WriteTo(w)
+}
+
+func (h *Handler) error(w http.ResponseWriter, r *http.Request, status int, message string, err error) {
+	if status >= http.StatusInternalServerError {
+		h.logger.Error(message, "path", r.URL.Path, "err", err)
+	}
+
+	h.render(w, r, status, "error", "content", view{
+		Title: http.StatusText(status),
+		Data:  message,
+	})
+}
+
+// redirect sends the browser to path. HTMX requests get an HX-Redirect header
+// instead, since a plain redirect would only swap the target fragment.
+func redirect(w http.ResponseWriter, r *http.Request, path string) {
+	if isHTMX(r) {
+		w.Header().Set("HX-Redirect", path)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	http.Redirect(w, r, path, http.StatusSeeOther)
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return t.Format(dateLayout)
+}
+
+func formatPlaytime(minutes int) string {
+	if minutes == 0 {
+		return "-"
+	}
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+
+	return fmt.Sprintf("%.1f h", float64(minutes)/60)
+}
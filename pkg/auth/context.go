@@ -11,8 +11,25 @@ type contextKey string
 const (
 	accountIDKey    contextKey = "account_id"
 	sessionTokenKey contextKey = "session_token"
+	roleKey         contextKey = "role"
 )
 
+type Role string
+
+const (
+	RoleMember Role = "member"
+	RoleAdmin  Role = "admin"
+)
+
+// OrMember treats an unset role as RoleMember.
+func (r Role) OrMember() Role {
+	if r == "" {
+		return RoleMember
+	}
+
+	return r
+}
+
 func WithAccountID(ctx context.Context, accountID uuid.UUID) context.Context {
 	return context.WithValue(ctx, accountIDKey, accountID)
 }
@@ -40,3 +57,14 @@ func SessionTokenFromContext(ctx context.Context) (string, bool) {
 	token, ok := value.(string)
 	return token, ok
 }
+
+func WithRole(ctx context.Context, role Role) context.Context {
+	return context.WithValue(ctx, roleKey, role)
+}
+
+// RoleFromContext falls back to RoleMember, so a request nobody vouched for
+// never gains privileges.
+func RoleFromContext(ctx context.Context) Role {
+	role, _ := ctx.Value(roleKey).(Role)
+	return role.OrMember()
+}
@@ -17,10 +17,23 @@ func Encode[T any](w http.ResponseWriter, r *http.Request, status int, payload T
 	return nil
 }
 
+// DecodeError reports a request body that isn't the JSON expected.
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode json: %v", e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
 func Decode[T any](r *http.Request) (T, error) {
 	var v T
 	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
-		return v, fmt.Errorf("decode json: %w", err)
+		return v, &DecodeError{err}
 	}
 
 	return v, nil
@@ -12,47 +12,65 @@ type InvalidParam struct {
 	Reason string `json:"reason"`
 }
 
+// ErrorResponse is an RFC 9457 problem details body.
 type ErrorResponse struct {
+	Type          string         `json:"type"`
 	Title         string         `json:"title"`
 	Detail        string         `json:"detail,omitempty"`
 	Status        int            `json:"status"`
+	Instance      string         `json:"instance,omitempty"`
 	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+	AllowedStates []string       `json:"allowed-states,omitempty"`
 }
 
-func EncodeError(w http.ResponseWriter, r *http.Request, status int, title string, detail string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	resp := ErrorResponse{
-		Title:  title,
-		Detail: detail,
-		Status: status,
-	}
+// StateConflict is implemented by errors that reject a state change and know
+// which states the resource could have moved to instead.
+type StateConflict interface {
+	error
+	AllowedStates() []string
+}
 
+func writeProblem(w http.ResponseWriter, resp ErrorResponse) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(resp.Status)
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+// EncodeError writes a problem with no type of its own, for errors that
+// aren't in the registry such as malformed path parameters.
+func EncodeError(w http.ResponseWriter, r *http.Request, status int, title string, detail string) {
+	writeProblem(w, ErrorResponse{
+		Type:     DefaultType,
+		Title:    title,
+		Detail:   detail,
+		Status:   status,
+		Instance: r.URL.Path,
+	})
+}
+
 func EncodeValidationErrors(w http.ResponseWriter, r *http.Request, problems map[string][]string) {
-	invalidParams := make([]InvalidParam, 0, len(problems))
+	writeProblem(w, ErrorResponse{
+		Type:          ProblemValidation.Type,
+		Title:         ProblemValidation.Title,
+		Detail:        "Some fields are invalid",
+		Status:        ProblemValidation.Status,
+		Instance:      r.URL.Path,
+		InvalidParams: invalidParams(problems),
+	})
+}
+
+func invalidParams(problems map[string][]string) []InvalidParam {
+	params := make([]InvalidParam, 0, len(problems))
 	for field, reasons := range problems {
 		for _, reason := range reasons {
-			invalidParams = append(invalidParams, InvalidParam{
+			params = append(params, InvalidParam{
 				Name:   field,
 				Reason: reason,
 			})
 		}
 	}
 
-	resp := ErrorResponse{
-		Title:         "Validation failed",
-		Detail:        "Some fields are invalid",
-		Status:        http.StatusUnprocessableEntity,
-		InvalidParams: invalidParams,
-	}
-
-	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(http.StatusUnprocessableEntity)
-	_ = json.NewEncoder(w).Encode(resp)
+	return params
 }
 
 func NotifyError(
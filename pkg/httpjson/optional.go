@@ -0,0 +1,28 @@
+package httpjson
+
+import "encoding/json"
+
+// Optional is a payload field that remembers whether it was present in the
+// request body and whether it was an explicit null, which is what JSON merge
+// patch (RFC 7386) needs to tell "leave as is" apart from "remove".
+type Optional[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+
+	if string(data) == "null" {
+		o.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &o.Value)
+}
+
+// Present reports whether the field was sent with a non-null value.
+func (o Optional[T]) Present() bool {
+	return o.Set && !o.Null
+}
@@ -0,0 +1,143 @@
+package httpjson
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/kalogs-c/nerd-backlog/pkg/validator"
+)
+
+// Problem describes a kind of error as RFC 9457 problem details. Type is a
+// stable URI clients can switch on; Title is its human readable summary and
+// should not change between occurrences.
+type Problem struct {
+	Type   string
+	Title  string
+	Status int
+}
+
+// DefaultType is what RFC 9457 prescribes when a problem has no semantics
+// beyond its status code.
+const DefaultType = "about:blank"
+
+var (
+	ProblemInternal = Problem{
+		Type:   DefaultType,
+		Title:  http.StatusText(http.StatusInternalServerError),
+		Status: http.StatusInternalServerError,
+	}
+	ProblemInvalidPayload = Problem{
+		Type:   "/problems/invalid-payload",
+		Title:  "Invalid payload",
+		Status: http.StatusBadRequest,
+	}
+	ProblemValidation = Problem{
+		Type:   "/problems/validation-failed",
+		Title:  "Validation failed",
+		Status: http.StatusUnprocessableEntity,
+	}
+)
+
+type matcher struct {
+	match   func(error) bool
+	problem Problem
+}
+
+// Registry maps errors to problems. Matchers are tried in registration order
+// and the first hit wins, so register specific errors before broad ones.
+type Registry struct {
+	mu       sync.RWMutex
+	matchers []matcher
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register maps every error that errors.Is target to problem.
+func (r *Registry) Register(target error, problem Problem) {
+	r.RegisterFunc(func(err error) bool { return errors.Is(err, target) }, problem)
+}
+
+func (r *Registry) RegisterFunc(match func(error) bool, problem Problem) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.matchers = append(r.matchers, matcher{match, problem})
+}
+
+// Lookup returns the problem registered for err, or ProblemInternal.
+func (r *Registry) Lookup(err error) Problem {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, m := range r.matchers {
+		if m.match(err) {
+			return m.problem
+		}
+	}
+
+	return ProblemInternal
+}
+
+// DefaultRegistry is the registry NotifyProblem consults. Payload errors from
+// Decode and DecodeValid are registered up front.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	RegisterErrorType[validator.ValidationError](ProblemValidation)
+	RegisterErrorType[*DecodeError](ProblemInvalidPayload)
+}
+
+func RegisterError(target error, problem Problem) {
+	DefaultRegistry.Register(target, problem)
+}
+
+// RegisterErrorType maps every error that errors.As a T to problem.
+func RegisterErrorType[T error](problem Problem) {
+	DefaultRegistry.RegisterFunc(func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}, problem)
+}
+
+// NotifyProblem answers with the problem registered for err. Client errors
+// carry err's message as detail; server errors only carry action. Either way
+// err is logged as a value, so errors that implement slog.LogValuer can log
+// more than they show clients.
+func NotifyProblem(w http.ResponseWriter, r *http.Request, logger *slog.Logger, action string, err error) {
+	problem := DefaultRegistry.Lookup(err)
+
+	resp := ErrorResponse{
+		Type:     problem.Type,
+		Title:    problem.Title,
+		Status:   problem.Status,
+		Detail:   err.Error(),
+		Instance: r.URL.Path,
+	}
+
+	var validation validator.ValidationError
+	if errors.As(err, &validation) {
+		resp.Detail = "Some fields are invalid"
+		resp.InvalidParams = invalidParams(validation.Problems)
+	}
+
+	var conflict StateConflict
+	if errors.As(err, &conflict) {
+		resp.AllowedStates = conflict.AllowedStates()
+		if resp.AllowedStates == nil {
+			resp.AllowedStates = []string{}
+		}
+	}
+
+	if problem.Status >= http.StatusInternalServerError {
+		logger.ErrorContext(r.Context(), action, "err", err)
+		resp.Detail = action
+	} else {
+		logger.WarnContext(r.Context(), action, "err", err)
+	}
+
+	writeProblem(w, resp)
+}
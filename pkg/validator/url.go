@@ -0,0 +1,15 @@
+package validator
+
+import (
+	"errors"
+	"net/url"
+)
+
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid url")
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS library_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'backlog'
+        CHECK (status IN ('backlog', 'playing', 'completed', 'dropped', 'wishlist', 'on_hold')),
+    inserted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (account_id, game_id)
+);
+
+CREATE INDEX IF NOT EXISTS library_entries_account_id_idx ON library_entries (account_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS library_entries;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE library_entries
+    ADD COLUMN started_at TIMESTAMPTZ,
+    ADD COLUMN finished_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE library_entries
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS finished_at;
+-- +goose StatementEnd
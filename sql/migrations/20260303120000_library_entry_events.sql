@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE library_entries
+    ADD COLUMN rating SMALLINT CHECK (rating BETWEEN 1 AND 10),
+    ADD COLUMN notes TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS library_entry_events (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES library_entries(id) ON DELETE CASCADE,
+    account_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('status_changed', 'rating_changed', 'notes_edited')),
+    from_value TEXT,
+    to_value TEXT,
+    inserted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS library_entry_events_entry_id_idx ON library_entry_events (entry_id, inserted_at);
+
+CREATE OR REPLACE FUNCTION library_entry_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'library_entry_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER library_entry_events_no_update
+    BEFORE UPDATE ON library_entry_events
+    FOR EACH ROW EXECUTE FUNCTION library_entry_events_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS library_entry_events;
+DROP FUNCTION IF EXISTS library_entry_events_append_only();
+
+ALTER TABLE library_entries
+    DROP COLUMN IF EXISTS rating,
+    DROP COLUMN IF EXISTS notes;
+-- +goose StatementEnd
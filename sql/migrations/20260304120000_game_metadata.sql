@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE games
+    ADD COLUMN release_date DATE,
+    ADD COLUMN summary TEXT NOT NULL DEFAULT '',
+    ADD COLUMN developer TEXT NOT NULL DEFAULT '',
+    ADD COLUMN publisher TEXT NOT NULL DEFAULT '',
+    ADD COLUMN cover_url TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS game_platforms (
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    PRIMARY KEY (game_id, platform)
+);
+
+CREATE INDEX IF NOT EXISTS game_platforms_platform_idx ON game_platforms (platform);
+
+CREATE TABLE IF NOT EXISTS game_genres (
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    genre TEXT NOT NULL,
+    PRIMARY KEY (game_id, genre)
+);
+
+CREATE INDEX IF NOT EXISTS game_genres_genre_idx ON game_genres (genre);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS game_genres;
+DROP TABLE IF EXISTS game_platforms;
+
+ALTER TABLE games
+    DROP COLUMN IF EXISTS release_date,
+    DROP COLUMN IF EXISTS summary,
+    DROP COLUMN IF EXISTS developer,
+    DROP COLUMN IF EXISTS publisher,
+    DROP COLUMN IF EXISTS cover_url;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS games_search_idx
+    ON games USING GIN (to_tsvector('english', title || ' ' || summary));
+
+CREATE INDEX IF NOT EXISTS games_title_trgm_idx
+    ON games USING GIN (lower(title) gin_trgm_ops);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS games_title_trgm_idx;
+DROP INDEX IF EXISTS games_search_idx;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE games
+    ADD COLUMN normalized_title TEXT NOT NULL DEFAULT '';
+
+-- Mirrors domain.NormalizeTitle for rows written before the column existed.
+UPDATE games
+SET normalized_title = btrim(regexp_replace(lower(title), '[^[:alnum:]]+', ' ', 'g'));
+
+CREATE INDEX IF NOT EXISTS games_normalized_title_idx ON games (normalized_title);
+
+CREATE TABLE IF NOT EXISTS external_ids (
+    provider TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    inserted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS external_ids_game_id_idx ON external_ids (game_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS external_ids;
+
+DROP INDEX IF EXISTS games_normalized_title_idx;
+
+ALTER TABLE games
+    DROP COLUMN IF EXISTS normalized_title;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Games already in the catalog have no known creator, so only admins may
+-- edit or delete them.
+ALTER TABLE games
+    ADD COLUMN created_by UUID REFERENCES accounts(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS games_created_by_idx ON games (created_by);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS games_created_by_idx;
+
+ALTER TABLE games
+    DROP COLUMN IF EXISTS created_by;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE accounts
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('member', 'admin')),
+    ADD COLUMN disabled_at TIMESTAMPTZ;
+
+-- Existing deployments get their first account as admin, as new ones do.
+UPDATE accounts
+SET role = 'admin'
+WHERE id = (SELECT id FROM accounts ORDER BY inserted_at, id LIMIT 1);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS disabled_at,
+    DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Merging games moves the history of a duplicate entry onto the entry that
+-- survives, so events may change entry but never what they recorded.
+CREATE OR REPLACE FUNCTION library_entry_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND (NEW.id, NEW.account_id, NEW.kind, NEW.from_value, NEW.to_value, NEW.inserted_at)
+            IS NOT DISTINCT FROM (OLD.id, OLD.account_id, OLD.kind, OLD.from_value, OLD.to_value, OLD.inserted_at)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'library_entry_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Orders statuses by how far along a game is, for picking the status that
+-- survives a merge.
+CREATE OR REPLACE FUNCTION library_status_rank(status TEXT) RETURNS INTEGER AS $$
+    SELECT array_position(ARRAY['wishlist', 'backlog', 'dropped', 'on_hold', 'playing', 'completed'], status);
+$$ LANGUAGE sql IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS library_status_rank(TEXT);
+
+CREATE OR REPLACE FUNCTION library_entry_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'library_entry_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
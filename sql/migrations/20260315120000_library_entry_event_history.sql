@@ -0,0 +1,74 @@
+-- +goose Up
+-- +goose StatementBegin
+-- History outlives its entry: deleting an entry only detaches its events, and
+-- game_id keeps saying which game they were about.
+ALTER TABLE library_entry_events
+    ADD COLUMN game_id UUID REFERENCES games(id) ON DELETE SET NULL;
+
+UPDATE library_entry_events
+SET game_id = library_entries.game_id
+FROM library_entries
+WHERE library_entries.id = library_entry_events.entry_id;
+
+ALTER TABLE library_entry_events
+    ALTER COLUMN entry_id DROP NOT NULL,
+    DROP CONSTRAINT library_entry_events_entry_id_fkey,
+    ADD CONSTRAINT library_entry_events_entry_id_fkey
+        FOREIGN KEY (entry_id) REFERENCES library_entries(id) ON DELETE SET NULL;
+
+-- Events may be detached from an entry or game, or moved onto another entry
+-- when games are merged, but what they recorded never changes. They are only
+-- deleted along with their account.
+CREATE OR REPLACE FUNCTION library_entry_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND (NEW.id, NEW.account_id, NEW.kind, NEW.from_value, NEW.to_value, NEW.inserted_at)
+            IS NOT DISTINCT FROM (OLD.id, OLD.account_id, OLD.kind, OLD.from_value, OLD.to_value, OLD.inserted_at)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'DELETE' AND NOT EXISTS (SELECT 1 FROM accounts WHERE accounts.id = OLD.account_id) THEN
+        RETURN OLD;
+    END IF;
+
+    RAISE EXCEPTION 'library_entry_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER library_entry_events_no_delete
+    BEFORE DELETE ON library_entry_events
+    FOR EACH ROW EXECUTE FUNCTION library_entry_events_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS library_entry_events_no_delete ON library_entry_events;
+
+CREATE OR REPLACE FUNCTION library_entry_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND (NEW.id, NEW.account_id, NEW.kind, NEW.from_value, NEW.to_value, NEW.inserted_at)
+            IS NOT DISTINCT FROM (OLD.id, OLD.account_id, OLD.kind, OLD.from_value, OLD.to_value, OLD.inserted_at)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'library_entry_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Detached events can't point at an entry again, so they go.
+ALTER TABLE library_entry_events DISABLE TRIGGER library_entry_events_no_update;
+DELETE FROM library_entry_events WHERE entry_id IS NULL;
+ALTER TABLE library_entry_events ENABLE TRIGGER library_entry_events_no_update;
+
+ALTER TABLE library_entry_events
+    DROP CONSTRAINT library_entry_events_entry_id_fkey,
+    ADD CONSTRAINT library_entry_events_entry_id_fkey
+        FOREIGN KEY (entry_id) REFERENCES library_entries(id) ON DELETE CASCADE,
+    ALTER COLUMN entry_id SET NOT NULL;
+
+ALTER TABLE library_entry_events
+    DROP COLUMN IF EXISTS game_id;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One index per ListGames order. Descending title and insertion time scan
+-- the ascending indexes backwards; release dates sort NULLS LAST both ways,
+-- so the descending order needs its own.
+CREATE INDEX IF NOT EXISTS games_title_order_idx ON games ((lower(title) COLLATE "C"), id);
+CREATE INDEX IF NOT EXISTS games_inserted_at_order_idx ON games (inserted_at, id);
+CREATE INDEX IF NOT EXISTS games_release_date_order_idx ON games (release_date, id);
+CREATE INDEX IF NOT EXISTS games_release_date_desc_order_idx ON games (release_date DESC NULLS LAST, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS games_release_date_desc_order_idx;
+DROP INDEX IF EXISTS games_release_date_order_idx;
+DROP INDEX IF EXISTS games_inserted_at_order_idx;
+DROP INDEX IF EXISTS games_title_order_idx;
+-- +goose StatementEnd
@@ -2,22 +2,35 @@ package migrations
 
 import (
 	"embed"
+	"fmt"
 	"log"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
 	"github.com/pressly/goose/v3/database"
+	"github.com/pressly/goose/v3/lock"
 )
 
 //go:embed *.sql
 var Embed embed.FS
 
 func MustProvide(pool *pgxpool.Pool) *goose.Provider {
-	db := stdlib.OpenDBFromPool(pool)
-	provider, err := goose.NewProvider(database.DialectPostgres, db, Embed)
+	provider, err := Provide(pool)
 	if err != nil {
 		log.Fatalf("migrations provider failed %v", err)
 	}
 	return provider
 }
+
+// Provide serializes migrations on a Postgres advisory lock, so replicas
+// booting together apply each migration once.
+func Provide(pool *pgxpool.Pool) (*goose.Provider, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("migrations locker: %w", err)
+	}
+
+	db := stdlib.OpenDBFromPool(pool)
+	return goose.NewProvider(database.DialectPostgres, db, Embed, goose.WithSessionLocker(locker))
+}
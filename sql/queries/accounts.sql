@@ -1,8 +1,38 @@
 -- name: CreateAccount :one
-INSERT INTO accounts (nickname, email, hashed_password)
-VALUES ($1, $2, $3)
+INSERT INTO accounts (nickname, email, hashed_password, role)
+VALUES ($1, $2, $3, $4)
 RETURNING *;
 
 -- name: GetAccountByEmail :one
 SELECT * FROM accounts
 WHERE email = $1;
+
+-- name: GetAccountByID :one
+SELECT * FROM accounts
+WHERE id = $1;
+
+-- name: ListAccounts :many
+SELECT * FROM accounts
+ORDER BY inserted_at, id;
+
+-- name: CountAccounts :one
+SELECT count(*) FROM accounts;
+
+-- name: LockAccountRegistration :exec
+-- Held until the transaction ends, so registrations checking whether they are
+-- the first account run one at a time.
+SELECT pg_advisory_xact_lock(hashtext('account_registration'));
+
+-- name: UpdateAccountRole :one
+UPDATE accounts
+SET role = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: DisableAccount :one
+UPDATE accounts
+SET disabled_at = COALESCE(disabled_at, now()),
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,28 @@
+-- name: AddExternalID :exec
+INSERT INTO external_ids (provider, external_id, game_id)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: ClaimExternalID :one
+-- Maps the external ID to the game unless another game already holds it, in
+-- which case no row comes back.
+INSERT INTO external_ids (provider, external_id, game_id)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+RETURNING game_id;
+
+-- name: GetGameByExternalID :one
+SELECT games.* FROM games
+JOIN external_ids ON external_ids.game_id = games.id
+WHERE external_ids.provider = $1
+  AND external_ids.external_id = $2;
+
+-- name: ListExternalIDs :many
+SELECT * FROM external_ids
+WHERE game_id = $1
+ORDER BY provider, external_id;
+
+-- name: RepointExternalIDs :exec
+UPDATE external_ids
+SET game_id = @target_id
+WHERE game_id = @source_id;
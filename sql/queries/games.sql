@@ -3,12 +3,203 @@ SELECT * FROM games
 WHERE id = $1;
 
 -- name: CreateGame :one
-INSERT INTO games (title) VALUES ($1)
+INSERT INTO games (title, normalized_title, release_date, summary, developer, publisher, cover_url, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
--- name: ListGames :many
-SELECT * FROM games;
+-- name: ListGamesByTitle :many
+-- Keyset pagination for each sort order walks an index on (sort column, id).
+-- Titles compare bytewise so every backend agrees on the order, and the
+-- lowercased title comes back as the cursor key so it matches what is compared.
+SELECT games.*, lower(title) COLLATE "C" AS sort_key
+FROM games
+WHERE (sqlc.narg('platform')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = sqlc.narg('platform')
+    ))
+  AND (sqlc.narg('genre')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = sqlc.narg('genre')
+    ))
+  AND (sqlc.narg('title_prefix')::text IS NULL
+    OR starts_with(lower(title), lower(sqlc.narg('title_prefix'))))
+  AND (sqlc.narg('after_id')::uuid IS NULL
+    OR (lower(title) COLLATE "C", id) > (@after_title::text COLLATE "C", sqlc.narg('after_id')))
+ORDER BY lower(title) COLLATE "C", id
+LIMIT @page_limit::int;
+
+-- name: ListGamesByTitleDesc :many
+SELECT games.*, lower(title) COLLATE "C" AS sort_key
+FROM games
+WHERE (sqlc.narg('platform')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = sqlc.narg('platform')
+    ))
+  AND (sqlc.narg('genre')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = sqlc.narg('genre')
+    ))
+  AND (sqlc.narg('title_prefix')::text IS NULL
+    OR starts_with(lower(title), lower(sqlc.narg('title_prefix'))))
+  AND (sqlc.narg('after_id')::uuid IS NULL
+    OR (lower(title) COLLATE "C", id) < (@after_title::text COLLATE "C", sqlc.narg('after_id')))
+ORDER BY lower(title) COLLATE "C" DESC, id DESC
+LIMIT @page_limit::int;
+
+-- name: ListGamesByInsertedAt :many
+SELECT * FROM games
+WHERE (sqlc.narg('platform')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = sqlc.narg('platform')
+    ))
+  AND (sqlc.narg('genre')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = sqlc.narg('genre')
+    ))
+  AND (sqlc.narg('title_prefix')::text IS NULL
+    OR starts_with(lower(title), lower(sqlc.narg('title_prefix'))))
+  AND (sqlc.narg('after_id')::uuid IS NULL
+    OR (inserted_at, id) > (@after_inserted_at::timestamptz, sqlc.narg('after_id')))
+ORDER BY inserted_at, id
+LIMIT @page_limit::int;
+
+-- name: ListGamesByInsertedAtDesc :many
+SELECT * FROM games
+WHERE (sqlc.narg('platform')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = sqlc.narg('platform')
+    ))
+  AND (sqlc.narg('genre')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = sqlc.narg('genre')
+    ))
+  AND (sqlc.narg('title_prefix')::text IS NULL
+    OR starts_with(lower(title), lower(sqlc.narg('title_prefix'))))
+  AND (sqlc.narg('after_id')::uuid IS NULL
+    OR (inserted_at, id) < (@after_inserted_at::timestamptz, sqlc.narg('after_id')))
+ORDER BY inserted_at DESC, id DESC
+LIMIT @page_limit::int;
+
+-- name: ListGamesByReleaseDate :many
+-- Missing release dates sort last, and a cursor on one only has those left.
+SELECT * FROM games
+WHERE (sqlc.narg('platform')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = sqlc.narg('platform')
+    ))
+  AND (sqlc.narg('genre')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = sqlc.narg('genre')
+    ))
+  AND (sqlc.narg('title_prefix')::text IS NULL
+    OR starts_with(lower(title), lower(sqlc.narg('title_prefix'))))
+  AND (sqlc.narg('after_id')::uuid IS NULL
+    OR (sqlc.narg('after_release_date')::date IS NULL AND release_date IS NULL AND id > sqlc.narg('after_id'))
+    OR (release_date, id) > (sqlc.narg('after_release_date'), sqlc.narg('after_id'))
+    OR (sqlc.narg('after_release_date') IS NOT NULL AND release_date IS NULL))
+ORDER BY release_date NULLS LAST, id
+LIMIT @page_limit::int;
+
+-- name: ListGamesByReleaseDateDesc :many
+-- Missing release dates sort last here too.
+SELECT * FROM games
+WHERE (sqlc.narg('platform')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = sqlc.narg('platform')
+    ))
+  AND (sqlc.narg('genre')::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = sqlc.narg('genre')
+    ))
+  AND (sqlc.narg('title_prefix')::text IS NULL
+    OR starts_with(lower(title), lower(sqlc.narg('title_prefix'))))
+  AND (sqlc.narg('after_id')::uuid IS NULL
+    OR (sqlc.narg('after_release_date')::date IS NULL AND release_date IS NULL AND id < sqlc.narg('after_id'))
+    OR (release_date, id) < (sqlc.narg('after_release_date'), sqlc.narg('after_id'))
+    OR (sqlc.narg('after_release_date') IS NOT NULL AND release_date IS NULL))
+ORDER BY release_date DESC NULLS LAST, id DESC
+LIMIT @page_limit::int;
+
+-- name: UpdateGame :one
+UPDATE games
+SET title = $2,
+    normalized_title = $3,
+    release_date = $4,
+    summary = $5,
+    developer = $6,
+    publisher = $7,
+    cover_url = $8,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: SearchGames :many
+-- Ranks full-text matches and typo-tolerant title matches together. Snippets
+-- wrap matches in U+E000/U+E001 so callers can escape them before marking up.
+WITH search AS (
+    SELECT websearch_to_tsquery('english', @query::text) AS tsquery,
+        lower(@query) AS term
+)
+SELECT games.id, games.title, games.release_date, games.summary, games.developer,
+    games.publisher, games.cover_url, games.inserted_at, games.updated_at, games.created_by,
+    (ts_rank(to_tsvector('english', games.title || ' ' || games.summary), search.tsquery)
+        + word_similarity(search.term, lower(games.title)))::real AS score,
+    ts_headline('english', games.title, search.tsquery,
+        'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345)) AS title_snippet,
+    ts_headline('english', games.summary, search.tsquery,
+        'MaxFragments=2, MaxWords=20, MinWords=5, StartSel=' || chr(57344) || ', StopSel=' || chr(57345)) AS summary_snippet
+FROM games, search
+WHERE to_tsvector('english', games.title || ' ' || games.summary) @@ search.tsquery
+   OR search.term <% lower(games.title)
+ORDER BY score DESC, games.title
+LIMIT @result_limit::int;
 
 -- name: DeleteGameByID :exec
 DELETE FROM games
 WHERE id = $1;
+
+-- name: AddGamePlatform :exec
+INSERT INTO game_platforms (game_id, platform)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteGamePlatforms :exec
+DELETE FROM game_platforms
+WHERE game_id = $1;
+
+-- name: ListGamePlatforms :many
+SELECT * FROM game_platforms
+WHERE game_id = ANY(@game_ids::uuid[])
+ORDER BY platform;
+
+-- name: AddGameGenre :exec
+INSERT INTO game_genres (game_id, genre)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteGameGenres :exec
+DELETE FROM game_genres
+WHERE game_id = $1;
+
+-- name: ListGameGenres :many
+SELECT * FROM game_genres
+WHERE game_id = ANY(@game_ids::uuid[])
+ORDER BY genre;
+
+-- name: GetGameByNormalizedTitle :one
+SELECT * FROM games
+WHERE normalized_title = $1
+ORDER BY inserted_at, id
+LIMIT 1;
@@ -0,0 +1,53 @@
+-- name: CreateImportJob :one
+INSERT INTO import_jobs (account_id, source, external_account_id, status)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetImportJob :one
+SELECT * FROM import_jobs
+WHERE id = $1
+  AND account_id = $2;
+
+-- name: ListImportJobs :many
+SELECT * FROM import_jobs
+WHERE account_id = $1
+ORDER BY inserted_at DESC;
+
+-- name: UpdateImportJob :one
+UPDATE import_jobs
+SET status = $2,
+    total_items = $3,
+    processed_items = $4,
+    failed_items = $5,
+    error = $6,
+    finished_at = $7,
+    updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateImportJobItem :one
+INSERT INTO import_job_items (job_id, external_id, title)
+VALUES ($1, $2, $3)
+ON CONFLICT (job_id, external_id) DO UPDATE
+SET title = EXCLUDED.title
+RETURNING *;
+
+-- name: UpdateImportJobItem :exec
+UPDATE import_job_items
+SET status = $2,
+    game_id = $3,
+    error = $4,
+    updated_at = now()
+WHERE id = $1;
+
+-- name: ListImportJobItems :many
+SELECT import_job_items.* FROM import_job_items
+JOIN import_jobs ON import_jobs.id = import_job_items.job_id
+WHERE import_job_items.job_id = $1
+  AND import_jobs.account_id = $2
+ORDER BY import_job_items.id;
+
+-- name: RepointImportJobItems :exec
+UPDATE import_job_items
+SET game_id = @target_id
+WHERE game_id = @source_id;
@@ -0,0 +1,69 @@
+-- name: EnqueueJob :one
+INSERT INTO jobs (kind, payload, max_attempts, run_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetJob :one
+SELECT * FROM jobs
+WHERE id = $1;
+
+-- name: ClaimJob :one
+UPDATE jobs
+SET status = 'running',
+    attempts = attempts + 1,
+    locked_at = now(),
+    updated_at = now()
+WHERE id = (
+    SELECT id FROM jobs
+    WHERE (jobs.status = 'queued' AND jobs.run_at <= now())
+       OR (jobs.status = 'running' AND jobs.locked_at < @stale_before)
+    ORDER BY jobs.run_at, jobs.id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CountJobsByStatus :many
+SELECT status, count(*) FROM jobs
+GROUP BY status;
+
+-- name: ExtendJobLock :execrows
+-- Keeps a long job's lock fresh so it isn't claimed again while it runs.
+-- Like the updates that finish a job, it only matches while the claim that
+-- made the attempt still holds the job.
+UPDATE jobs
+SET locked_at = now()
+WHERE id = $1
+  AND status = 'running'
+  AND attempts = $2;
+
+-- name: CompleteJob :execrows
+UPDATE jobs
+SET status = 'succeeded',
+    locked_at = NULL,
+    last_error = '',
+    updated_at = now()
+WHERE id = $1
+  AND status = 'running'
+  AND attempts = $2;
+
+-- name: RetryJob :execrows
+UPDATE jobs
+SET status = 'queued',
+    run_at = $2,
+    locked_at = NULL,
+    last_error = $3,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'running'
+  AND attempts = $4;
+
+-- name: KillJob :execrows
+UPDATE jobs
+SET status = 'dead',
+    locked_at = NULL,
+    last_error = $2,
+    updated_at = now()
+WHERE id = $1
+  AND status = 'running'
+  AND attempts = $3;
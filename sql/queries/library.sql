@@ -0,0 +1,122 @@
+-- name: CreateLibraryEntry :one
+INSERT INTO library_entries (account_id, game_id, status, started_at, finished_at, rating, notes)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetLibraryEntry :one
+SELECT library_entries.*, games.title AS game_title
+FROM library_entries
+JOIN games ON games.id = library_entries.game_id
+WHERE library_entries.id = $1
+  AND library_entries.account_id = $2;
+
+-- name: ListLibraryEntries :many
+SELECT library_entries.*, games.title AS game_title
+FROM library_entries
+JOIN games ON games.id = library_entries.game_id
+WHERE library_entries.account_id = $1
+ORDER BY library_entries.updated_at DESC;
+
+-- name: UpdateLibraryEntry :one
+UPDATE library_entries
+SET status = $3,
+    started_at = $4,
+    finished_at = $5,
+    rating = $6,
+    notes = $7,
+    updated_at = now()
+WHERE id = $1
+  AND account_id = $2
+RETURNING *;
+
+-- name: DeleteLibraryEntry :execrows
+DELETE FROM library_entries
+WHERE id = $1
+  AND account_id = $2;
+
+-- name: CreateLibraryEntryEvent :exec
+-- Records the entry's game too, so the event still says what it was about
+-- once the entry is deleted.
+INSERT INTO library_entry_events (entry_id, game_id, account_id, kind, from_value, to_value)
+VALUES (
+    @entry_id::uuid,
+    (SELECT game_id FROM library_entries WHERE id = @entry_id::uuid),
+    @account_id, @kind, @from_value, @to_value
+);
+
+-- name: ListLibraryEntryEvents :many
+SELECT * FROM library_entry_events
+WHERE entry_id = @entry_id::uuid
+  AND account_id = @account_id
+ORDER BY inserted_at, id;
+
+-- name: ImportLibraryEntry :one
+INSERT INTO library_entries (account_id, game_id, status, started_at, playtime_minutes, last_played_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (account_id, game_id) DO UPDATE
+SET playtime_minutes = EXCLUDED.playtime_minutes,
+    last_played_at = COALESCE(EXCLUDED.last_played_at, library_entries.last_played_at),
+    updated_at = now()
+RETURNING *, (xmax = 0)::boolean AS inserted;
+
+-- name: MergeCollidingLibraryEntries :exec
+-- Folds the source game's entries into the target's for accounts that track
+-- both, since an account can only have one entry per game. The more advanced
+-- status wins along with its finish date, the earliest start is kept, ratings
+-- and notes are kept and playtime adds up.
+UPDATE library_entries AS target
+SET status = CASE
+        WHEN library_status_rank(source.status) > library_status_rank(target.status) THEN source.status
+        ELSE target.status
+    END,
+    started_at = LEAST(target.started_at, source.started_at),
+    finished_at = CASE
+        WHEN library_status_rank(source.status) > library_status_rank(target.status) THEN source.finished_at
+        ELSE target.finished_at
+    END,
+    rating = COALESCE(target.rating, source.rating),
+    notes = CASE
+        WHEN source.notes = '' OR source.notes = target.notes THEN target.notes
+        WHEN target.notes = '' THEN source.notes
+        ELSE target.notes || E'\n\n' || source.notes
+    END,
+    playtime_minutes = target.playtime_minutes + source.playtime_minutes,
+    last_played_at = GREATEST(target.last_played_at, source.last_played_at),
+    updated_at = now()
+FROM library_entries AS source
+WHERE source.game_id = @source_id::uuid
+  AND target.game_id = @target_id::uuid
+  AND target.account_id = source.account_id;
+
+-- name: RepointCollidingLibraryEntryEvents :exec
+-- Moves the history of the source game's colliding entries onto the entries
+-- they are merged into.
+UPDATE library_entry_events
+SET entry_id = target.id
+FROM library_entries AS source
+JOIN library_entries AS target
+  ON target.account_id = source.account_id
+ AND target.game_id = @target_id::uuid
+WHERE source.game_id = @source_id::uuid
+  AND library_entry_events.entry_id = source.id;
+
+-- name: DeleteCollidingLibraryEntries :exec
+-- Drops the source game's entries for accounts that already track the target,
+-- once they have been merged into the target's entries.
+DELETE FROM library_entries
+WHERE library_entries.game_id = @source_id::uuid
+  AND library_entries.account_id IN (
+    SELECT target.account_id FROM library_entries AS target
+    WHERE target.game_id = @target_id::uuid
+  );
+
+-- name: RepointLibraryEntryEvents :exec
+UPDATE library_entry_events
+SET game_id = @target_id::uuid
+WHERE game_id = @source_id::uuid;
+
+-- name: RepointLibraryEntries :exec
+UPDATE library_entries
+SET game_id = @target_id,
+    updated_at = now()
+WHERE game_id = @source_id;
@@ -0,0 +1,12 @@
+-- name: GetMetadataCacheEntry :one
+SELECT * FROM metadata_cache
+WHERE provider = $1
+  AND external_id = $2;
+
+-- name: UpsertMetadataCacheEntry :exec
+INSERT INTO metadata_cache (provider, external_id, payload, etag, fetched_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (provider, external_id) DO UPDATE
+SET payload = EXCLUDED.payload,
+    etag = EXCLUDED.etag,
+    fetched_at = EXCLUDED.fetched_at;
@@ -2,11 +2,22 @@
 INSERT INTO sessions (token, account_id, expires_at)
 VALUES ($1, $2, $3);
 
--- name: GetSessionAccountID :one
-SELECT account_id FROM sessions
-WHERE token = $1
-  AND expires_at > now();
+-- name: CountActiveSessions :one
+SELECT count(*) FROM sessions
+WHERE expires_at > now();
+
+-- name: GetSessionAccount :one
+-- Sessions of disabled accounts are treated as missing.
+SELECT accounts.* FROM sessions
+JOIN accounts ON accounts.id = sessions.account_id
+WHERE sessions.token = $1
+  AND sessions.expires_at > now()
+  AND accounts.disabled_at IS NULL;
 
 -- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE token = $1;
+
+-- name: DeleteAccountSessions :exec
+DELETE FROM sessions
+WHERE account_id = $1;
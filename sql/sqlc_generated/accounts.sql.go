@@ -7,22 +7,41 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/google/uuid"
 )
 
+const countAccounts = `-- name: CountAccounts :one
+SELECT count(*) FROM accounts
+`
+
+func (q *Queries) CountAccounts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countAccounts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
-INSERT INTO accounts (nickname, email, hashed_password)
-VALUES ($1, $2, $3)
-RETURNING id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at
+INSERT INTO accounts (nickname, email, hashed_password, role)
+VALUES ($1, $2, $3, $4)
+RETURNING id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at, role, disabled_at
 `
 
 type CreateAccountParams struct {
 	Nickname       string
 	Email          string
 	HashedPassword string
+	Role           string
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
-	row := q.db.QueryRow(ctx, createAccount, arg.Nickname, arg.Email, arg.HashedPassword)
+	row := q.db.QueryRow(ctx, createAccount,
+		arg.Nickname,
+		arg.Email,
+		arg.HashedPassword,
+		arg.Role,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -32,12 +51,39 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.InsertedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const disableAccount = `-- name: DisableAccount :one
+UPDATE accounts
+SET disabled_at = COALESCE(disabled_at, now()),
+    updated_at = now()
+WHERE id = $1
+RETURNING id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at, role, disabled_at
+`
+
+func (q *Queries) DisableAccount(ctx context.Context, id uuid.UUID) (Account, error) {
+	row := q.db.QueryRow(ctx, disableAccount, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Nickname,
+		&i.Email,
+		&i.HashedPassword,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
 
 const getAccountByEmail = `-- name: GetAccountByEmail :one
-SELECT id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at FROM accounts
+SELECT id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at, role, disabled_at FROM accounts
 WHERE email = $1
 `
 
@@ -52,6 +98,106 @@ func (q *Queries) GetAccountByEmail(ctx context.Context, email string) (Account,
 		&i.InsertedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const getAccountByID = `-- name: GetAccountByID :one
+SELECT id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at, role, disabled_at FROM accounts
+WHERE id = $1
+`
+
+func (q *Queries) GetAccountByID(ctx context.Context, id uuid.UUID) (Account, error) {
+	row := q.db.QueryRow(ctx, getAccountByID, id)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Nickname,
+		&i.Email,
+		&i.HashedPassword,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const listAccounts = `-- name: ListAccounts :many
+SELECT id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at, role, disabled_at FROM accounts
+ORDER BY inserted_at, id
+`
+
+func (q *Queries) ListAccounts(ctx context.Context) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Nickname,
+			&i.Email,
+			&i.HashedPassword,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Role,
+			&i.DisabledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAccountRegistration = `-- name: LockAccountRegistration :exec
+SELECT pg_advisory_xact_lock(hashtext('account_registration'))
+`
+
+// Held until the transaction ends, so registrations checking whether they are
+// the first account run one at a time.
+func (q *Queries) LockAccountRegistration(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, lockAccountRegistration)
+	return err
+}
+
+const updateAccountRole = `-- name: UpdateAccountRole :one
+UPDATE accounts
+SET role = $2,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, nickname, email, hashed_password, inserted_at, updated_at, deleted_at, role, disabled_at
+`
+
+type UpdateAccountRoleParams struct {
+	ID   uuid.UUID
+	Role string
+}
+
+func (q *Queries) UpdateAccountRole(ctx context.Context, arg UpdateAccountRoleParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountRole, arg.ID, arg.Role)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Nickname,
+		&i.Email,
+		&i.HashedPassword,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.DisabledAt,
 	)
 	return i, err
 }
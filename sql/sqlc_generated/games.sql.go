@@ -9,17 +9,83 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addGameGenre = `-- name: AddGameGenre :exec
+INSERT INTO game_genres (game_id, genre)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddGameGenreParams struct {
+	GameID uuid.UUID
+	Genre  string
+}
+
+func (q *Queries) AddGameGenre(ctx context.Context, arg AddGameGenreParams) error {
+	_, err := q.db.Exec(ctx, addGameGenre, arg.GameID, arg.Genre)
+	return err
+}
+
+const addGamePlatform = `-- name: AddGamePlatform :exec
+INSERT INTO game_platforms (game_id, platform)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddGamePlatformParams struct {
+	GameID   uuid.UUID
+	Platform string
+}
+
+func (q *Queries) AddGamePlatform(ctx context.Context, arg AddGamePlatformParams) error {
+	_, err := q.db.Exec(ctx, addGamePlatform, arg.GameID, arg.Platform)
+	return err
+}
+
 const createGame = `-- name: CreateGame :one
-INSERT INTO games (title) VALUES ($1)
-RETURNING id, title
+INSERT INTO games (title, normalized_title, release_date, summary, developer, publisher, cover_url, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by
 `
 
-func (q *Queries) CreateGame(ctx context.Context, title string) (Game, error) {
-	row := q.db.QueryRow(ctx, createGame, title)
+type CreateGameParams struct {
+	Title           string
+	NormalizedTitle string
+	ReleaseDate     pgtype.Date
+	Summary         string
+	Developer       string
+	Publisher       string
+	CoverUrl        string
+	CreatedBy       pgtype.UUID
+}
+
+func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (Game, error) {
+	row := q.db.QueryRow(ctx, createGame,
+		arg.Title,
+		arg.NormalizedTitle,
+		arg.ReleaseDate,
+		arg.Summary,
+		arg.Developer,
+		arg.Publisher,
+		arg.CoverUrl,
+		arg.CreatedBy,
+	)
 	var i Game
-	err := row.Scan(&i.ID, &i.Title)
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.ReleaseDate,
+		&i.Summary,
+		&i.Developer,
+		&i.Publisher,
+		&i.CoverUrl,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.NormalizedTitle,
+		&i.CreatedBy,
+	)
 	return i, err
 }
 
@@ -33,24 +99,305 @@ func (q *Queries) DeleteGameByID(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteGameGenres = `-- name: DeleteGameGenres :exec
+DELETE FROM game_genres
+WHERE game_id = $1
+`
+
+func (q *Queries) DeleteGameGenres(ctx context.Context, gameID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteGameGenres, gameID)
+	return err
+}
+
+const deleteGamePlatforms = `-- name: DeleteGamePlatforms :exec
+DELETE FROM game_platforms
+WHERE game_id = $1
+`
+
+func (q *Queries) DeleteGamePlatforms(ctx context.Context, gameID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteGamePlatforms, gameID)
+	return err
+}
+
 const getGame = `-- name: GetGame :one
-SELECT id, title FROM games
+SELECT id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by FROM games
 WHERE id = $1
 `
 
 func (q *Queries) GetGame(ctx context.Context, id uuid.UUID) (Game, error) {
 	row := q.db.QueryRow(ctx, getGame, id)
 	var i Game
-	err := row.Scan(&i.ID, &i.Title)
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.ReleaseDate,
+		&i.Summary,
+		&i.Developer,
+		&i.Publisher,
+		&i.CoverUrl,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.NormalizedTitle,
+		&i.CreatedBy,
+	)
 	return i, err
 }
 
-const listGames = `-- name: ListGames :many
-SELECT id, title FROM games
+const getGameByNormalizedTitle = `-- name: GetGameByNormalizedTitle :one
+SELECT id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by FROM games
+WHERE normalized_title = $1
+ORDER BY inserted_at, id
+LIMIT 1
+`
+
+func (q *Queries) GetGameByNormalizedTitle(ctx context.Context, normalizedTitle string) (Game, error) {
+	row := q.db.QueryRow(ctx, getGameByNormalizedTitle, normalizedTitle)
+	var i Game
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.ReleaseDate,
+		&i.Summary,
+		&i.Developer,
+		&i.Publisher,
+		&i.CoverUrl,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.NormalizedTitle,
+		&i.CreatedBy,
+	)
+	return i, err
+}
+
+const listGameGenres = `-- name: ListGameGenres :many
+SELECT game_id, genre FROM game_genres
+WHERE game_id = ANY($1::uuid[])
+ORDER BY genre
+`
+
+func (q *Queries) ListGameGenres(ctx context.Context, gameIds []uuid.UUID) ([]GameGenre, error) {
+	rows, err := q.db.Query(ctx, listGameGenres, gameIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GameGenre{}
+	for rows.Next() {
+		var i GameGenre
+		if err := rows.Scan(&i.GameID, &i.Genre); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamePlatforms = `-- name: ListGamePlatforms :many
+SELECT game_id, platform FROM game_platforms
+WHERE game_id = ANY($1::uuid[])
+ORDER BY platform
+`
+
+func (q *Queries) ListGamePlatforms(ctx context.Context, gameIds []uuid.UUID) ([]GamePlatform, error) {
+	rows, err := q.db.Query(ctx, listGamePlatforms, gameIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GamePlatform{}
+	for rows.Next() {
+		var i GamePlatform
+		if err := rows.Scan(&i.GameID, &i.Platform); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamesByInsertedAt = `-- name: ListGamesByInsertedAt :many
+SELECT id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by FROM games
+WHERE ($1::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = $1
+    ))
+  AND ($2::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = $2
+    ))
+  AND ($3::text IS NULL
+    OR starts_with(lower(title), lower($3)))
+  AND ($4::uuid IS NULL
+    OR (inserted_at, id) > ($5::timestamptz, $4))
+ORDER BY inserted_at, id
+LIMIT $6::int
+`
+
+type ListGamesByInsertedAtParams struct {
+	Platform        pgtype.Text
+	Genre           pgtype.Text
+	TitlePrefix     pgtype.Text
+	AfterID         pgtype.UUID
+	AfterInsertedAt pgtype.Timestamptz
+	PageLimit       int32
+}
+
+func (q *Queries) ListGamesByInsertedAt(ctx context.Context, arg ListGamesByInsertedAtParams) ([]Game, error) {
+	rows, err := q.db.Query(ctx, listGamesByInsertedAt,
+		arg.Platform,
+		arg.Genre,
+		arg.TitlePrefix,
+		arg.AfterID,
+		arg.AfterInsertedAt,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Game{}
+	for rows.Next() {
+		var i Game
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Summary,
+			&i.Developer,
+			&i.Publisher,
+			&i.CoverUrl,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.NormalizedTitle,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamesByInsertedAtDesc = `-- name: ListGamesByInsertedAtDesc :many
+SELECT id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by FROM games
+WHERE ($1::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = $1
+    ))
+  AND ($2::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = $2
+    ))
+  AND ($3::text IS NULL
+    OR starts_with(lower(title), lower($3)))
+  AND ($4::uuid IS NULL
+    OR (inserted_at, id) < ($5::timestamptz, $4))
+ORDER BY inserted_at DESC, id DESC
+LIMIT $6::int
+`
+
+type ListGamesByInsertedAtDescParams struct {
+	Platform        pgtype.Text
+	Genre           pgtype.Text
+	TitlePrefix     pgtype.Text
+	AfterID         pgtype.UUID
+	AfterInsertedAt pgtype.Timestamptz
+	PageLimit       int32
+}
+
+func (q *Queries) ListGamesByInsertedAtDesc(ctx context.Context, arg ListGamesByInsertedAtDescParams) ([]Game, error) {
+	rows, err := q.db.Query(ctx, listGamesByInsertedAtDesc,
+		arg.Platform,
+		arg.Genre,
+		arg.TitlePrefix,
+		arg.AfterID,
+		arg.AfterInsertedAt,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Game{}
+	for rows.Next() {
+		var i Game
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Summary,
+			&i.Developer,
+			&i.Publisher,
+			&i.CoverUrl,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.NormalizedTitle,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamesByReleaseDate = `-- name: ListGamesByReleaseDate :many
+SELECT id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by FROM games
+WHERE ($1::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = $1
+    ))
+  AND ($2::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = $2
+    ))
+  AND ($3::text IS NULL
+    OR starts_with(lower(title), lower($3)))
+  AND ($4::uuid IS NULL
+    OR ($5::date IS NULL AND release_date IS NULL AND id > $4)
+    OR (release_date, id) > ($5, $4)
+    OR ($5 IS NOT NULL AND release_date IS NULL))
+ORDER BY release_date NULLS LAST, id
+LIMIT $6::int
 `
 
-func (q *Queries) ListGames(ctx context.Context) ([]Game, error) {
-	rows, err := q.db.Query(ctx, listGames)
+type ListGamesByReleaseDateParams struct {
+	Platform         pgtype.Text
+	Genre            pgtype.Text
+	TitlePrefix      pgtype.Text
+	AfterID          pgtype.UUID
+	AfterReleaseDate pgtype.Date
+	PageLimit        int32
+}
+
+// Missing release dates sort last, and a cursor on one only has those left.
+func (q *Queries) ListGamesByReleaseDate(ctx context.Context, arg ListGamesByReleaseDateParams) ([]Game, error) {
+	rows, err := q.db.Query(ctx, listGamesByReleaseDate,
+		arg.Platform,
+		arg.Genre,
+		arg.TitlePrefix,
+		arg.AfterID,
+		arg.AfterReleaseDate,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +405,263 @@ func (q *Queries) ListGames(ctx context.Context) ([]Game, error) {
 	items := []Game{}
 	for rows.Next() {
 		var i Game
-		if err := rows.Scan(&i.ID, &i.Title); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Summary,
+			&i.Developer,
+			&i.Publisher,
+			&i.CoverUrl,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.NormalizedTitle,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamesByReleaseDateDesc = `-- name: ListGamesByReleaseDateDesc :many
+SELECT id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by FROM games
+WHERE ($1::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = $1
+    ))
+  AND ($2::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = $2
+    ))
+  AND ($3::text IS NULL
+    OR starts_with(lower(title), lower($3)))
+  AND ($4::uuid IS NULL
+    OR ($5::date IS NULL AND release_date IS NULL AND id < $4)
+    OR (release_date, id) < ($5, $4)
+    OR ($5 IS NOT NULL AND release_date IS NULL))
+ORDER BY release_date DESC NULLS LAST, id DESC
+LIMIT $6::int
+`
+
+type ListGamesByReleaseDateDescParams struct {
+	Platform         pgtype.Text
+	Genre            pgtype.Text
+	TitlePrefix      pgtype.Text
+	AfterID          pgtype.UUID
+	AfterReleaseDate pgtype.Date
+	PageLimit        int32
+}
+
+// Missing release dates sort last here too.
+func (q *Queries) ListGamesByReleaseDateDesc(ctx context.Context, arg ListGamesByReleaseDateDescParams) ([]Game, error) {
+	rows, err := q.db.Query(ctx, listGamesByReleaseDateDesc,
+		arg.Platform,
+		arg.Genre,
+		arg.TitlePrefix,
+		arg.AfterID,
+		arg.AfterReleaseDate,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Game{}
+	for rows.Next() {
+		var i Game
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Summary,
+			&i.Developer,
+			&i.Publisher,
+			&i.CoverUrl,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.NormalizedTitle,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamesByTitle = `-- name: ListGamesByTitle :many
+SELECT games.id, games.title, games.release_date, games.summary, games.developer, games.publisher, games.cover_url, games.inserted_at, games.updated_at, games.normalized_title, games.created_by, lower(title) COLLATE "C" AS sort_key
+FROM games
+WHERE ($1::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = $1
+    ))
+  AND ($2::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = $2
+    ))
+  AND ($3::text IS NULL
+    OR starts_with(lower(title), lower($3)))
+  AND ($4::uuid IS NULL
+    OR (lower(title) COLLATE "C", id) > ($5::text COLLATE "C", $4))
+ORDER BY lower(title) COLLATE "C", id
+LIMIT $6::int
+`
+
+type ListGamesByTitleParams struct {
+	Platform    pgtype.Text
+	Genre       pgtype.Text
+	TitlePrefix pgtype.Text
+	AfterID     pgtype.UUID
+	AfterTitle  string
+	PageLimit   int32
+}
+
+type ListGamesByTitleRow struct {
+	ID              uuid.UUID
+	Title           string
+	ReleaseDate     pgtype.Date
+	Summary         string
+	Developer       string
+	Publisher       string
+	CoverUrl        string
+	InsertedAt      pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	NormalizedTitle string
+	CreatedBy       pgtype.UUID
+	SortKey         string
+}
+
+// Keyset pagination for each sort order walks an index on (sort column, id).
+// Titles compare bytewise so every backend agrees on the order, and the
+// lowercased title comes back as the cursor key so it matches what is compared.
+func (q *Queries) ListGamesByTitle(ctx context.Context, arg ListGamesByTitleParams) ([]ListGamesByTitleRow, error) {
+	rows, err := q.db.Query(ctx, listGamesByTitle,
+		arg.Platform,
+		arg.Genre,
+		arg.TitlePrefix,
+		arg.AfterID,
+		arg.AfterTitle,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGamesByTitleRow{}
+	for rows.Next() {
+		var i ListGamesByTitleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Summary,
+			&i.Developer,
+			&i.Publisher,
+			&i.CoverUrl,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.NormalizedTitle,
+			&i.CreatedBy,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamesByTitleDesc = `-- name: ListGamesByTitleDesc :many
+SELECT games.id, games.title, games.release_date, games.summary, games.developer, games.publisher, games.cover_url, games.inserted_at, games.updated_at, games.normalized_title, games.created_by, lower(title) COLLATE "C" AS sort_key
+FROM games
+WHERE ($1::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_platforms
+        WHERE game_platforms.game_id = games.id
+          AND game_platforms.platform = $1
+    ))
+  AND ($2::text IS NULL OR EXISTS (
+        SELECT 1 FROM game_genres
+        WHERE game_genres.game_id = games.id
+          AND game_genres.genre = $2
+    ))
+  AND ($3::text IS NULL
+    OR starts_with(lower(title), lower($3)))
+  AND ($4::uuid IS NULL
+    OR (lower(title) COLLATE "C", id) < ($5::text COLLATE "C", $4))
+ORDER BY lower(title) COLLATE "C" DESC, id DESC
+LIMIT $6::int
+`
+
+type ListGamesByTitleDescParams struct {
+	Platform    pgtype.Text
+	Genre       pgtype.Text
+	TitlePrefix pgtype.Text
+	AfterID     pgtype.UUID
+	AfterTitle  string
+	PageLimit   int32
+}
+
+type ListGamesByTitleDescRow struct {
+	ID              uuid.UUID
+	Title           string
+	ReleaseDate     pgtype.Date
+	Summary         string
+	Developer       string
+	Publisher       string
+	CoverUrl        string
+	InsertedAt      pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	NormalizedTitle string
+	CreatedBy       pgtype.UUID
+	SortKey         string
+}
+
+func (q *Queries) ListGamesByTitleDesc(ctx context.Context, arg ListGamesByTitleDescParams) ([]ListGamesByTitleDescRow, error) {
+	rows, err := q.db.Query(ctx, listGamesByTitleDesc,
+		arg.Platform,
+		arg.Genre,
+		arg.TitlePrefix,
+		arg.AfterID,
+		arg.AfterTitle,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListGamesByTitleDescRow{}
+	for rows.Next() {
+		var i ListGamesByTitleDescRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Summary,
+			&i.Developer,
+			&i.Publisher,
+			&i.CoverUrl,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.NormalizedTitle,
+			&i.CreatedBy,
+			&i.SortKey,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -68,3 +671,133 @@ func (q *Queries) ListGames(ctx context.Context) ([]Game, error) {
 	}
 	return items, nil
 }
+
+const searchGames = `-- name: SearchGames :many
+WITH search AS (
+    SELECT websearch_to_tsquery('english', $1::text) AS tsquery,
+        lower($1) AS term
+)
+SELECT games.id, games.title, games.release_date, games.summary, games.developer,
+    games.publisher, games.cover_url, games.inserted_at, games.updated_at, games.created_by,
+    (ts_rank(to_tsvector('english', games.title || ' ' || games.summary), search.tsquery)
+        + word_similarity(search.term, lower(games.title)))::real AS score,
+    ts_headline('english', games.title, search.tsquery,
+        'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345)) AS title_snippet,
+    ts_headline('english', games.summary, search.tsquery,
+        'MaxFragments=2, MaxWords=20, MinWords=5, StartSel=' || chr(57344) || ', StopSel=' || chr(57345)) AS summary_snippet
+FROM games, search
+WHERE to_tsvector('english', games.title || ' ' || games.summary) @@ search.tsquery
+   OR search.term <% lower(games.title)
+ORDER BY score DESC, games.title
+LIMIT $2::int
+`
+
+type SearchGamesParams struct {
+	Query       string
+	ResultLimit int32
+}
+
+type SearchGamesRow struct {
+	ID             uuid.UUID
+	Title          string
+	ReleaseDate    pgtype.Date
+	Summary        string
+	Developer      string
+	Publisher      string
+	CoverUrl       string
+	InsertedAt     pgtype.Timestamptz
+	UpdatedAt      pgtype.Timestamptz
+	CreatedBy      pgtype.UUID
+	Score          float32
+	TitleSnippet   string
+	SummarySnippet string
+}
+
+// Ranks full-text matches and typo-tolerant title matches together. Snippets
+// wrap matches in U+E000/U+E001 so callers can escape them before marking up.
+func (q *Queries) SearchGames(ctx context.Context, arg SearchGamesParams) ([]SearchGamesRow, error) {
+	rows, err := q.db.Query(ctx, searchGames, arg.Query, arg.ResultLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchGamesRow{}
+	for rows.Next() {
+		var i SearchGamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.Summary,
+			&i.Developer,
+			&i.Publisher,
+			&i.CoverUrl,
+			&i.InsertedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.Score,
+			&i.TitleSnippet,
+			&i.SummarySnippet,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateGame = `-- name: UpdateGame :one
+UPDATE games
+SET title = $2,
+    normalized_title = $3,
+    release_date = $4,
+    summary = $5,
+    developer = $6,
+    publisher = $7,
+    cover_url = $8,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, title, release_date, summary, developer, publisher, cover_url, inserted_at, updated_at, normalized_title, created_by
+`
+
+type UpdateGameParams struct {
+	ID              uuid.UUID
+	Title           string
+	NormalizedTitle string
+	ReleaseDate     pgtype.Date
+	Summary         string
+	Developer       string
+	Publisher       string
+	CoverUrl        string
+}
+
+func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, error) {
+	row := q.db.QueryRow(ctx, updateGame,
+		arg.ID,
+		arg.Title,
+		arg.NormalizedTitle,
+		arg.ReleaseDate,
+		arg.Summary,
+		arg.Developer,
+		arg.Publisher,
+		arg.CoverUrl,
+	)
+	var i Game
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.ReleaseDate,
+		&i.Summary,
+		&i.Developer,
+		&i.Publisher,
+		&i.CoverUrl,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.NormalizedTitle,
+		&i.CreatedBy,
+	)
+	return i, err
+}
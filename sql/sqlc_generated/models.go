@@ -17,9 +17,112 @@ type Account struct {
 	InsertedAt     pgtype.Timestamptz
 	UpdatedAt      pgtype.Timestamptz
 	DeletedAt      pgtype.Timestamptz
+	Role           string
+	DisabledAt     pgtype.Timestamptz
+}
+
+type ExternalID struct {
+	Provider   string
+	ExternalID string
+	GameID     uuid.UUID
+	InsertedAt pgtype.Timestamptz
 }
 
 type Game struct {
-	ID    uuid.UUID
-	Title string
+	ID              uuid.UUID
+	Title           string
+	ReleaseDate     pgtype.Date
+	Summary         string
+	Developer       string
+	Publisher       string
+	CoverUrl        string
+	InsertedAt      pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	NormalizedTitle string
+	CreatedBy       pgtype.UUID
+}
+
+type GameGenre struct {
+	GameID uuid.UUID
+	Genre  string
+}
+
+type GamePlatform struct {
+	GameID   uuid.UUID
+	Platform string
+}
+
+type ImportJob struct {
+	ID                uuid.UUID
+	AccountID         uuid.UUID
+	Source            string
+	ExternalAccountID string
+	Status            string
+	TotalItems        int32
+	ProcessedItems    int32
+	FailedItems       int32
+	Error             string
+	FinishedAt        pgtype.Timestamptz
+	InsertedAt        pgtype.Timestamptz
+	UpdatedAt         pgtype.Timestamptz
+}
+
+type ImportJobItem struct {
+	ID         int64
+	JobID      uuid.UUID
+	ExternalID string
+	Title      string
+	Status     string
+	GameID     pgtype.UUID
+	Error      string
+	InsertedAt pgtype.Timestamptz
+	UpdatedAt  pgtype.Timestamptz
+}
+
+type Job struct {
+	ID          int64
+	Kind        string
+	Payload     []byte
+	Status      string
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       pgtype.Timestamptz
+	LockedAt    pgtype.Timestamptz
+	LastError   string
+	InsertedAt  pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type LibraryEntry struct {
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	GameID          uuid.UUID
+	Status          string
+	InsertedAt      pgtype.Timestamptz
+	UpdatedAt       pgtype.Timestamptz
+	StartedAt       pgtype.Timestamptz
+	FinishedAt      pgtype.Timestamptz
+	Rating          pgtype.Int2
+	Notes           string
+	PlaytimeMinutes int32
+	LastPlayedAt    pgtype.Timestamptz
+}
+
+type LibraryEntryEvent struct {
+	ID         int64
+	EntryID    pgtype.UUID
+	AccountID  uuid.UUID
+	Kind       string
+	FromValue  pgtype.Text
+	ToValue    pgtype.Text
+	InsertedAt pgtype.Timestamptz
+	GameID     pgtype.UUID
+}
+
+type MetadataCache struct {
+	Provider   string
+	ExternalID string
+	Payload    []byte
+	Etag       string
+	FetchedAt  pgtype.Timestamptz
 }
@@ -12,6 +12,18 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countActiveSessions = `-- name: CountActiveSessions :one
+SELECT count(*) FROM sessions
+WHERE expires_at > now()
+`
+
+func (q *Queries) CountActiveSessions(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveSessions)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createSession = `-- name: CreateSession :exec
 INSERT INTO sessions (token, account_id, expires_at)
 VALUES ($1, $2, $3)
@@ -28,6 +40,16 @@ func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) er
 	return err
 }
 
+const deleteAccountSessions = `-- name: DeleteAccountSessions :exec
+DELETE FROM sessions
+WHERE account_id = $1
+`
+
+func (q *Queries) DeleteAccountSessions(ctx context.Context, accountID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteAccountSessions, accountID)
+	return err
+}
+
 const deleteSession = `-- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE token = $1
@@ -38,15 +60,28 @@ func (q *Queries) DeleteSession(ctx context.Context, token string) error {
 	return err
 }
 
-const getSessionAccountID = `-- name: GetSessionAccountID :one
-SELECT account_id FROM sessions
-WHERE token = $1
-  AND expires_at > now()
+const getSessionAccount = `-- name: GetSessionAccount :one
+SELECT accounts.id, accounts.nickname, accounts.email, accounts.hashed_password, accounts.inserted_at, accounts.updated_at, accounts.deleted_at, accounts.role, accounts.disabled_at FROM sessions
+JOIN accounts ON accounts.id = sessions.account_id
+WHERE sessions.token = $1
+  AND sessions.expires_at > now()
+  AND accounts.disabled_at IS NULL
 `
 
-func (q *Queries) GetSessionAccountID(ctx context.Context, token string) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, getSessionAccountID, token)
-	var account_id uuid.UUID
-	err := row.Scan(&account_id)
-	return account_id, err
+// Sessions of disabled accounts are treated as missing.
+func (q *Queries) GetSessionAccount(ctx context.Context, token string) (Account, error) {
+	row := q.db.QueryRow(ctx, getSessionAccount, token)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Nickname,
+		&i.Email,
+		&i.HashedPassword,
+		&i.InsertedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.DisabledAt,
+	)
+	return i, err
 }